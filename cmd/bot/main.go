@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -12,7 +13,9 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dice/hxs_reservation_system/internal/commands"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
 	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
 	"github.com/dice/hxs_reservation_system/internal/storage"
 	"github.com/joho/godotenv"
 )
@@ -25,16 +28,38 @@ const (
 	cleanupHour        = 3
 	cleanupMinute      = 10
 	retentionDays      = 30
+
+	boardRefreshInterval = 5 * time.Minute // 掲示板を予約の変更がなくても更新する間隔（使用中の表示の切り替えなど）
+
+	defaultDigestTime = "08:00" // 朝のダイジェストを投稿する時刻（DIGEST_TIME の既定値）
+
+	weeklyReportHour   = 9 // 週間レポートを投稿する時刻（毎週月曜日）
+	weeklyReportMinute = 0
+)
+
+// 起動時のコマンドの同期方法（--sync-commands）
+const (
+	syncCommandsOff   = "off"   // 同期しない（登録済みのコマンドをそのまま使う）
+	syncCommandsDiff  = "diff"  // 変更があった場合だけ一括上書きする（既定）
+	syncCommandsForce = "force" // 登録済みのコマンドをすべて削除してから登録し直す
 )
 
 var (
 	store                 *storage.Storage
+	registry              *commands.Registry
 	logger                *logging.Logger
 	guildID               string
 	allowedChannelID      string
+	officerChannelID      string
 	startupChannelID      string
 	startupMessage        string
 	processedInteractions sync.Map
+	syncCommandsMode      string
+	boardEnabled          bool
+	board                 *commands.Board
+	digestEnabled         bool
+	digestHour            int
+	digestMinute          int
 )
 
 func init() {
@@ -44,11 +69,46 @@ func init() {
 
 	guildID = os.Getenv("GUILD_ID")
 	allowedChannelID = os.Getenv("ALLOWED_CHANNEL_ID")
+	officerChannelID = os.Getenv("OFFICER_CHANNEL_ID")
 	startupChannelID = os.Getenv("STARTUP_NOTIFICATION_CHANNEL_ID")
 	startupMessage = os.Getenv("STARTUP_NOTIFICATION_MESSAGE")
+	boardEnabled = os.Getenv("BOARD_ENABLED") != "false"
+
+	digestTime := os.Getenv("DIGEST_TIME")
+	if digestTime == "" {
+		digestTime = defaultDigestTime
+	}
+	if digestTime != "off" {
+		t, err := time.Parse("15:04", digestTime)
+		if err != nil {
+			log.Printf("Warning: invalid DIGEST_TIME %q (using default %s)", digestTime, defaultDigestTime)
+			t, _ = time.Parse("15:04", defaultDigestTime)
+		}
+		digestEnabled = true
+		digestHour, digestMinute = t.Hour(), t.Minute()
+	}
+
+	if openingHours := os.Getenv("OPENING_HOURS"); openingHours != "" {
+		if err := schedule.SetOpeningHours(openingHours); err != nil {
+			log.Printf("Warning: %v (using default %s-%s)", err, schedule.OpeningTime, schedule.ClosingTime)
+		}
+	}
+	if closedDays := os.Getenv("CLOSED_DAYS"); closedDays != "" {
+		if err := schedule.SetClosedDays(closedDays); err != nil {
+			log.Printf("Warning: %v (no closed days)", err)
+		}
+	}
 }
 
 func main() {
+	flag.StringVar(&syncCommandsMode, "sync-commands", syncCommandsDiff, "起動時のコマンドの同期方法 (off|diff|force)")
+	flag.Parse()
+	switch syncCommandsMode {
+	case syncCommandsOff, syncCommandsDiff, syncCommandsForce:
+	default:
+		log.Fatalf("Invalid --sync-commands value %q (expected off, diff or force)", syncCommandsMode)
+	}
+
 	initializeServices()
 
 	token := os.Getenv("DISCORD_TOKEN")
@@ -61,6 +121,7 @@ func main() {
 		log.Fatalf("Failed to create Discord session: %v", err)
 	}
 
+	setupBoard(dg)
 	setupHandlers(dg)
 	dg.Identify.Intents = discordgo.IntentsGuildMessages | discordgo.IntentsGuilds
 
@@ -74,8 +135,12 @@ func main() {
 
 	log.Println("Bot is now running. Press CTRL+C to exit.")
 
-	if err := registerCommands(dg); err != nil {
-		log.Fatalf("Failed to register commands: %v", err)
+	if err := syncCommands(dg, syncCommandsMode); err != nil {
+		// 登録済みのコマンドはそのまま使えるため、起動は続ける
+		log.Printf("❌ Failed to sync commands: %v", err)
+		logger.LogError("ERROR", "syncCommands", "Failed to sync commands", err, map[string]interface{}{
+			"mode": syncCommandsMode,
+		})
 	}
 
 	sendStartupNotification(dg)
@@ -93,6 +158,19 @@ func initializeServices() {
 
 	logger = logging.NewLogger("./logs")
 	log.Println("Logger initialized successfully")
+
+	registry = commands.DefaultRegistry()
+}
+
+// setupBoard は ALLOWED_CHANNEL_ID のチャンネルに掲示する予約状況の掲示板を準備する
+// 掲示板を使わない場合は .env の BOARD_ENABLED を false にしてください
+func setupBoard(dg *discordgo.Session) {
+	if allowedChannelID == "" || !boardEnabled {
+		log.Println("Schedule board disabled (ALLOWED_CHANNEL_ID not set or BOARD_ENABLED=false)")
+		return
+	}
+	board = commands.NewBoard(dg, store, logger, allowedChannelID)
+	store.OnChange(board.Notify)
 }
 
 func setupHandlers(dg *discordgo.Session) {
@@ -101,13 +179,23 @@ func setupHandlers(dg *discordgo.Session) {
 			return
 		}
 
-		if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		switch i.Type {
+		case discordgo.InteractionApplicationCommandAutocomplete:
 			commands.HandleAutocomplete(s, i, store)
-			return
+		case discordgo.InteractionMessageComponent:
+			commands.HandleComponent(s, i, store, logger, allowedChannelID)
+		case discordgo.InteractionModalSubmit:
+			commands.HandleModalSubmit(s, i, store, logger, allowedChannelID)
+		default:
+			registry.HandleInteraction(s, i, store, logger, allowedChannelID)
 		}
-
-		commands.HandleInteraction(s, i, store, logger, allowedChannelID)
 	})
+
+	if board != nil {
+		dg.AddHandler(func(s *discordgo.Session, m *discordgo.MessageDelete) {
+			board.HandleMessageDelete(m)
+		})
+	}
 }
 
 func startBackgroundTasks(dg *discordgo.Session) {
@@ -115,6 +203,24 @@ func startBackgroundTasks(dg *discordgo.Session) {
 	go periodicLogCleanup()
 	go dailyAutoComplete()
 	go dailyCleanup()
+	if board != nil {
+		go board.Run(boardRefreshInterval)
+	}
+
+	// 予約の開始前のリマインダー、終了前の確認、終了時刻の自動完了（REMINDER_MINUTES, REMINDER_CHANNEL_PING, END_PROMPT_MINUTES）
+	sessionScheduler := commands.NewSessionScheduler(dg, store, logger, allowedChannelID)
+	store.OnChange(sessionScheduler.Notify)
+	go sessionScheduler.Run()
+
+	// 朝のダイジェスト（DIGEST_TIME）
+	if digestEnabled && allowedChannelID != "" {
+		go dailyDigest(dg)
+	}
+
+	// 役員向けの週間レポート（OFFICER_CHANNEL_ID）
+	if officerChannelID != "" {
+		go weeklyReport(dg)
+	}
 }
 
 func periodicSave(dg *discordgo.Session) {
@@ -139,6 +245,7 @@ func periodicLogCleanup() {
 	}
 }
 
+// dailyAutoComplete は停止中などで終了時刻に完了にできなかった予約を完了にする（終了時刻の完了は SessionScheduler が行う）
 func dailyAutoComplete() {
 	runTaskAtStartup("auto-complete", func() (int, error) {
 		return store.AutoCompleteExpiredReservations()
@@ -163,6 +270,69 @@ func dailyCleanup() {
 	}
 }
 
+// dailyDigest は毎日 DIGEST_TIME に予約チャンネルへ朝のダイジェストを投稿する（休室日は投稿しない）
+// 投稿時刻を過ぎてから起動した場合は、閉室時刻までなら今日の分をすぐに投稿する（投稿済みの場合は投稿しない）
+func dailyDigest(dg *discordgo.Session) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
+	if clock := now.Format("15:04"); clock >= fmt.Sprintf("%02d:%02d", digestHour, digestMinute) && clock < schedule.ClosingTime {
+		postDailyDigest(dg, now)
+	}
+
+	for {
+		time.Sleep(waitUntilTime(digestHour, digestMinute))
+		postDailyDigest(dg, time.Now().In(jst))
+	}
+}
+
+func postDailyDigest(dg *discordgo.Session, now time.Time) {
+	posted, err := commands.PostDailyDigest(dg, store, allowedChannelID, now)
+	switch {
+	case err != nil:
+		log.Printf("❌ Failed to post daily digest: %v", err)
+		logger.LogError("ERROR", "dailyDigest", "Failed to post daily digest", err, map[string]interface{}{
+			"channel_id": allowedChannelID,
+			"date":       now.Format("2006-01-02"),
+		})
+	case posted:
+		log.Printf("✅ Daily digest posted for %s", now.Format("2006-01-02"))
+	default:
+		log.Printf("✓ Daily digest skipped for %s (closed day or already posted)", now.Format("2006-01-02"))
+	}
+}
+
+// weeklyReport は毎週月曜日に役員チャンネルへ先週の週間レポートを投稿する
+// 起動時は、今週の投稿時刻を過ぎていれば先週の分をすぐに投稿する（投稿済みの場合は投稿しない）
+func weeklyReport(dg *discordgo.Session) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
+	if now.Weekday() != time.Monday || now.Hour()*60+now.Minute() >= weeklyReportHour*60+weeklyReportMinute {
+		postWeeklyReport(dg, now)
+	}
+
+	for {
+		time.Sleep(waitUntilTime(weeklyReportHour, weeklyReportMinute))
+		if now := time.Now().In(jst); now.Weekday() == time.Monday {
+			postWeeklyReport(dg, now)
+		}
+	}
+}
+
+func postWeeklyReport(dg *discordgo.Session, now time.Time) {
+	posted, err := commands.PostWeeklyReport(dg, store, logger, officerChannelID, now)
+	switch {
+	case err != nil:
+		log.Printf("❌ Failed to post weekly report: %v", err)
+		logger.LogError("ERROR", "weeklyReport", "Failed to post weekly report", err, map[string]interface{}{
+			"channel_id": officerChannelID,
+		})
+	case posted:
+		log.Println("✅ Weekly report posted")
+	default:
+		log.Println("✓ Weekly report skipped (already posted)")
+	}
+}
+
 func runTaskAtStartup(taskName string, task func() (int, error)) {
 	log.Printf("Startup: Running initial %s check...", taskName)
 	count, err := task()
@@ -231,7 +401,12 @@ func sendStartupNotification(s *discordgo.Session) {
 	}
 
 	// 4. メッセージ送信
-	_, err := s.ChannelMessageSendEmbed(startupChannelID, embed)
+	// 「今すぐ予約」「新しく予約」ボタンは ALLOWED_CHANNEL_ID 以外のチャンネルでは押しても使えないため、同じチャンネルの場合だけ付ける
+	send := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}}
+	if allowedChannelID == "" || startupChannelID == allowedChannelID {
+		send.Components = commands.BoardComponents(i18n.DefaultLocale())
+	}
+	_, err := s.ChannelMessageSendComplex(startupChannelID, send)
 	if err != nil {
 		log.Printf("❌ Failed to send startup notification: %v", err)
 		logger.LogError("ERROR", "sendStartupNotification", "Failed to send startup notification", err, map[string]interface{}{
@@ -288,22 +463,121 @@ func updateBotStatus(s *discordgo.Session, store *storage.Storage) {
 	}
 }
 
-func registerCommands(s *discordgo.Session) error {
-	deleteExistingCommands(s)
-	log.Println("Registering new commands...")
+// syncCommands はレジストリのコマンド定義をDiscordに登録する
+// diff: 登録済みのコマンドと比較し、変更があった場合だけ一括上書きする。
+// 一括上書きでは名前が同じコマンドのIDが変わらないため、管理者が設定したコマンドの権限は保たれる
+// force: 登録済みのコマンドをすべて削除してから登録し直す（表示がおかしい場合の復旧用。コマンドの権限設定は消える）
+func syncCommands(s *discordgo.Session, mode string) error {
+	if mode == syncCommandsOff {
+		log.Println("Command sync disabled (--sync-commands=off)")
+		return nil
+	}
 
-	for _, cmd := range getCommandDefinitions() {
-		if err := createCommand(s, cmd); err != nil {
-			log.Printf("❌ Failed to register command '%s': %v", cmd.Name, err)
-		} else {
-			log.Printf("✅ Registered command: %s", cmd.Name)
+	appID := s.State.User.ID
+	definitions := registry.Definitions()
+
+	if mode == syncCommandsForce {
+		deleteExistingCommands(s)
+		log.Println("Registering commands...")
+		registered, err := s.ApplicationCommandBulkOverwrite(appID, guildID, definitions)
+		if err != nil {
+			return fmt.Errorf("failed to register commands: %w", err)
+		}
+		for _, cmd := range registered {
+			log.Printf("✅ Registered command: %s%s", cmd.Name, commandTypeLabel(cmd.Type))
 		}
+		log.Println("Command registration completed")
+		return nil
+	}
+
+	// GUILD_ID を設定している場合、以前にグローバルに登録したコマンドが残っていると同じコマンドが2つ表示される
+	if guildID != "" {
+		removeGlobalCommands(s)
+	}
+
+	existing, err := s.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch registered commands: %w", err)
+	}
+
+	diff := commands.DiffCommands(definitions, existing, guildID != "")
+	if diff.Empty() {
+		log.Printf("✓ Commands are up to date (%d command(s))", len(existing))
+		return nil
+	}
+	for _, cmd := range diff.Create {
+		log.Printf("➕ New command: %s%s", cmd.Name, commandTypeLabel(cmd.Type))
+	}
+	for _, cmd := range diff.Update {
+		log.Printf("🔄 Changed command: %s%s", cmd.Name, commandTypeLabel(cmd.Type))
+	}
+	for _, cmd := range diff.Delete {
+		log.Printf("➖ Removed command: %s%s", cmd.Name, commandTypeLabel(cmd.Type))
 	}
 
-	log.Println("Command registration completed")
+	if _, err := s.ApplicationCommandBulkOverwrite(appID, guildID, definitions); err != nil {
+		log.Printf("Bulk overwrite failed, applying changes one by one: %v", err)
+		applyCommandDiff(s, diff)
+		return nil
+	}
+	log.Printf("✅ Commands synced: %d created, %d updated, %d deleted", len(diff.Create), len(diff.Update), len(diff.Delete))
 	return nil
 }
 
+// applyCommandDiff は差分のコマンドを1つずつ作成・更新・削除する（一括上書きに失敗した場合に使う）
+func applyCommandDiff(s *discordgo.Session, diff commands.CommandDiff) {
+	appID := s.State.User.ID
+	for _, cmd := range diff.Create {
+		if _, err := s.ApplicationCommandCreate(appID, guildID, cmd); err != nil {
+			log.Printf("❌ Failed to create command '%s': %v", cmd.Name, err)
+		} else {
+			log.Printf("✅ Created command: %s%s", cmd.Name, commandTypeLabel(cmd.Type))
+		}
+	}
+	for _, cmd := range diff.Update {
+		if _, err := s.ApplicationCommandEdit(appID, guildID, cmd.ID, cmd); err != nil {
+			log.Printf("❌ Failed to update command '%s': %v", cmd.Name, err)
+		} else {
+			log.Printf("✅ Updated command: %s%s", cmd.Name, commandTypeLabel(cmd.Type))
+		}
+	}
+	for _, cmd := range diff.Delete {
+		if err := s.ApplicationCommandDelete(appID, guildID, cmd.ID); err != nil {
+			log.Printf("❌ Failed to delete command '%s': %v", cmd.Name, err)
+		} else {
+			log.Printf("✅ Deleted command: %s%s", cmd.Name, commandTypeLabel(cmd.Type))
+		}
+	}
+}
+
+// removeGlobalCommands はグローバルに登録されているコマンドをすべて削除する（GUILD_ID を設定している場合に使う）
+func removeGlobalCommands(s *discordgo.Session) {
+	globalCommands, err := s.ApplicationCommands(s.State.User.ID, "")
+	if err != nil {
+		log.Printf("Failed to fetch existing global commands: %v", err)
+		return
+	}
+	if len(globalCommands) == 0 {
+		return
+	}
+	if _, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, "", []*discordgo.ApplicationCommand{}); err != nil {
+		log.Printf("Failed to remove global commands: %v", err)
+		return
+	}
+	log.Printf("Removed %d global command(s) (GUILD_ID is set)", len(globalCommands))
+}
+
+// commandTypeLabel はログに表示するコマンドの種類を返す（スラッシュコマンドの場合は空）
+func commandTypeLabel(commandType discordgo.ApplicationCommandType) string {
+	switch commandType {
+	case discordgo.UserApplicationCommand:
+		return " (user command)"
+	case discordgo.MessageApplicationCommand:
+		return " (message command)"
+	}
+	return ""
+}
+
 func deleteExistingCommands(s *discordgo.Session) {
 	log.Println("Removing existing commands...")
 
@@ -335,152 +609,3 @@ func deleteExistingCommands(s *discordgo.Session) {
 		}
 	}
 }
-
-func createCommand(s *discordgo.Session, cmd *discordgo.ApplicationCommand) error {
-	if guildID != "" {
-		_, err := s.ApplicationCommandCreate(s.State.User.ID, guildID, cmd)
-		return err
-	}
-	_, err := s.ApplicationCommandCreate(s.State.User.ID, "", cmd)
-	return err
-}
-
-func getCommandDefinitions() []*discordgo.ApplicationCommand {
-	return []*discordgo.ApplicationCommand{
-		{
-			Name:        "reserve",
-			Description: "部室の予約を作成します",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "date",
-					Description:  "予約日（YYYY-MM-DD または YYYY/MM/DD、例: 2025-10-15 または 2025/10/15）",
-					Required:     true,
-					Autocomplete: true,
-				},
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "start_time",
-					Description:  "開始時間（HH:MM形式、例: 14:00）",
-					Required:     true,
-					Autocomplete: true,
-				},
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "end_time",
-					Description:  "終了時間（HH:MM形式、例: 15:00）※省略時は開始時刻+1時間",
-					Required:     false,
-					Autocomplete: true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "comment",
-					Description: "コメント（任意）",
-					Required:    false,
-				},
-			},
-		},
-		{
-			Name:        "cancel",
-			Description: "予約を取り消します",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "reservation_id",
-					Description:  "予約ID",
-					Required:     true,
-					Autocomplete: true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "comment",
-					Description: "コメント（任意）",
-					Required:    false,
-				},
-			},
-		},
-		{
-			Name:        "complete",
-			Description: "予約を完了にします",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "reservation_id",
-					Description:  "予約ID",
-					Required:     true,
-					Autocomplete: true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "comment",
-					Description: "コメント（任意）",
-					Required:    false,
-				},
-			},
-		},
-		{
-			Name:        "edit",
-			Description: "予約を編集します",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "reservation_id",
-					Description:  "予約ID",
-					Required:     true,
-					Autocomplete: true,
-				},
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "date",
-					Description:  "新しい予約日（YYYY-MM-DD または YYYY/MM/DD）※変更しない場合は省略",
-					Required:     false,
-					Autocomplete: true,
-				},
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "start_time",
-					Description:  "新しい開始時間（HH:MM形式）※変更しない場合は省略",
-					Required:     false,
-					Autocomplete: true,
-				},
-				{
-					Type:         discordgo.ApplicationCommandOptionString,
-					Name:         "end_time",
-					Description:  "新しい終了時間（HH:MM形式）※変更しない場合は省略",
-					Required:     false,
-					Autocomplete: true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "comment",
-					Description: "新しいコメント（※変更しない場合は省略）",
-					Required:    false,
-				},
-			},
-		},
-		{
-			Name:        "list",
-			Description: "すべての予約を表示します（自分だけに表示されます）",
-		},
-		{
-			Name:        "my-reservations",
-			Description: "自分の予約を表示します（自分だけに表示されます）",
-		},
-		{
-			Name:        "help",
-			Description: "ヘルプメッセージを表示します（自分だけに表示されます）",
-		},
-		{
-			Name:        "feedback",
-			Description: "システムへのご意見・ご要望を匿名で送信します",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "message",
-					Description: "フィードバック内容",
-					Required:    true,
-				},
-			},
-		},
-	}
-}
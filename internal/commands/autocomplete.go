@@ -2,23 +2,35 @@ package commands
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
 	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
 	"github.com/dice/hxs_reservation_system/internal/storage"
 )
 
 // HandleAutocomplete はオートコンプリートのリクエストを処理する
 func HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage) {
 	data := i.ApplicationCommandData()
+	applyUserLocale(i, store)
+
+	// サブコマンドの場合はサブコマンド内のオプションを対象にする
+	options := data.Options
+	subcommandName := ""
+	if len(options) > 0 && options[0].Type == discordgo.ApplicationCommandOptionSubCommand {
+		subcommandName = options[0].Name
+		options = options[0].Options
+	}
 
 	// 現在フォーカスされているオプションを取得
 	var focusedOption *discordgo.ApplicationCommandInteractionDataOption
-	for _, opt := range data.Options {
+	for _, opt := range options {
 		if opt.Focused {
 			focusedOption = opt
 			break
@@ -36,19 +48,27 @@ func HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, st
 
 	switch focusedOption.Name {
 	case "date":
-		choices = getDateSuggestions(focusedOption.StringValue())
-	case "start_time":
-		choices = getTimeSuggestions(focusedOption.StringValue(), "")
-	case "end_time":
-		// end_timeの場合、start_timeを取得して考慮する
-		var startTime string
-		for _, opt := range data.Options {
-			if opt.Name == "start_time" {
-				startTime = opt.StringValue()
+		choices = getDateSuggestions(lang(i), focusedOption.StringValue(), store.GetAllReservations())
+	case "from", "to":
+		// 期間の指定では空き状況は表示しない
+		choices = getDateSuggestions(lang(i), focusedOption.StringValue(), nil)
+	case "start_time", "end_time":
+		// 予約日の空き状況に合わせて候補を表示する（end_time は start_time から次の予約まで）
+		q := slotContext(i, store, commandName, options)
+		choices = getTimeSuggestions(lang(i), q, focusedOption.StringValue(), focusedOption.Name == "end_time")
+	case "duration":
+		choices = getDurationSuggestions(lang(i), slotContext(i, store, commandName, options), focusedOption.StringValue())
+	case "by":
+		// 予約IDが入力済みなら、その予約で選べる長さだけを表示する
+		var reservationID string
+		for _, opt := range options {
+			if opt.Name == "reservation_id" {
+				reservationID = opt.StringValue()
 				break
 			}
 		}
-		choices = getTimeSuggestions(focusedOption.StringValue(), startTime)
+		userID, _ := getUserInfo(i, i.GuildID == "")
+		choices = getExtendSuggestions(lang(i), store, reservationID, userID, focusedOption.StringValue())
 	case "reservation_id":
 		// ユーザーIDを取得
 		var userID string
@@ -59,8 +79,15 @@ func HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, st
 		}
 
 		// コマンドに応じて候補を生成
-		if commandName == "cancel" || commandName == "complete" || commandName == "edit" {
+		if commandName == "cancel" || commandName == "complete" || commandName == "edit" || commandName == "extend" || commandName == "transfer" {
 			choices = getReservationSuggestions(store, userID, "pending", focusedOption.StringValue())
+		} else if commandName == "admin" && isAdmin(i) {
+			// 管理者コマンドではすべてのユーザーの予約を検索する
+			statuses := []models.ReservationStatus{models.StatusPending}
+			if subcommandName == "reopen" {
+				statuses = []models.ReservationStatus{models.StatusCompleted, models.StatusCancelled}
+			}
+			choices = getAdminReservationSuggestions(lang(i), store, statuses, focusedOption.StringValue())
 		}
 	}
 
@@ -82,22 +109,142 @@ func HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, st
 	}
 }
 
-// getWeekdayJa は日本語の曜日を返す
-func getWeekdayJa(t time.Time) string {
-	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
-	return weekdays[int(t.Weekday())]
-}
-
-// formatDateWithWeekday は日付を曜日付きでフォーマットする
-func formatDateWithWeekday(t time.Time) string {
-	return fmt.Sprintf("%s (%s)", t.Format("2006/01/02"), getWeekdayJa(t))
+// formatDateWithWeekday は日付を曜日付きでフォーマットする（例: 2025/11/20 (木)）
+func formatDateWithWeekday(locale string, t time.Time) string {
+	return fmt.Sprintf("%s (%s)", t.Format("2006/01/02"), i18n.Weekday(locale, t.Weekday()))
 }
 
 // getDateSuggestions は日付の候補を生成する
-func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoice {
-	now := time.Now()
+// 「明日」「来週の火曜」のような入力は、解釈した日付を先頭の候補として表示する
+// reservations を渡した場合は各日付に空き状況を付け、「空き」「free」と入力すると空きのある日だけを表示する
+func getDateSuggestions(locale, input string, reservations []*models.Reservation) []*discordgo.ApplicationCommandOptionChoice {
 	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
-	nowJST := now.In(jst)
+	nowJST := time.Now().In(jst)
+
+	if reservations != nil && isFreeOnlyInput(input) {
+		return freeDateCandidates(locale, reservations, nowJST)
+	}
+
+	suggestions := dateCandidates(locale, input, nowJST)
+	if choice := interpretedDateChoice(locale, input, nowJST); choice != nil {
+		suggestions = append([]*discordgo.ApplicationCommandOptionChoice{choice}, suggestions...)
+	}
+	if reservations != nil {
+		annotateOccupancy(locale, suggestions, reservations, nowJST)
+	}
+	return suggestions
+}
+
+// isFreeOnlyInput は入力が空きのある日だけを表示する指定（各言語の date.free_keyword で始まる）かどうかを返す
+func isFreeOnlyInput(input string) bool {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return false
+	}
+	for _, locale := range i18n.Locales() {
+		if strings.HasPrefix(input, strings.ToLower(i18n.T(locale, "date.free_keyword"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// freeDateCandidates は今日から30日後までのうち、空き時間のある日を空き状況付きの候補にする
+func freeDateCandidates(locale string, reservations []*models.Reservation, nowJST time.Time) []*discordgo.ApplicationCommandOptionChoice {
+	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
+	for offset := 0; offset <= 30 && len(suggestions) < 25; offset++ {
+		day := nowJST.AddDate(0, 0, offset)
+		label, free := occupancyLabel(locale, reservations, day.Format("2006-01-02"), nowJST)
+		if !free {
+			continue
+		}
+		suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateText(fmt.Sprintf("%s — %s", formatDateWithWeekday(locale, day), label), 100),
+			Value: day.Format("2006/01/02"),
+		})
+	}
+	return suggestions
+}
+
+// annotateOccupancy は日付の候補の表示名に空き状況（「空きあり 6h」「満室」「休室」など）を付ける
+// 過去の日付には付けない
+func annotateOccupancy(locale string, choices []*discordgo.ApplicationCommandOptionChoice, reservations []*models.Reservation, nowJST time.Time) {
+	for _, choice := range choices {
+		value, _ := choice.Value.(string)
+		if len(value) < len("2006/01/02") {
+			continue
+		}
+		day, err := time.Parse("2006/01/02", value[:len("2006/01/02")])
+		if err != nil {
+			continue
+		}
+		date := day.Format("2006-01-02")
+		if date < nowJST.Format("2006-01-02") {
+			continue
+		}
+		label, _ := occupancyLabel(locale, reservations, date, nowJST)
+		choice.Name = truncateText(fmt.Sprintf("%s — %s", choice.Name, label), 100)
+	}
+}
+
+// occupancyLabel は指定日（YYYY-MM-DD形式）の空き状況の表示と、空き時間があるかどうかを返す
+// 今日の場合は現在時刻以降の空き時間を数える
+func occupancyLabel(locale string, reservations []*models.Reservation, date string, nowJST time.Time) (string, bool) {
+	if reason, closed := schedule.ClosedOn(date); closed {
+		return closedLabel(locale, reason), false
+	}
+
+	earliest := ""
+	if date == nowJST.Format("2006-01-02") {
+		earliest = schedule.RoundUpToSlot(nowJST.Format("15:04"))
+		if earliest >= schedule.ClosingTime {
+			return i18n.T(locale, "date.no_more_today"), false
+		}
+	}
+
+	free := schedule.FreeTime(reservations, date, earliest)
+	if free <= 0 {
+		return i18n.T(locale, "date.full"), false
+	}
+	hours := strconv.FormatFloat(math.Round(free.Hours()*10)/10, 'f', -1, 64)
+	return i18n.T(locale, "date.free", hours), true
+}
+
+// closedLabel は休室日の表示（理由がある場合は理由付き）を返す
+func closedLabel(locale, reason string) string {
+	if reason != "" {
+		return i18n.T(locale, "date.closed_reason", reason)
+	}
+	return i18n.T(locale, "date.closed")
+}
+
+// interpretedDateChoice は日付の入力を解釈した結果を「明日 → 2025/11/21 (金)」の形式の候補にする
+// 時刻を含む場合は値にも時刻を含める。解釈できない場合や入力が解釈結果と同じ場合は nil を返す
+func interpretedDateChoice(locale, input string, now time.Time) *discordgo.ApplicationCommandOptionChoice {
+	input = strings.TrimSpace(input)
+	_, parsed, clock, err := parseDateTimeInput(input, now)
+	if err != nil {
+		return nil
+	}
+
+	value := parsed.Format("2006/01/02")
+	label := formatDateWithWeekday(locale, parsed)
+	if clock != "" {
+		value += " " + clock
+		label += " " + clock
+	}
+	if input == value {
+		return nil
+	}
+	return &discordgo.ApplicationCommandOptionChoice{
+		Name:  truncateText(fmt.Sprintf("%s → %s", input, label), 100),
+		Value: value,
+	}
+}
+
+// dateCandidates は入力に応じた日付の候補の一覧を生成する
+func dateCandidates(locale, input string, nowJST time.Time) []*discordgo.ApplicationCommandOptionChoice {
+	jst := nowJST.Location()
 
 	// 入力が空の場合
 	if input == "" {
@@ -106,9 +253,9 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 		dayAfterTomorrow := nowJST.AddDate(0, 0, 2)
 
 		suggestions := []*discordgo.ApplicationCommandOptionChoice{
-			{Name: fmt.Sprintf("今日 %s (%s)", today.Format("2006/01/02"), getWeekdayJa(today)), Value: today.Format("2006/01/02")},
-			{Name: fmt.Sprintf("明日 %s (%s)", tomorrow.Format("2006/01/02"), getWeekdayJa(tomorrow)), Value: tomorrow.Format("2006/01/02")},
-			{Name: fmt.Sprintf("明後日 %s (%s)", dayAfterTomorrow.Format("2006/01/02"), getWeekdayJa(dayAfterTomorrow)), Value: dayAfterTomorrow.Format("2006/01/02")},
+			{Name: i18n.T(locale, "date.today", formatDateWithWeekday(locale, today)), Value: today.Format("2006/01/02")},
+			{Name: i18n.T(locale, "date.tomorrow", formatDateWithWeekday(locale, tomorrow)), Value: tomorrow.Format("2006/01/02")},
+			{Name: i18n.T(locale, "date.day_after_tomorrow", formatDateWithWeekday(locale, dayAfterTomorrow)), Value: dayAfterTomorrow.Format("2006/01/02")},
 		}
 
 		// 3日後から30日後まで
@@ -117,13 +264,13 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 				week := i / 7
 				futureDate := nowJST.AddDate(0, 0, i)
 				suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
-					Name:  fmt.Sprintf("%d週間後 %s (%s)", week, futureDate.Format("2006/01/02"), getWeekdayJa(futureDate)),
+					Name:  i18n.T(locale, "date.weeks_later", week, formatDateWithWeekday(locale, futureDate)),
 					Value: futureDate.Format("2006/01/02"),
 				})
 			} else {
 				futureDate := nowJST.AddDate(0, 0, i)
 				suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
-					Name:  formatDateWithWeekday(futureDate),
+					Name:  formatDateWithWeekday(locale, futureDate),
 					Value: futureDate.Format("2006/01/02"),
 				})
 			}
@@ -143,7 +290,7 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 				for day := 1; day <= daysInMonth && len(suggestions) < 25; day++ {
 					dateTime := time.Date(targetYear, time.Month(monthNum), day, 0, 0, 0, 0, jst)
 					suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
-						Name:  formatDateWithWeekday(dateTime),
+						Name:  formatDateWithWeekday(locale, dateTime),
 						Value: dateTime.Format("2006/01/02"),
 					})
 				}
@@ -171,7 +318,7 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 				for day := 1; day <= 7 && len(suggestions) < 25; day++ {
 					dateTime := time.Date(fullYear, time.Month(month), day, 0, 0, 0, 0, jst)
 					suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
-						Name:  formatDateWithWeekday(dateTime),
+						Name:  formatDateWithWeekday(locale, dateTime),
 						Value: dateTime.Format("2006/01/02"),
 					})
 				}
@@ -204,7 +351,7 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 				if dayNum <= daysInMonth {
 					dateTime := time.Date(targetYear, time.Month(targetMonth), dayNum, 0, 0, 0, 0, jst)
 					suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
-						Name:  formatDateWithWeekday(dateTime),
+						Name:  formatDateWithWeekday(locale, dateTime),
 						Value: dateTime.Format("2006/01/02"),
 					})
 				}
@@ -222,9 +369,9 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 	dayAfterTomorrow := nowJST.AddDate(0, 0, 2)
 
 	allSuggestions := []*discordgo.ApplicationCommandOptionChoice{
-		{Name: fmt.Sprintf("今日 %s (%s)", today.Format("2006/01/02"), getWeekdayJa(today)), Value: today.Format("2006/01/02")},
-		{Name: fmt.Sprintf("明日 %s (%s)", tomorrow.Format("2006/01/02"), getWeekdayJa(tomorrow)), Value: tomorrow.Format("2006/01/02")},
-		{Name: fmt.Sprintf("明後日 %s (%s)", dayAfterTomorrow.Format("2006/01/02"), getWeekdayJa(dayAfterTomorrow)), Value: dayAfterTomorrow.Format("2006/01/02")},
+		{Name: i18n.T(locale, "date.today", formatDateWithWeekday(locale, today)), Value: today.Format("2006/01/02")},
+		{Name: i18n.T(locale, "date.tomorrow", formatDateWithWeekday(locale, tomorrow)), Value: tomorrow.Format("2006/01/02")},
+		{Name: i18n.T(locale, "date.day_after_tomorrow", formatDateWithWeekday(locale, dayAfterTomorrow)), Value: dayAfterTomorrow.Format("2006/01/02")},
 	}
 
 	for i := 3; i <= 30; i++ {
@@ -232,13 +379,13 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 			week := i / 7
 			futureDate := nowJST.AddDate(0, 0, i)
 			allSuggestions = append(allSuggestions, &discordgo.ApplicationCommandOptionChoice{
-				Name:  fmt.Sprintf("%d週間後 %s (%s)", week, futureDate.Format("2006/01/02"), getWeekdayJa(futureDate)),
+				Name:  i18n.T(locale, "date.weeks_later", week, formatDateWithWeekday(locale, futureDate)),
 				Value: futureDate.Format("2006/01/02"),
 			})
 		} else {
 			futureDate := nowJST.AddDate(0, 0, i)
 			allSuggestions = append(allSuggestions, &discordgo.ApplicationCommandOptionChoice{
-				Name:  formatDateWithWeekday(futureDate),
+				Name:  formatDateWithWeekday(locale, futureDate),
 				Value: futureDate.Format("2006/01/02"),
 			})
 		}
@@ -262,36 +409,22 @@ func getDateSuggestions(input string) []*discordgo.ApplicationCommandOptionChoic
 	return allSuggestions
 }
 
-// getTimeSuggestions は時刻の候補を生成する
-func getTimeSuggestions(input string, startTime string) []*discordgo.ApplicationCommandOptionChoice {
-	// 9:00から21:00まで30分刻みで候補を生成
-	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
-	for hour := 9; hour <= 21; hour++ {
-		for _, minute := range []int{0, 30} {
-			if hour == 21 && minute == 30 {
-				break // 21:00で終了
-			}
-			timeStr := fmt.Sprintf("%02d:%02d", hour, minute)
-			suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
-				Name:  timeStr,
-				Value: timeStr,
-			})
-		}
-	}
+// getTimeSuggestions は開始時間（endTime が true の場合は終了時間）の候補を生成する
+// 「14時半」「3pm」のような入力は、解釈した時刻を先頭の候補として表示する
+func getTimeSuggestions(locale string, q slotQuery, input string, endTime bool) []*discordgo.ApplicationCommandOptionChoice {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
 
-	// end_timeの場合、start_timeより後の時刻のみフィルタリング
-	if startTime != "" {
-		var filtered []*discordgo.ApplicationCommandOptionChoice
-		for _, choice := range suggestions {
-			if choice.Value.(string) > startTime {
-				filtered = append(filtered, choice)
-			}
-		}
-		suggestions = filtered
+	var suggestions []*discordgo.ApplicationCommandOptionChoice
+	if endTime {
+		suggestions = endTimeCandidates(locale, q, now)
+	} else {
+		suggestions = startTimeCandidates(locale, q, now)
 	}
 
-	// 入力がある場合、さらにフィルタリング
+	input = strings.TrimSpace(input)
 	if input != "" {
+		// 入力で始まる時刻に絞り込む（該当しない場合はすべて表示）
 		var filtered []*discordgo.ApplicationCommandOptionChoice
 		for _, choice := range suggestions {
 			if strings.HasPrefix(choice.Value.(string), input) {
@@ -299,13 +432,259 @@ func getTimeSuggestions(input string, startTime string) []*discordgo.Application
 			}
 		}
 		if len(filtered) > 0 {
-			return filtered
+			suggestions = filtered
+		}
+	}
+
+	if clock, err := parseTimeInput(input); err == nil && clock != input {
+		choice := &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateText(fmt.Sprintf("%s → %s", input, clock), 100),
+			Value: clock,
+		}
+		suggestions = append([]*discordgo.ApplicationCommandOptionChoice{choice}, suggestions...)
+	}
+	return suggestions
+}
+
+// startTimeCandidates は開室時間内の予約枠の刻みの開始時間の候補を生成する
+// 予約日が分かる場合は、当日の過ぎた時刻を除き、予約が入っている時間帯は予約ごとに1件だけ予約者付きで表示する
+func startTimeCandidates(locale string, q slotQuery, now time.Time) []*discordgo.ApplicationCommandOptionChoice {
+	times := slotTimes(schedule.OpeningTime, schedule.ClosingTime)
+	if len(times) > 0 && times[len(times)-1] == schedule.ClosingTime {
+		// 閉室時刻からは予約できない
+		times = times[:len(times)-1]
+	}
+
+	date, _, _, err := parseDateTimeInput(q.Date, now)
+	if err != nil {
+		return plainTimeChoices(times)
+	}
+
+	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
+	shown := make(map[string]bool)
+	for _, clock := range times {
+		if date == now.Format("2006-01-02") && clock < now.Format("15:04") {
+			continue
+		}
+		r := schedule.ReservationAt(q.Reservations, date, clock)
+		if r == nil {
+			suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{Name: clock, Value: clock})
+			continue
+		}
+		if shown[r.ID] {
+			continue
+		}
+		shown[r.ID] = true
+		suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateText(i18n.T(locale, "time.taken", clock, r.Username, r.StartTime, r.EndTime), 100),
+			Value: clock,
+		})
+	}
+	return suggestions
+}
+
+// endTimeCandidates は開始時間より後の終了時間の候補を生成する
+// 予約日と開始時間が分かる場合は、開始時間から次の予約の開始時刻（または閉室時刻）までに限る
+func endTimeCandidates(locale string, q slotQuery, now time.Time) []*discordgo.ApplicationCommandOptionChoice {
+	date, _, clock, dateErr := parseDateTimeInput(q.Date, now)
+	startTime, timeErr := parseTimeInput(q.StartTime)
+	if q.StartTime == "" && clock != "" {
+		startTime, timeErr = clock, nil
+	}
+	if timeErr != nil {
+		return plainTimeChoices(slotTimes(schedule.OpeningTime, schedule.ClosingTime))
+	}
+
+	if dateErr != nil {
+		var times []string
+		for _, t := range slotTimes(schedule.OpeningTime, schedule.ClosingTime) {
+			if t > startTime {
+				times = append(times, t)
+			}
 		}
+		return plainTimeChoices(times)
+	}
+
+	freeUntil, ok := schedule.FreeUntil(q.Reservations, date, startTime)
+	if !ok {
+		// 開始時間が予約と重なる・開室時間外の場合は候補なし
+		return []*discordgo.ApplicationCommandOptionChoice{}
 	}
 
+	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, t := range slotTimes(startTime, freeUntil) {
+		if t > startTime && t != freeUntil {
+			suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{Name: t, Value: t})
+		}
+	}
+	// 空き時間の最後は、予約枠の刻みでなくても候補にする
+	last := &discordgo.ApplicationCommandOptionChoice{Name: freeUntil, Value: freeUntil}
+	if freeUntil < schedule.ClosingTime {
+		last.Name = i18n.T(locale, "time.until_next", freeUntil)
+	}
+	return append(suggestions, last)
+}
+
+// slotTimes は from から to まで（両端を含む）の予約枠の刻みの時刻を返す
+func slotTimes(from, to string) []string {
+	var times []string
+	for minutes := schedule.ToMinutes(schedule.RoundUpToSlot(from)); minutes <= schedule.ToMinutes(to); minutes += schedule.SlotMinutes {
+		times = append(times, schedule.FromMinutes(minutes))
+	}
+	return times
+}
+
+// plainTimeChoices は時刻をそのまま候補にする
+func plainTimeChoices(times []string) []*discordgo.ApplicationCommandOptionChoice {
+	suggestions := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(times))
+	for _, t := range times {
+		suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{Name: t, Value: t})
+	}
 	return suggestions
 }
 
+// commonDurations はオートコンプリートで提案する利用時間
+var commonDurations = []time.Duration{
+	30 * time.Minute, time.Hour, 90 * time.Minute, 2 * time.Hour, 150 * time.Minute,
+	3 * time.Hour, 4 * time.Hour, 5 * time.Hour, 6 * time.Hour,
+}
+
+// slotQuery は時刻・利用時間の候補を空き状況で絞り込むための入力中の値を表す
+type slotQuery struct {
+	Reservations []*models.Reservation // 重なりを調べる予約（編集中の予約は除く）
+	Date         string                // 予約日（正規化前の入力値）
+	StartTime    string                // 開始時間（正規化前の入力値）
+}
+
+// slotContext は入力中のオプションから時刻・利用時間の候補を絞り込む条件を組み立てる
+// /edit（/admin edit）で日付や開始時間を指定していない場合は、編集する予約の現在の値を使う
+// 編集する予約は空き状況の計算から除く（自分の予約とは重ならない）
+func slotContext(i *discordgo.InteractionCreate, store *storage.Storage, commandName string, options []*discordgo.ApplicationCommandInteractionDataOption) slotQuery {
+	var q slotQuery
+	excludeID := ""
+	for _, opt := range options {
+		switch opt.Name {
+		case "date":
+			q.Date = opt.StringValue()
+		case "start_time":
+			q.StartTime = opt.StringValue()
+		case "reservation_id":
+			excludeID = opt.StringValue()
+		}
+	}
+
+	if commandName == "reserve-now" {
+		// 今すぐ予約する場合は、現在時刻から始まる時間帯の空きで絞り込む
+		jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+		now := time.Now().In(jst)
+		q.Date = now.Format("2006/01/02")
+		q.StartTime = reserveNowStart(store.GetAllReservations(), now)
+	}
+
+	if (commandName == "edit" || commandName == "admin") && excludeID != "" {
+		userID, _ := getUserInfo(i, i.GuildID == "")
+		if r, err := store.GetReservation(excludeID); err == nil && (r.UserID == userID || (commandName == "admin" && isAdmin(i))) {
+			if q.Date == "" {
+				q.Date = r.Date
+			}
+			if q.StartTime == "" {
+				q.StartTime = r.StartTime
+			}
+		}
+	}
+
+	for _, r := range store.GetAllReservations() {
+		if r.ID != excludeID {
+			q.Reservations = append(q.Reservations, r)
+		}
+	}
+	return q
+}
+
+// getDurationSuggestions は利用時間の候補を生成する
+// 予約日と開始時間が分かる場合は、開始時間から空いている長さの候補だけを終了時刻付きで表示する
+func getDurationSuggestions(locale string, q slotQuery, input string) []*discordgo.ApplicationCommandOptionChoice {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	date, _, clock, dateErr := parseDateTimeInput(q.Date, time.Now().In(jst))
+	startTime, timeErr := parseTimeInput(q.StartTime)
+	if q.StartTime == "" && clock != "" {
+		startTime, timeErr = clock, nil
+	}
+
+	// 開始時間から空いている長さ（分）。分からない場合は絞り込まない
+	freeMinutes := -1
+	if dateErr == nil && timeErr == nil {
+		freeUntil, ok := schedule.FreeUntil(q.Reservations, date, startTime)
+		if !ok {
+			return []*discordgo.ApplicationCommandOptionChoice{}
+		}
+		freeMinutes = schedule.ToMinutes(freeUntil) - schedule.ToMinutes(startTime)
+	}
+
+	durationChoice := func(label string, d time.Duration) *discordgo.ApplicationCommandOptionChoice {
+		name := label
+		if freeMinutes >= 0 {
+			end := schedule.FromMinutes(schedule.ToMinutes(startTime) + int(d/time.Minute))
+			name = i18n.T(locale, "duration.until", label, end)
+			if int(d/time.Minute) > freeMinutes {
+				name = i18n.T(locale, "duration.until_overlapping", label, end)
+			}
+		}
+		return &discordgo.ApplicationCommandOptionChoice{Name: truncateText(name, 100), Value: durationValue(d)}
+	}
+
+	var suggestions []*discordgo.ApplicationCommandOptionChoice
+	for _, d := range commonDurations {
+		if freeMinutes < 0 || int(d/time.Minute) <= freeMinutes {
+			suggestions = append(suggestions, durationChoice(formatDuration(locale, d), d))
+		}
+	}
+
+	// 空き時間の最後までの長さが一般的な長さと異なる場合は候補に加える
+	if freeMinutes > 0 {
+		longest := time.Duration(freeMinutes) * time.Minute
+		if len(suggestions) == 0 || suggestions[len(suggestions)-1].Value != durationValue(longest) {
+			suggestions = append(suggestions, durationChoice(i18n.T(locale, "duration.until_free_end", formatDuration(locale, longest)), longest))
+		}
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return suggestions
+	}
+
+	// 入力を解釈できる場合は、解釈した長さを先頭に表示する
+	if d, err := parseDurationInput(input); err == nil {
+		choice := durationChoice(fmt.Sprintf("%s → %s", input, formatDuration(locale, d)), d)
+		return append([]*discordgo.ApplicationCommandOptionChoice{choice}, suggestions...)
+	}
+
+	var filtered []*discordgo.ApplicationCommandOptionChoice
+	for _, choice := range suggestions {
+		if strings.HasPrefix(choice.Value.(string), input) || strings.Contains(choice.Name, input) {
+			filtered = append(filtered, choice)
+		}
+	}
+	if len(filtered) > 0 {
+		return filtered
+	}
+	return suggestions
+}
+
+// durationValue は時間の長さを 1h30m のような入力値の形式にする
+func durationValue(d time.Duration) string {
+	hours := int(d / time.Hour)
+	minutes := int(d/time.Minute) % 60
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
 // getReservationSuggestions はユーザーの予約候補を生成する
 func getReservationSuggestions(store *storage.Storage, userID string, status string, input string) []*discordgo.ApplicationCommandOptionChoice {
 	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
@@ -360,3 +739,34 @@ func getReservationSuggestions(store *storage.Storage, userID string, status str
 
 	return suggestions
 }
+
+// getAdminReservationSuggestions はすべてのユーザーの予約から候補を生成する（管理者用）
+func getAdminReservationSuggestions(locale string, store *storage.Storage, statuses []models.ReservationStatus, input string) []*discordgo.ApplicationCommandOptionChoice {
+	suggestions := []*discordgo.ApplicationCommandOptionChoice{}
+	reservations := store.FindReservations(storage.ReservationFilter{Statuses: statuses})
+
+	// 新しい予約を優先して表示
+	for idx := len(reservations) - 1; idx >= 0; idx-- {
+		r := reservations[idx]
+		name := fmt.Sprintf("%s %s-%s %s", formatDate(r.Date), r.StartTime, r.EndTime, r.Username)
+		if r.Status != models.StatusPending {
+			name = fmt.Sprintf("%s [%s]", name, statusLabel(locale, r.Status))
+		}
+		if len([]rune(name)) > 100 {
+			name = string([]rune(name)[:100])
+		}
+
+		if input == "" || strings.Contains(r.ID, input) || strings.Contains(strings.ToLower(name), strings.ToLower(input)) {
+			suggestions = append(suggestions, &discordgo.ApplicationCommandOptionChoice{
+				Name:  name,
+				Value: r.ID,
+			})
+		}
+
+		if len(suggestions) >= 25 {
+			break
+		}
+	}
+
+	return suggestions
+}
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+func TestInterpretedDateChoice(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, jst)
+
+	choice := interpretedDateChoice("ja", "来週の火曜", now)
+	if choice == nil || choice.Name != "来週の火曜 → 2025/11/25 (火)" || choice.Value != "2025/11/25" {
+		t.Errorf("Unexpected choice: %+v", choice)
+	}
+
+	// 時刻を含む場合は値にも時刻を含める
+	choice = interpretedDateChoice("ja", "tomorrow 3pm", now)
+	if choice == nil || choice.Value != "2025/11/21 15:00" {
+		t.Errorf("Unexpected choice: %+v", choice)
+	}
+
+	// 解釈できない入力や、解釈結果と同じ入力には候補を追加しない
+	if choice := interpretedDateChoice("ja", "someday", now); choice != nil {
+		t.Errorf("Expected no choice, got %+v", choice)
+	}
+	if choice := interpretedDateChoice("ja", "2025/11/21", now); choice != nil {
+		t.Errorf("Expected no choice, got %+v", choice)
+	}
+}
+
+func TestOccupancyLabels(t *testing.T) {
+	if err := schedule.SetClosedDays("sun,2025-11-24=勤労感謝の日"); err != nil {
+		t.Fatal(err)
+	}
+	defer schedule.SetClosedDays("")
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 18, 10, 0, 0, jst) // 木曜日
+	reservations := []*models.Reservation{
+		{ID: "r1", Date: "2025-11-21", StartTime: "09:00", EndTime: "21:00", Status: models.StatusPending},
+		{ID: "r2", Date: "2025-11-22", StartTime: "09:00", EndTime: "15:00", Status: models.StatusPending},
+		{ID: "r3", Date: "2025-11-20", StartTime: "19:00", EndTime: "20:00", Status: models.StatusPending},
+	}
+
+	tests := []struct {
+		date  string
+		label string
+		free  bool
+	}{
+		{"2025-11-20", "空きあり 1.5h", true}, // 今日は 18:30 以降の空き
+		{"2025-11-21", "満室", false},
+		{"2025-11-22", "空きあり 6h", true},
+		{"2025-11-23", "休室", false},
+		{"2025-11-24", "休室（勤労感謝の日）", false},
+	}
+	for _, tt := range tests {
+		label, free := occupancyLabel("ja", reservations, tt.date, now)
+		if label != tt.label || free != tt.free {
+			t.Errorf("%s: expected %q (%v), got %q (%v)", tt.date, tt.label, tt.free, label, free)
+		}
+	}
+
+	// 候補の表示名に空き状況を付ける（過去の日付には付けない）
+	choices := []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "2025/11/19 (水)", Value: "2025/11/19"},
+		{Name: "明日 → 2025/11/21 (金) 15:00", Value: "2025/11/21 15:00"},
+	}
+	annotateOccupancy("ja", choices, reservations, now)
+	if choices[0].Name != "2025/11/19 (水)" || choices[1].Name != "明日 → 2025/11/21 (金) 15:00 — 満室" {
+		t.Errorf("Unexpected labels: %q, %q", choices[0].Name, choices[1].Name)
+	}
+
+	// 空きのある日だけを表示する
+	if !isFreeOnlyInput("空き") || !isFreeOnlyInput("Free") || isFreeOnlyInput("") || isFreeOnlyInput("明日") {
+		t.Error("Unexpected free-only input detection")
+	}
+	free := freeDateCandidates("ja", reservations, now)
+	if len(free) < 2 || free[0].Value != "2025/11/20" || free[1].Value != "2025/11/22" {
+		t.Errorf("Expected fully booked and closed days to be skipped, got %+v", free)
+	}
+	for _, choice := range free {
+		if choice.Value == "2025/11/23" || choice.Value == "2025/11/30" {
+			t.Errorf("Expected closed day %v to be skipped", choice.Value)
+		}
+	}
+}
+
+func TestGetTimeSuggestionsEchoesInterpretation(t *testing.T) {
+	choices := getTimeSuggestions("ja", slotQuery{}, "午後3時半", false)
+	if len(choices) == 0 || choices[0].Name != "午後3時半 → 15:30" || choices[0].Value != "15:30" {
+		t.Errorf("Expected interpreted time first, got %+v", choices[0])
+	}
+
+	// 開始時間が自然な表現でも終了時間の候補を絞り込める
+	for _, choice := range getTimeSuggestions("ja", slotQuery{StartTime: "14時"}, "", true) {
+		if choice.Value.(string) <= "14:00" {
+			t.Errorf("Expected only times after 14:00, got %v", choice.Value)
+		}
+	}
+}
+
+func TestGetTimeSuggestionsAvailability(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", Username: "alice", Date: "2099-01-05", StartTime: "13:00", EndTime: "14:30", Status: models.StatusPending},
+		{ID: "r2", Username: "bob", Date: "2099-01-05", StartTime: "16:15", EndTime: "17:00", Status: models.StatusPending},
+	}
+	q := slotQuery{Reservations: reservations, Date: "2099/01/05"}
+
+	// 予約が入っている時間帯は予約ごとに1件だけ、予約者付きで表示する
+	var names []string
+	for _, choice := range getTimeSuggestions("ja", q, "", false) {
+		names = append(names, choice.Name)
+	}
+	want := "09:00,09:30,10:00,10:30,11:00,11:30,12:00,12:30,13:00（alice さんが予約中 13:00-14:30）,14:30,15:00,15:30,16:00,16:30（bob さんが予約中 16:15-17:00）,17:00,17:30,18:00,18:30,19:00,19:30,20:00,20:30"
+	if strings.Join(names, ",") != want {
+		t.Errorf("Unexpected start times: %v", names)
+	}
+
+	// 終了時間は次の予約の開始時刻まで（刻みでない時刻も含む）
+	q.StartTime = "15:00"
+	var values []string
+	for _, choice := range getTimeSuggestions("ja", q, "", true) {
+		values = append(values, choice.Value.(string))
+	}
+	if strings.Join(values, ",") != "15:30,16:00,16:15" {
+		t.Errorf("Unexpected end times: %v", values)
+	}
+
+	// 開始時間が予約と重なる場合は候補なし
+	q.StartTime = "13:30"
+	if choices := getTimeSuggestions("ja", q, "", true); len(choices) != 0 {
+		t.Errorf("Expected no end times, got %+v", choices)
+	}
+
+	// 編集中の予約を除けば、その時間帯も空いている
+	q = slotQuery{Reservations: reservations[1:], Date: "2099/01/05", StartTime: "13:00"}
+	choices := getTimeSuggestions("ja", q, "", true)
+	if last := choices[len(choices)-1]; last.Value != "16:15" || last.Name != "16:15（次の予約の開始）" {
+		t.Errorf("Expected end times up to the next reservation, got %+v", last)
+	}
+}
+
+func TestGetDurationSuggestions(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", Date: "2099-01-05", StartTime: "15:30", EndTime: "17:00", Status: models.StatusPending},
+	}
+
+	// 14:00 から 15:30 まで空いているので、1時間30分までの候補になる
+	choices := getDurationSuggestions("ja", slotQuery{Reservations: reservations, Date: "2099/01/05", StartTime: "14時"}, "")
+	var values []string
+	for _, choice := range choices {
+		values = append(values, choice.Value.(string))
+	}
+	if strings.Join(values, ",") != "30m,1h,1h30m" {
+		t.Errorf("Unexpected durations: %v", values)
+	}
+	if choices[2].Name != "1時間30分（15:30 まで）" {
+		t.Errorf("Unexpected label: %s", choices[2].Name)
+	}
+
+	// 一般的な長さと異なる最後までの長さも候補になる
+	choices = getDurationSuggestions("ja", slotQuery{Reservations: reservations, Date: "2099/01/05", StartTime: "14:45"}, "")
+	if last := choices[len(choices)-1]; last.Value != "45m" {
+		t.Errorf("Expected the remaining 45 minutes last, got %+v", last)
+	}
+
+	// 入力した長さは空いていなくても解釈結果として先頭に表示する
+	choices = getDurationSuggestions("ja", slotQuery{Reservations: reservations, Date: "2099/01/05", StartTime: "14:00"}, "2時間")
+	if choices[0].Value != "2h" || !strings.Contains(choices[0].Name, "予約と重なります") {
+		t.Errorf("Expected interpreted duration with overlap warning, got %+v", choices[0])
+	}
+
+	// 開始時間が予約と重なる場合は候補なし
+	if choices := getDurationSuggestions("ja", slotQuery{Reservations: reservations, Date: "2099/01/05", StartTime: "16:00"}, ""); len(choices) != 0 {
+		t.Errorf("Expected no durations, got %d", len(choices))
+	}
+
+	// 開始時間が分からない場合は絞り込まない
+	if choices := getDurationSuggestions("ja", slotQuery{}, ""); len(choices) != len(commonDurations) {
+		t.Errorf("Expected all common durations, got %d", len(choices))
+	}
+}
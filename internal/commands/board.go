@@ -0,0 +1,290 @@
+package commands
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// boardMessageStateKey は掲示板のメッセージIDを保存する状態のキー
+const boardMessageStateKey = "board_message_id"
+
+const (
+	boardUpcomingDays  = 7               // 「今後の予約」に表示する日数（今日を除く）
+	boardUpcomingLimit = 10              // 「今後の予約」に表示する最大件数
+	boardCommentLimit  = 40              // 予約ごとに表示するコメントの最大文字数
+	boardFieldLimit    = 1024            // 埋め込みのフィールドの文字数の上限
+	boardDebounce      = 2 * time.Second // 変更の通知を受けてから更新するまでの待ち時間（続けて変更された場合にまとめる）
+)
+
+// Board はチャンネルにピン留めした予約状況の掲示板メッセージを管理する
+// メッセージIDは再起動後も使えるように保存し、メッセージが削除された場合は作り直す
+type Board struct {
+	session   *discordgo.Session
+	store     *storage.Storage
+	logger    *logging.Logger
+	channelID string
+	locale    string
+	notify    chan struct{}
+
+	mu   sync.Mutex
+	last string // 最後に表示した内容（変更がない場合は編集しない）
+}
+
+// NewBoard は channelID のチャンネルに掲示する掲示板を作成する（表示言語は i18n.DefaultLocale()）
+func NewBoard(s *discordgo.Session, store *storage.Storage, logger *logging.Logger, channelID string) *Board {
+	return &Board{
+		session:   s,
+		store:     store,
+		logger:    logger,
+		channelID: channelID,
+		locale:    i18n.DefaultLocale(),
+		notify:    make(chan struct{}, 1),
+	}
+}
+
+// Notify は予約が変更されたことを知らせ、掲示板の更新を予約する（storage.OnChange に登録する）
+func (b *Board) Notify() {
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+}
+
+// HandleMessageDelete は掲示板のメッセージが削除された場合に作り直す
+func (b *Board) HandleMessageDelete(m *discordgo.MessageDelete) {
+	if m.Message == nil || m.ChannelID != b.channelID || m.ID != b.store.GetState(boardMessageStateKey) {
+		return
+	}
+	b.mu.Lock()
+	b.last = ""
+	b.mu.Unlock()
+	b.Notify()
+}
+
+// Run は掲示板を作成し、予約の変更の通知を受けたときと interval ごとに更新する（戻らない）
+// 定期的な更新では内容が変わっていなくても編集し、削除されていれば作り直す
+func (b *Board) Run(interval time.Duration) {
+	b.refreshAndLog(true)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.refreshAndLog(true)
+		case <-b.notify:
+			time.Sleep(boardDebounce)
+			b.refreshAndLog(false)
+		}
+	}
+}
+
+func (b *Board) refreshAndLog(force bool) {
+	if err := b.Refresh(force); err != nil {
+		b.logger.LogError("ERROR", "Board", "Failed to update schedule board", err, map[string]interface{}{
+			"channel_id": b.channelID,
+		})
+	}
+}
+
+// Refresh は掲示板のメッセージを最新の予約状況に編集する
+// メッセージがまだない場合や削除されていた場合は、新しく送信してピン留めし、メッセージIDを保存する
+// force が false の場合、前回から内容が変わっていなければ何もしない
+func (b *Board) Refresh(force bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	embed := BoardEmbed(b.locale, b.store.GetAllReservations(), time.Now().In(jst))
+	content := boardContent(embed)
+	if !force && content == b.last {
+		return nil
+	}
+
+	embeds := []*discordgo.MessageEmbed{embed}
+	components := BoardComponents(b.locale)
+
+	if messageID := b.store.GetState(boardMessageStateKey); messageID != "" {
+		_, err := b.session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         messageID,
+			Channel:    b.channelID,
+			Embeds:     embeds,
+			Components: components,
+		})
+		if err == nil {
+			b.last = content
+			return nil
+		}
+		if !isUnknownMessage(err) {
+			return err
+		}
+		// メッセージが削除されていた場合は作り直す
+	}
+
+	msg, err := b.session.ChannelMessageSendComplex(b.channelID, &discordgo.MessageSend{
+		Embeds:     embeds,
+		Components: components,
+	})
+	if err != nil {
+		return err
+	}
+	if err := b.session.ChannelMessagePin(b.channelID, msg.ID); err != nil {
+		// ピン留めの権限がない場合も掲示板は使えるため、記録だけして続ける
+		b.logger.LogError("WARN", "Board", "Failed to pin schedule board", err, map[string]interface{}{
+			"channel_id": b.channelID,
+			"message_id": msg.ID,
+		})
+	}
+	b.last = content
+	return b.store.SetState(boardMessageStateKey, msg.ID)
+}
+
+// isUnknownMessage はメッセージが見つからない（削除された）ことを示すエラーかどうかを返す
+func isUnknownMessage(err error) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) {
+		return false
+	}
+	if restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownMessage {
+		return true
+	}
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
+
+// boardContent は掲示板の内容を比較するための文字列を返す（更新時刻は含めない）
+func boardContent(embed *discordgo.MessageEmbed) string {
+	parts := []string{embed.Title, embed.Description}
+	for _, field := range embed.Fields {
+		parts = append(parts, field.Name, field.Value)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// BoardEmbed は掲示板に表示する現在の状況と、今日と今後の予約の埋め込みを作成する
+// now は日本時間の現在時刻。今日の予約は終了したものを除き、今後の予約は boardUpcomingDays 日分を表示する
+func BoardEmbed(locale string, reservations []*models.Reservation, now time.Time) *discordgo.MessageEmbed {
+	today := now.Format("2006-01-02")
+	clock := now.Format("15:04")
+
+	var todays []*models.Reservation
+	for _, r := range schedule.ActiveReservationsOn(reservations, today) {
+		if r.EndTime > clock {
+			todays = append(todays, r)
+		}
+	}
+
+	// 1. 現在の状況
+	status := ""
+	color := 0x57F287
+	current := schedule.ReservationAt(reservations, today, clock)
+	reason, closed := schedule.ClosedOn(today)
+	switch {
+	case closed:
+		status = i18n.T(locale, "board.status_closed", closedLabel(locale, reason))
+		color = 0x99AAB5
+	case current != nil:
+		status = i18n.T(locale, "board.status_in_use", current.Username, current.EndTime)
+		color = 0xED4245
+	case clock < schedule.OpeningTime || clock >= schedule.ClosingTime:
+		status = i18n.T(locale, "board.status_outside", schedule.OpeningTime, schedule.ClosingTime)
+		color = 0x99AAB5
+	case len(todays) > 0:
+		status = i18n.T(locale, "board.status_free_until", todays[0].StartTime)
+	default:
+		status = i18n.T(locale, "board.status_free")
+	}
+
+	// 2. 今日の予約
+	var todayLines []string
+	for _, r := range todays {
+		line := boardEntry(r)
+		if r == current {
+			line = i18n.T(locale, "board.now_marker") + line
+		}
+		todayLines = append(todayLines, line)
+	}
+	if len(todayLines) == 0 {
+		todayLines = []string{i18n.T(locale, "board.no_reservations")}
+	}
+
+	// 3. 今後の予約
+	var upcoming []*models.Reservation
+	for day := 1; day <= boardUpcomingDays; day++ {
+		upcoming = append(upcoming, schedule.ActiveReservationsOn(reservations, now.AddDate(0, 0, day).Format("2006-01-02"))...)
+	}
+	var upcomingLines []string
+	for idx, r := range upcoming {
+		if idx >= boardUpcomingLimit {
+			upcomingLines = append(upcomingLines, i18n.T(locale, "board.more", len(upcoming)-boardUpcomingLimit))
+			break
+		}
+		upcomingLines = append(upcomingLines, "**"+boardDate(locale, r.Date)+"** "+boardEntry(r))
+	}
+	if len(upcomingLines) == 0 {
+		upcomingLines = []string{i18n.T(locale, "board.no_reservations")}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "board.title"),
+		Description: status + "\n\n" + i18n.T(locale, "board.hint"),
+		Color:       color,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  i18n.T(locale, "board.today", formatDateWithWeekday(locale, now)),
+				Value: joinFieldLines(todayLines),
+			},
+			{
+				Name:  i18n.T(locale, "board.upcoming", boardUpcomingDays),
+				Value: joinFieldLines(upcomingLines),
+			},
+		},
+		Timestamp: now.Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(locale, "board.footer"),
+		},
+	}
+}
+
+// boardEntry は掲示板に表示する予約1件の行を作成する（例: `14:00-15:00` 山田 — 練習）
+func boardEntry(r *models.Reservation) string {
+	line := "`" + r.StartTime + "-" + r.EndTime + "` " + r.Username
+	if comment := strings.TrimSpace(strings.ReplaceAll(r.Comment, "\n", " ")); comment != "" {
+		line += " — " + truncateText(comment, boardCommentLimit)
+	}
+	return line
+}
+
+// boardDate は「今後の予約」に表示する日付を返す（例: 11/21 (金)）
+func boardDate(locale, date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("01/02") + " (" + i18n.Weekday(locale, t.Weekday()) + ")"
+}
+
+// joinFieldLines は行をフィールドの文字数の上限に収まるだけつなげる
+func joinFieldLines(lines []string) string {
+	var b strings.Builder
+	for idx, line := range lines {
+		if idx > 0 {
+			if len([]rune(b.String()))+1+len([]rune(line)) > boardFieldLimit-2 {
+				b.WriteString("\n…")
+				break
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+func TestBoardEmbed(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 14, 30, 0, 0, jst)
+	reservations := []*models.Reservation{
+		{ID: "r1", Username: "alice", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending},
+		{ID: "r2", Username: "bob", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Comment: "練習", Status: models.StatusPending},
+		{ID: "r3", Username: "carol", Date: "2025-11-20", StartTime: "16:00", EndTime: "17:00", Status: models.StatusCancelled},
+		{ID: "r4", Username: "dave", Date: "2025-11-22", StartTime: "13:00", EndTime: "14:00", Status: models.StatusPending},
+		{ID: "r5", Username: "erin", Date: "2025-12-10", StartTime: "13:00", EndTime: "14:00", Status: models.StatusPending},
+	}
+
+	embed := BoardEmbed(i18n.Japanese, reservations, now)
+	if !strings.Contains(embed.Description, i18n.T(i18n.Japanese, "board.status_in_use", "bob", "15:00")) {
+		t.Errorf("Expected in-use status, got %q", embed.Description)
+	}
+
+	// 今日の予約は終了したものとキャンセルされたものを除く
+	today := embed.Fields[0].Value
+	if strings.Contains(today, "alice") || strings.Contains(today, "carol") {
+		t.Errorf("Expected only remaining reservations today, got %q", today)
+	}
+	if !strings.Contains(today, "▶ `14:00-15:00` bob — 練習") {
+		t.Errorf("Expected the current reservation to be marked, got %q", today)
+	}
+
+	// 今後の予約は boardUpcomingDays 日分
+	upcoming := embed.Fields[1].Value
+	if !strings.Contains(upcoming, "**11/22 (土)** `13:00-14:00` dave") || strings.Contains(upcoming, "erin") {
+		t.Errorf("Unexpected upcoming reservations: %q", upcoming)
+	}
+}
+
+func TestBoardEmbedStatus(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	reservations := []*models.Reservation{
+		{ID: "r1", Username: "alice", Date: "2025-11-20", StartTime: "16:00", EndTime: "17:00", Status: models.StatusPending},
+	}
+
+	embed := BoardEmbed(i18n.English, reservations, time.Date(2025, 11, 20, 14, 0, 0, 0, jst))
+	if !strings.Contains(embed.Description, i18n.T(i18n.English, "board.status_free_until", "16:00")) {
+		t.Errorf("Expected free-until status, got %q", embed.Description)
+	}
+
+	embed = BoardEmbed(i18n.English, nil, time.Date(2025, 11, 20, 23, 0, 0, 0, jst))
+	if !strings.Contains(embed.Description, i18n.T(i18n.English, "board.status_outside", schedule.OpeningTime, schedule.ClosingTime)) {
+		t.Errorf("Expected outside-hours status, got %q", embed.Description)
+	}
+	if embed.Fields[0].Value != i18n.T(i18n.English, "board.no_reservations") {
+		t.Errorf("Expected no reservations today, got %q", embed.Fields[0].Value)
+	}
+}
+
+func TestJoinFieldLines(t *testing.T) {
+	lines := make([]string, 100)
+	for idx := range lines {
+		lines[idx] = strings.Repeat("x", 30)
+	}
+	if n := len([]rune(joinFieldLines(lines))); n > boardFieldLimit {
+		t.Errorf("Expected at most %d characters, got %d", boardFieldLimit, n)
+	}
+}
+
+func TestBoardComponents(t *testing.T) {
+	row := BoardComponents(i18n.Japanese)[0].(discordgo.ActionsRow)
+	var actions []string
+	for _, component := range row.Components {
+		action, _ := decodeCustomID(component.(discordgo.Button).CustomID)
+		actions = append(actions, action)
+	}
+	want := []string{actionReserveNow, actionReserveNew, actionAvailabilityToday, actionScheduleToday}
+	if strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected buttons %v, got %v", want, actions)
+	}
+}
@@ -0,0 +1,692 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// adminListLimit は /admin list で一度に表示する最大件数
+const adminListLimit = 30
+
+// isAdmin は実行者が管理者かどうかを返す
+// ADMIN_ROLE_ID（カンマ区切りで複数指定可）のいずれかのロール、またはサーバー管理者権限を持つ場合に管理者とみなす
+func isAdmin(i *discordgo.InteractionCreate) bool {
+	if i.Member == nil {
+		return false
+	}
+
+	if i.Member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+
+	for _, roleID := range strings.Split(os.Getenv("ADMIN_ROLE_ID"), ",") {
+		roleID = strings.TrimSpace(roleID)
+		if roleID == "" {
+			continue
+		}
+		for _, memberRole := range i.Member.Roles {
+			if memberRole == roleID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// adminCommand は /admin の定義を返す
+func adminCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "admin",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "cancel",
+					Options: []*discordgo.ApplicationCommandOption{
+						adminReservationIDOption(),
+						adminReasonOption(),
+					},
+				},
+				{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "edit",
+					Options: []*discordgo.ApplicationCommandOption{
+						adminReservationIDOption(),
+						adminReasonOption(),
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "date",
+							Required:     false,
+							Autocomplete: true,
+						},
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "start_time",
+							Required:     false,
+							Autocomplete: true,
+						},
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "end_time",
+							Required:     false,
+							Autocomplete: true,
+						},
+						{
+							Type:     discordgo.ApplicationCommandOptionString,
+							Name:     "comment",
+							Required: false,
+						},
+						{
+							Type:     discordgo.ApplicationCommandOptionBoolean,
+							Name:     "override",
+							Required: false,
+						},
+					},
+				},
+				{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "reopen",
+					Options: []*discordgo.ApplicationCommandOption{
+						adminReservationIDOption(),
+						adminReasonOption(),
+					},
+				},
+				{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "reassign",
+					Options: []*discordgo.ApplicationCommandOption{
+						adminReservationIDOption(),
+						{
+							Type:     discordgo.ApplicationCommandOptionUser,
+							Name:     "user",
+							Required: true,
+						},
+						adminReasonOption(),
+					},
+				},
+				{
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Name: "list",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:     discordgo.ApplicationCommandOptionUser,
+							Name:     "user",
+							Required: false,
+						},
+						{
+							Type:     discordgo.ApplicationCommandOptionString,
+							Name:     "status",
+							Required: false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Value: "pending"},
+								{Value: "completed"},
+								{Value: "cancelled"},
+								{Value: "all"},
+							},
+						},
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "from",
+							Required:     false,
+							Autocomplete: true,
+						},
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "to",
+							Required:     false,
+							Autocomplete: true,
+						},
+					},
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleAdmin(c.Session, c.Interaction, c.Store, c.Logger, c.AllowedChannelID, c.IsDM)
+		},
+		Permission: PermissionAdmin,
+		GuildOnly:  true,
+		Help:       "help.admin",
+	}
+}
+
+// adminReservationIDOption は管理者コマンド用の予約IDオプションを作成する
+func adminReservationIDOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:         discordgo.ApplicationCommandOptionString,
+		Name:         "reservation_id",
+		Required:     true,
+		Autocomplete: true,
+	}
+}
+
+// adminReasonOption は管理者コマンド用の理由オプションを作成する（監査ログに記録される）
+func adminReasonOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:     discordgo.ApplicationCommandOptionString,
+		Name:     "reason",
+		Required: true,
+	}
+}
+
+// handleAdmin は管理者用コマンドを処理する
+func handleAdmin(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool) {
+	// 1. ユーザー情報取得（DMでの実行と権限は permissionMiddleware で確認済み）
+	userID, _ := getUserInfo(i, isDM)
+
+	// 2. サブコマンド取得
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		respondError(s, i, tr(i, "admin.subcommand_required"))
+		return
+	}
+	subcommand := options[0]
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(subcommand.Options))
+	for _, opt := range subcommand.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 3. サブコマンドごとの処理
+	switch subcommand.Name {
+	case "cancel":
+		handleAdminCancel(s, i, store, logger, allowedChannelID, optionMap, userID)
+	case "edit":
+		handleAdminEdit(s, i, store, logger, allowedChannelID, optionMap, userID)
+	case "reopen":
+		handleAdminReopen(s, i, store, logger, allowedChannelID, optionMap, userID)
+	case "reassign":
+		handleAdminReassign(s, i, store, logger, allowedChannelID, optionMap, userID)
+	case "list":
+		handleAdminList(s, i, store, optionMap)
+	default:
+		respondError(s, i, tr(i, "admin.unknown_subcommand"))
+	}
+}
+
+// handleAdminCancel は任意ユーザーの予約を取り消す
+func handleAdminCancel(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption, adminID string) {
+	reservationID := optionMap["reservation_id"].StringValue()
+	reason := optionMap["reason"].StringValue()
+
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found_check_id"))
+		return
+	}
+
+	// 状態の確認と更新を1つのロックの中で行う
+	var rejection error
+	reservation, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		if r.Status == models.StatusCancelled {
+			rejection = newError("admin.already_cancelled")
+			return rejection
+		}
+
+		r.Status = models.StatusCancelled
+		r.UpdatedAt = time.Now()
+		r.AddHistory("admin_cancel", adminID, reason, "")
+		return nil
+	})
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	}
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.save_failed"))
+		logger.LogError("ERROR", "handleAdminCancel", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	respondEmbedWithFooter(s, i, tr(i, "admin.cancel.done"), tr(i, "reservation.id_line", reservation.ID), adminReservationFields(lang(i), reservation), 0xED4245, footer(lang(i), "admin cancel"), true)
+
+	pub := i18n.DefaultLocale()
+	sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "admin.cancel.announce"), "", adminReservationFields(pub, reservation), 0xED4245, footer(pub, "admin cancel"))
+	sendAuditLog(s, logger, "cancel", adminID, reservation, reason, nil)
+	notifyWatchers(s, store, logger, reservation, reservation.Date, reservation.StartTime, reservation.EndTime)
+
+	if UpdateStatusCallback != nil {
+		UpdateStatusCallback()
+	}
+}
+
+// handleAdminEdit は任意ユーザーの予約を編集する
+// 記録の修正に使うため、過去日時への変更も許可する。休室日・開室時間外への変更は override を指定した場合のみ許可し、履歴に記録する
+func handleAdminEdit(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption, adminID string) {
+	reservationID := optionMap["reservation_id"].StringValue()
+	reason := optionMap["reason"].StringValue()
+
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found_check_id"))
+		return
+	}
+
+	// 入力値の解釈（指定されていない項目は nil のまま、予約の現在の値を引き継ぐ）
+	var newDate, newStartTime, newEndTime, newComment *string
+	if opt, ok := optionMap["date"]; ok {
+		date, _, err := parseDateInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, localize(lang(i), err))
+			return
+		}
+		newDate = &date
+	}
+	if opt, ok := optionMap["start_time"]; ok {
+		startTime, err := parseTimeInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, tr(i, "validation.invalid_start_time"))
+			return
+		}
+		newStartTime = &startTime
+	}
+	if opt, ok := optionMap["end_time"]; ok {
+		endTime, err := parseTimeInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, tr(i, "validation.invalid_end_time"))
+			return
+		}
+		newEndTime = &endTime
+	}
+	if opt, ok := optionMap["comment"]; ok {
+		comment := opt.StringValue()
+		newComment = &comment
+	}
+	override := false
+	if opt, ok := optionMap["override"]; ok {
+		override = opt.BoolValue()
+	}
+
+	if newDate == nil && newStartTime == nil && newEndTime == nil && newComment == nil {
+		respondError(s, i, tr(i, "edit.no_changes"))
+		return
+	}
+
+	// 変更後の値の確認・重複チェック・保存を1つのロックの中で行う
+	var (
+		before      models.Reservation  // 変更前の予約
+		details     string              // 変更内容（履歴と監査ログに記録する）
+		rejection   error               // 変更後の時刻による拒否
+		outside     bool                // 休室日・開室時間外のため拒否したかどうか
+		overlapping *models.Reservation // 時間が重なる他の予約
+		overlapErr  error               // 重複チェックの失敗
+	)
+	reservation, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		before = *r
+
+		candidate := *r
+		if newDate != nil {
+			candidate.Date = *newDate
+		}
+		if newStartTime != nil {
+			candidate.StartTime = *newStartTime
+		}
+		if newEndTime != nil {
+			candidate.EndTime = *newEndTime
+		}
+		if newComment != nil {
+			candidate.Comment = *newComment
+		}
+
+		if candidate.EndTime <= candidate.StartTime {
+			rejection = newError("validation.end_before_start", candidate.StartTime)
+			return rejection
+		}
+
+		// 日付・時刻を変更する場合は休室日と開室時間をチェック（override で許可した場合は履歴に残す）
+		rescheduled := candidate.Date != r.Date || candidate.StartTime != r.StartTime || candidate.EndTime != r.EndTime
+		scheduleErr := scheduleError(candidate.Date, candidate.StartTime, candidate.EndTime)
+		if rescheduled && scheduleErr != nil && !override {
+			rejection = scheduleErr
+			outside = true
+			return rejection
+		}
+
+		overlapping, overlapErr = storage.FindOverlap(&candidate, others)
+		if overlapErr != nil {
+			return overlapErr
+		}
+		if overlapping != nil {
+			return errEditInvalid
+		}
+
+		r.Date = candidate.Date
+		r.StartTime = candidate.StartTime
+		r.EndTime = candidate.EndTime
+		r.Comment = candidate.Comment
+		r.UpdatedAt = time.Now()
+		details = fmt.Sprintf("%s %s-%s → %s %s-%s",
+			formatDate(before.Date), before.StartTime, before.EndTime,
+			formatDate(r.Date), r.StartTime, r.EndTime,
+		)
+		if rescheduled && scheduleErr != nil {
+			details += " [override]"
+		}
+		r.AddHistory("admin_edit", adminID, reason, details)
+		return nil
+	})
+	switch {
+	case outside:
+		respondError(s, i, localize(lang(i), rejection)+"\n"+tr(i, "admin.edit.override_hint"))
+		return
+	case rejection != nil:
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	case overlapErr != nil:
+		respondError(s, i, tr(i, "reservation.overlap_check_failed"))
+		logger.LogError("ERROR", "handleAdminEdit", "Failed to check overlap", overlapErr, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	case overlapping != nil:
+		respondEmbedWithFooter(s, i, tr(i, "edit.failed"), tr(i, "reservation.time_taken"), adminReservationFields(lang(i), overlapping), 0xED4245, footer(lang(i), "admin edit"), true)
+		return
+	case err != nil:
+		respondError(s, i, tr(i, "reservation.update_failed"))
+		logger.LogError("ERROR", "handleAdminEdit", "Failed to save reservation", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	changeFields := func(locale string) []*discordgo.MessageEmbedField {
+		return []*discordgo.MessageEmbedField{
+			{
+				Name:   i18n.T(locale, "admin.edit.changes"),
+				Value:  details,
+				Inline: false,
+			},
+		}
+	}
+	respondEmbedWithFooter(s, i, tr(i, "admin.edit.done"), tr(i, "reservation.id_line", reservation.ID), append(adminReservationFields(lang(i), reservation), changeFields(lang(i))...), 0xFEE75C, footer(lang(i), "admin edit"), true)
+
+	pub := i18n.DefaultLocale()
+	sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "admin.edit.announce"), "", append(adminReservationFields(pub, reservation), changeFields(pub)...), 0xFEE75C, footer(pub, "admin edit"))
+	sendAuditLog(s, logger, "edit", adminID, reservation, reason, changeFields(pub))
+
+	// 予約中の予約の時間帯が変わった場合は、元の時間帯が空くので空き待ちのユーザーに通知
+	if before.Status == models.StatusPending && (before.Date != reservation.Date || before.StartTime != reservation.StartTime || before.EndTime != reservation.EndTime) {
+		notifyWatchers(s, store, logger, reservation, before.Date, before.StartTime, before.EndTime)
+	}
+
+	if UpdateStatusCallback != nil {
+		UpdateStatusCallback()
+	}
+}
+
+// handleAdminReopen は完了・キャンセル済みの予約を予約中に戻す
+func handleAdminReopen(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption, adminID string) {
+	reservationID := optionMap["reservation_id"].StringValue()
+	reason := optionMap["reason"].StringValue()
+
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found_check_id"))
+		return
+	}
+
+	// 状態の確認・重複チェック・保存を1つのロックの中で行う
+	var (
+		rejection   error
+		overlapping *models.Reservation
+		overlapErr  error
+	)
+	reservation, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		if r.Status == models.StatusPending {
+			rejection = newError("admin.already_pending")
+			return rejection
+		}
+
+		// 再開後に他の予約と重複しないか確認
+		candidate := *r
+		candidate.Status = models.StatusPending
+		overlapping, overlapErr = storage.FindOverlap(&candidate, others)
+		if overlapErr != nil {
+			return overlapErr
+		}
+		if overlapping != nil {
+			return errEditInvalid
+		}
+
+		previousStatus := r.Status
+		r.Status = models.StatusPending
+		r.UpdatedAt = time.Now()
+		r.AddHistory("admin_reopen", adminID, reason, fmt.Sprintf("%s → %s", previousStatus, models.StatusPending))
+		return nil
+	})
+	switch {
+	case rejection != nil:
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	case overlapErr != nil:
+		respondError(s, i, tr(i, "reservation.overlap_check_failed"))
+		logger.LogError("ERROR", "handleAdminReopen", "Failed to check overlap", overlapErr, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	case overlapping != nil:
+		respondEmbedWithFooter(s, i, tr(i, "admin.reopen.failed"), tr(i, "admin.reopen.overlap"), adminReservationFields(lang(i), overlapping), 0xED4245, footer(lang(i), "admin reopen"), true)
+		return
+	case err != nil:
+		respondError(s, i, tr(i, "reservation.save_failed"))
+		logger.LogError("ERROR", "handleAdminReopen", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	respondEmbedWithFooter(s, i, tr(i, "admin.reopen.done"), tr(i, "reservation.id_line", reservation.ID), adminReservationFields(lang(i), reservation), 0x57F287, footer(lang(i), "admin reopen"), true)
+
+	pub := i18n.DefaultLocale()
+	sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "admin.reopen.announce"), "", adminReservationFields(pub, reservation), 0x57F287, footer(pub, "admin reopen"))
+	sendAuditLog(s, logger, "reopen", adminID, reservation, reason, nil)
+
+	if UpdateStatusCallback != nil {
+		UpdateStatusCallback()
+	}
+}
+
+// handleAdminReassign は予約中の予約の所有者を別のユーザーに変更する（承諾待ちの譲渡の申し出は取り消す）
+func handleAdminReassign(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption, adminID string) {
+	reservationID := optionMap["reservation_id"].StringValue()
+	reason := optionMap["reason"].StringValue()
+	newOwner := optionMap["user"].UserValue(s)
+
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found_check_id"))
+		return
+	}
+
+	if newOwner == nil || newOwner.Bot {
+		respondError(s, i, tr(i, "admin.reassign.invalid_user"))
+		return
+	}
+
+	// 表示名を解決（サーバーのニックネームを優先）
+	newUsername := newOwner.Username
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+		if member, ok := resolved.Members[newOwner.ID]; ok && member.Nick != "" {
+			newUsername = member.Nick
+		}
+	}
+
+	// 所有者の確認と変更を1つのロックの中で行う
+	var (
+		previousOwnerID string
+		rejection       error
+	)
+	reservation, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		switch {
+		case r.Status != models.StatusPending:
+			rejection = newError("admin.reassign.not_pending")
+		case newOwner.ID == r.UserID:
+			rejection = newError("admin.reassign.same_owner")
+		}
+		if rejection != nil {
+			return rejection
+		}
+
+		// 前の予約者の譲渡の申し出は取り消す（残すと譲渡先が承諾して新しい予約者から予約を取れてしまう）
+		details := fmt.Sprintf("%s → %s", r.UserID, newOwner.ID)
+		if r.Transfer != nil {
+			details += fmt.Sprintf(" [transfer offer to %s withdrawn]", r.Transfer.ToUserID)
+			r.Transfer = nil
+		}
+
+		previousOwnerID = r.UserID
+		r.UserID = newOwner.ID
+		r.Username = newUsername
+		r.UpdatedAt = time.Now()
+		r.AddHistory("admin_reassign", adminID, reason, details)
+		return nil
+	})
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	}
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.save_failed"))
+		logger.LogError("ERROR", "handleAdminReassign", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	changeFields := func(locale string) []*discordgo.MessageEmbedField {
+		return []*discordgo.MessageEmbedField{
+			{
+				Name:   i18n.T(locale, "admin.reassign.changes"),
+				Value:  fmt.Sprintf("<@%s> → <@%s>", previousOwnerID, newOwner.ID),
+				Inline: false,
+			},
+		}
+	}
+	respondEmbedWithFooter(s, i, tr(i, "admin.reassign.done"), tr(i, "reservation.id_line", reservation.ID), append(adminReservationFields(lang(i), reservation), changeFields(lang(i))...), 0xFEE75C, footer(lang(i), "admin reassign"), true)
+
+	pub := i18n.DefaultLocale()
+	sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "admin.reassign.announce"), "", append(adminReservationFields(pub, reservation), changeFields(pub)...), 0xFEE75C, footer(pub, "admin reassign"))
+	sendAuditLog(s, logger, "reassign", adminID, reservation, reason, changeFields(pub))
+}
+
+// handleAdminList は条件に一致するすべてのユーザーの予約を表示する
+func handleAdminList(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption) {
+	filter := storage.ReservationFilter{}
+
+	if opt, ok := optionMap["user"]; ok {
+		if user := opt.UserValue(s); user != nil {
+			filter.UserID = user.ID
+		}
+	}
+	if opt, ok := optionMap["status"]; ok && opt.StringValue() != "all" {
+		filter.Statuses = []models.ReservationStatus{models.ReservationStatus(opt.StringValue())}
+	}
+	if opt, ok := optionMap["from"]; ok {
+		date, _, err := parseDateInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, localize(lang(i), err))
+			return
+		}
+		filter.FromDate = date
+	}
+	if opt, ok := optionMap["to"]; ok {
+		date, _, err := parseDateInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, localize(lang(i), err))
+			return
+		}
+		filter.ToDate = date
+	}
+
+	reservations := store.FindReservations(filter)
+	if len(reservations) == 0 {
+		respondEmbed(s, i, tr(i, "admin.list.title"), tr(i, "list.empty_filtered"), 0x000000, true)
+		return
+	}
+
+	var lines []string
+	for idx, r := range reservations {
+		if idx >= adminListLimit {
+			lines = append(lines, tr(i, "admin.list.more", len(reservations)-adminListLimit))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("`%s` %s %s-%s <@%s> [%s]", r.ID, formatDate(r.Date), r.StartTime, r.EndTime, r.UserID, statusLabel(lang(i), r.Status)))
+	}
+
+	respondEmbedWithFooter(s, i, tr(i, "admin.list.title"), strings.Join(lines, "\n"), nil, 0x000000, tr(i, "admin.list.footer", len(reservations)), true)
+}
+
+// adminReservationFields は管理者操作の表示に使う予約情報のフィールドを作成する
+func adminReservationFields(locale string, r *models.Reservation) []*discordgo.MessageEmbedField {
+	return []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(locale, "label.user"),
+			Value:  fmt.Sprintf("<@%s>", r.UserID),
+			Inline: false,
+		},
+		{
+			Name:   i18n.T(locale, "label.date"),
+			Value:  formatDate(r.Date),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "label.time"),
+			Value:  fmt.Sprintf("%s - %s", r.StartTime, r.EndTime),
+			Inline: true,
+		},
+	}
+}
+
+// statusLabel は予約状態の表示名を返す
+func statusLabel(locale string, status models.ReservationStatus) string {
+	switch status {
+	case models.StatusPending, models.StatusCompleted, models.StatusCancelled:
+		return i18n.T(locale, "status."+string(status))
+	}
+	return string(status)
+}
+
+// sendAuditLog は管理者操作を監査チャンネルに既定の言語で記録する
+func sendAuditLog(s *discordgo.Session, logger *logging.Logger, action string, adminID string, r *models.Reservation, reason string, extraFields []*discordgo.MessageEmbedField) {
+	auditChannelID := os.Getenv("AUDIT_CHANNEL_ID")
+	if auditChannelID == "" {
+		logger.LogError("ERROR", "sendAuditLog", "AUDIT_CHANNEL_ID not set", nil, map[string]interface{}{
+			"action":         action,
+			"admin_id":       adminID,
+			"reservation_id": r.ID,
+		})
+		return
+	}
+
+	locale := i18n.DefaultLocale()
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(locale, "admin.audit.admin"),
+			Value:  fmt.Sprintf("<@%s>", adminID),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "label.reservation_id"),
+			Value:  fmt.Sprintf("`%s`", r.ID),
+			Inline: true,
+		},
+	}
+	fields = append(fields, adminReservationFields(locale, r)...)
+	fields = append(fields, extraFields...)
+	fields = append(fields, &discordgo.MessageEmbedField{
+		Name:   i18n.T(locale, "admin.audit.reason"),
+		Value:  reason,
+		Inline: false,
+	})
+
+	if err := sendChannelEmbed(s, auditChannelID, i18n.T(locale, "admin.audit.title", action), "", fields, 0x99AAB5, footer(locale, "admin "+action)); err != nil {
+		logger.LogError("ERROR", "sendAuditLog", "Failed to send audit log", err, map[string]interface{}{
+			"action":         action,
+			"reservation_id": r.ID,
+		})
+	}
+}
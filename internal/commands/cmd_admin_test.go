@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// adminOptions は管理者コマンドのオプションを作成する（値はすべて文字列）
+func adminOptions(values map[string]string) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(values))
+	for name, value := range values {
+		options[name] = &discordgo.ApplicationCommandInteractionDataOption{
+			Name:  name,
+			Type:  discordgo.ApplicationCommandOptionString,
+			Value: value,
+		}
+	}
+	return options
+}
+
+func newAdminTestStore(t *testing.T, reservations ...*models.Reservation) *storage.Storage {
+	t.Helper()
+	store := storage.NewStorageIn(t.TempDir())
+	for _, r := range reservations {
+		if err := store.AddReservation(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func TestHandleAdminEdit(t *testing.T) {
+	s, _ := newRecordingSession(t)
+	logger := logging.NewLogger(t.TempDir())
+	store := newAdminTestStore(t,
+		&models.Reservation{ID: "r1", UserID: "u1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCompleted},
+		&models.Reservation{ID: "r2", UserID: "u2", Date: "2025-11-20", StartTime: "12:00", EndTime: "13:00", Status: models.StatusPending},
+		&models.Reservation{ID: "r3", UserID: "u3", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusPending},
+	)
+	snapshot, _ := store.GetReservation("r1")
+	i := testInteraction(discordgo.InteractionApplicationCommand)
+
+	// 過去の記録も修正できる。取得済みの予約は変更されず、履歴が残る
+	handleAdminEdit(s, i, store, logger, "", adminOptions(map[string]string{
+		"reservation_id": "r1", "reason": "記録の修正", "end_time": "11:30",
+	}), "admin")
+	got, _ := store.GetReservation("r1")
+	if got.EndTime != "11:30" {
+		t.Errorf("Expected end time 11:30, got %s", got.EndTime)
+	}
+	if len(got.History) != 1 || got.History[0].Action != "admin_edit" || got.History[0].Details != "2025/11/20 10:00-11:00 → 2025/11/20 10:00-11:30" {
+		t.Errorf("Unexpected history: %+v", got.History)
+	}
+	if snapshot.EndTime != "11:00" || len(snapshot.History) != 0 {
+		t.Errorf("Expected the fetched snapshot to stay unchanged, got %+v", snapshot)
+	}
+
+	// 予約中の他の予約と重なる変更は保存しない（完了した予約とは重なってもよい）
+	handleAdminEdit(s, i, store, logger, "", adminOptions(map[string]string{
+		"reservation_id": "r2", "reason": "延長", "end_time": "14:30",
+	}), "admin")
+	if got, _ := store.GetReservation("r2"); got.EndTime != "13:00" || len(got.History) != 0 {
+		t.Errorf("Expected overlapping edit to be rejected, got %s with history %+v", got.EndTime, got.History)
+	}
+	handleAdminEdit(s, i, store, logger, "", adminOptions(map[string]string{
+		"reservation_id": "r2", "reason": "移動", "start_time": "10:30",
+	}), "admin")
+	if got, _ := store.GetReservation("r2"); got.StartTime != "10:30" {
+		t.Errorf("Expected edit over a completed reservation to be saved, got %s", got.StartTime)
+	}
+
+	// 開室時間外への変更は override を指定した場合のみ保存し、履歴に残す
+	options := adminOptions(map[string]string{"reservation_id": "r3", "reason": "夜間利用", "end_time": "22:00"})
+	handleAdminEdit(s, i, store, logger, "", options, "admin")
+	if got, _ := store.GetReservation("r3"); got.EndTime != "15:00" {
+		t.Errorf("Expected edit outside opening hours to be rejected, got %s", got.EndTime)
+	}
+	options["override"] = &discordgo.ApplicationCommandInteractionDataOption{Name: "override", Type: discordgo.ApplicationCommandOptionBoolean, Value: true}
+	handleAdminEdit(s, i, store, logger, "", options, "admin")
+	got, _ = store.GetReservation("r3")
+	if got.EndTime != "22:00" || len(got.History) != 1 || got.History[0].Details != "2025/11/20 14:00-15:00 → 2025/11/20 14:00-22:00 [override]" {
+		t.Errorf("Expected overridden edit with history, got %s %+v", got.EndTime, got.History)
+	}
+}
+
+func TestHandleAdminReopenOverlap(t *testing.T) {
+	s, _ := newRecordingSession(t)
+	logger := logging.NewLogger(t.TempDir())
+	store := newAdminTestStore(t,
+		&models.Reservation{ID: "r1", UserID: "u1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCancelled},
+		&models.Reservation{ID: "r2", UserID: "u2", Date: "2025-11-20", StartTime: "10:30", EndTime: "11:30", Status: models.StatusPending},
+	)
+	i := testInteraction(discordgo.InteractionApplicationCommand)
+
+	handleAdminReopen(s, i, store, logger, "", adminOptions(map[string]string{"reservation_id": "r1", "reason": "誤操作"}), "admin")
+	if got, _ := store.GetReservation("r1"); got.Status != models.StatusCancelled {
+		t.Errorf("Expected reopen to be rejected by the overlap, got %s", got.Status)
+	}
+
+	handleAdminCancel(s, i, store, logger, "", adminOptions(map[string]string{"reservation_id": "r2", "reason": "重複"}), "admin")
+	handleAdminReopen(s, i, store, logger, "", adminOptions(map[string]string{"reservation_id": "r1", "reason": "誤操作"}), "admin")
+	got, _ := store.GetReservation("r1")
+	if got.Status != models.StatusPending {
+		t.Errorf("Expected reopened reservation, got %s", got.Status)
+	}
+	if len(got.History) != 1 || got.History[0].Details != "cancelled → pending" {
+		t.Errorf("Unexpected history: %+v", got.History)
+	}
+}
+
+// unknownUserTransport はユーザー取得APIだけ404を返す（UserValue はオプションのIDだけを持つユーザーを返す）
+type unknownUserTransport struct {
+	*recordingTransport
+}
+
+func (t unknownUserTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/users/") {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Unknown User", "code": 10013}`)),
+			Request:    req,
+		}, nil
+	}
+	return t.recordingTransport.RoundTrip(req)
+}
+
+func TestHandleAdminReassign(t *testing.T) {
+	s, transport := newRecordingSession(t)
+	s.Client = &http.Client{Transport: unknownUserTransport{transport}}
+	logger := logging.NewLogger(t.TempDir())
+	store := newAdminTestStore(t,
+		&models.Reservation{ID: "r1", UserID: "u1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending,
+			Transfer: &models.TransferOffer{ToUserID: "u3", ToUsername: "carol", OfferedAt: time.Now()}},
+		&models.Reservation{ID: "r2", UserID: "u1", Date: "2025-11-20", StartTime: "12:00", EndTime: "13:00", Status: models.StatusCompleted},
+	)
+	i := testInteraction(discordgo.InteractionApplicationCommand)
+	reassign := func(reservationID string) {
+		options := adminOptions(map[string]string{"reservation_id": reservationID, "reason": "代理予約"})
+		options["user"] = &discordgo.ApplicationCommandInteractionDataOption{Name: "user", Type: discordgo.ApplicationCommandOptionUser, Value: "u2"}
+		handleAdminReassign(s, i, store, logger, "", options, "admin")
+	}
+
+	// 承諾待ちの譲渡の申し出は取り消し、履歴に残す
+	reassign("r1")
+	got, _ := store.GetReservation("r1")
+	if got.UserID != "u2" || got.Transfer != nil {
+		t.Errorf("Expected new owner without a transfer offer, got %s %+v", got.UserID, got.Transfer)
+	}
+	if len(got.History) != 1 || got.History[0].Details != "u1 → u2 [transfer offer to u3 withdrawn]" {
+		t.Errorf("Unexpected history: %+v", got.History)
+	}
+
+	// 予約中でない予約は割り当て直さない
+	reassign("r2")
+	if got, _ := store.GetReservation("r2"); got.UserID != "u1" || len(got.History) != 0 {
+		t.Errorf("Expected completed reservation to keep its owner, got %s %+v", got.UserID, got.History)
+	}
+}
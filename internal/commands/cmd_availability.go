@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// maxBookButtons はメッセージに付けられるボタンの最大数（5個 × 5行）
+const maxBookButtons = 25
+
+// availabilityCommand は /availability の定義を返す
+func availabilityCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "availability",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "date",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "duration",
+					Required: false,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleAvailability(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.availability",
+	}
+}
+
+// handleAvailability は指定日の空き時間を表示する（自分だけに表示される）
+func handleAvailability(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. ユーザー情報取得
+	userID, username := getUserInfo(i, isDM)
+
+	// 3. パラメータ抽出
+	date, parsedDate, err := parseDateInput(optionMap["date"].StringValue())
+	if err != nil {
+		logger.LogCommand("availability", userID, username, i.ChannelID, false, "Invalid date", nil)
+		respondError(s, i, localize(lang(i), err))
+		return
+	}
+
+	var duration time.Duration
+	if opt, ok := optionMap["duration"]; ok {
+		duration, err = parseDurationInput(opt.StringValue())
+		if err != nil {
+			logger.LogCommand("availability", userID, username, i.ChannelID, false, "Invalid duration", nil)
+			respondError(s, i, localize(lang(i), err))
+			return
+		}
+	}
+
+	// 4. ビジネスロジック - 過去の日付は対象外、当日は現在時刻以降のみ
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	nowJST := time.Now().In(jst)
+	today := nowJST.Format("2006-01-02")
+	if date < today {
+		respondError(s, i, tr(i, "availability.past_date"))
+		return
+	}
+
+	embed, components := availabilityMessage(lang(i), store.GetAllReservations(), date, parsedDate, duration, nowJST)
+
+	// 5. レスポンス
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleAvailabilityToday は掲示板の「空き状況」ボタンが押されたときに、今日の空き時間を表示する（自分だけに表示される）
+func handleAvailabilityToday(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	nowJST := time.Now().In(jst)
+	embed, components := availabilityMessage(lang(i), store.GetAllReservations(), nowJST.Format("2006-01-02"), nowJST, 0, nowJST)
+
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// availabilityTodayButton は今日の空き状況を表示する「今日の空き状況」ボタンを作成する
+func availabilityTodayButton(locale string) discordgo.Button {
+	return discordgo.Button{
+		Label:    i18n.T(locale, "availability.today_button"),
+		Style:    discordgo.SecondaryButton,
+		CustomID: encodeCustomID(actionAvailabilityToday),
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🔍",
+		},
+	}
+}
+
+// availabilityMessage は指定日の空き時間の埋め込みと「予約」ボタンを作成する（当日は現在時刻以降のみ）
+// duration を指定した場合はその長さ以上空いている時間帯だけを表示する
+func availabilityMessage(locale string, reservations []*models.Reservation, date string, parsedDate time.Time, duration time.Duration, nowJST time.Time) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	earliest := ""
+	if date == nowJST.Format("2006-01-02") {
+		earliest = schedule.RoundUpToSlot(nowJST.Format("15:04"))
+	}
+
+	slots := schedule.FreeSlots(reservations, date, earliest)
+	if duration > 0 {
+		slots = schedule.FilterByDuration(slots, duration)
+	}
+
+	title := i18n.T(locale, "availability.title", formatDateWithWeekday(locale, parsedDate))
+	openingHours := i18n.T(locale, "availability.opening_hours", schedule.OpeningTime, schedule.ClosingTime)
+	embed := &discordgo.MessageEmbed{
+		Title:     title,
+		Color:     0xED4245,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: footer(locale, "availability"),
+		},
+	}
+
+	if reason, closed := schedule.ClosedOn(date); closed {
+		embed.Description = openingHours + "\n\n" + i18n.T(locale, "availability.closed", closedLabel(locale, reason))
+		return embed, nil
+	}
+
+	if len(slots) == 0 {
+		embed.Description = openingHours + "\n\n" + i18n.T(locale, "availability.none")
+		if duration > 0 {
+			embed.Description = openingHours + "\n\n" + i18n.T(locale, "availability.none_for_duration", formatDuration(locale, duration))
+		}
+		return embed, nil
+	}
+
+	lines := []string{openingHours}
+	if duration > 0 {
+		lines = append(lines, i18n.T(locale, "availability.for_duration", formatDuration(locale, duration)))
+	}
+	lines = append(lines, "")
+	for _, slot := range slots {
+		lines = append(lines, i18n.T(locale, "availability.slot", slot.Start, slot.End, formatDuration(locale, slot.Duration())))
+	}
+	lines = append(lines, "", i18n.T(locale, "availability.book_hint"))
+
+	embed.Description = strings.Join(lines, "\n")
+	embed.Color = 0x57F287
+	return embed, bookButtonRows(locale, date, slots, duration)
+}
+
+// bookButtonRows は空き時間帯ごとの「予約」ボタンを作成する
+func bookButtonRows(locale, date string, slots []schedule.Slot, duration time.Duration) []discordgo.MessageComponent {
+	rows := []discordgo.MessageComponent{}
+	var buttons []discordgo.MessageComponent
+
+	for idx, slot := range slots {
+		if idx >= maxBookButtons {
+			break
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    i18n.T(locale, "availability.book_button", slot.Start, slot.End),
+			Style:    discordgo.SuccessButton,
+			CustomID: encodeCustomID(actionAvailabilityBook, date, encodeTimeArg(slot.Start), encodeTimeArg(slot.End), strconv.Itoa(int(duration.Minutes()))),
+		})
+		if len(buttons) == 5 {
+			rows = append(rows, discordgo.ActionsRow{Components: buttons})
+			buttons = nil
+		}
+	}
+	if len(buttons) > 0 {
+		rows = append(rows, discordgo.ActionsRow{Components: buttons})
+	}
+	return rows
+}
+
+// handleAvailabilityBook は「予約」ボタンが押されたときに、空き時間帯で埋めた予約フォームを開く
+func handleAvailabilityBook(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+	if len(args) != 4 {
+		respondError(s, i, tr(i, "availability.invalid_button"))
+		return
+	}
+
+	date := args[0]
+	slot := schedule.Slot{Start: decodeTimeArg(args[1]), End: decodeTimeArg(args[2])}
+
+	// 希望時間（未指定の場合は1時間）で終了時刻を決め、空き時間帯の終わりを超えないようにする
+	duration := time.Hour
+	if minutes, err := strconv.Atoi(args[3]); err == nil && minutes > 0 {
+		duration = time.Duration(minutes) * time.Minute
+	}
+	if duration > slot.Duration() {
+		duration = slot.Duration()
+	}
+	endTime := schedule.FromMinutes(schedule.ToMinutes(slot.Start) + int(duration.Minutes()))
+
+	openReservationModal(s, i, date, slot.Start, endTime, "")
+}
@@ -5,11 +5,38 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
 	"github.com/dice/hxs_reservation_system/internal/logging"
 	"github.com/dice/hxs_reservation_system/internal/models"
 	"github.com/dice/hxs_reservation_system/internal/storage"
 )
 
+// completeCommand は /complete の定義を返す
+func completeCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "complete",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "reservation_id",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "comment",
+					Required: false,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleComplete(c.Session, c.Interaction, c.Store, c.Logger, c.AllowedChannelID, c.IsDM)
+		},
+		Help: "help.complete",
+	}
+}
+
 // handleComplete は予約完了コマンドを処理する
 func handleComplete(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool) {
 	// 1. オプション取得
@@ -27,63 +54,88 @@ func handleComplete(s *discordgo.Session, i *discordgo.InteractionCreate, store
 		comment = opt.StringValue()
 	}
 
-	// 3. ビジネスロジック - 予約を取得
-	reservation, err := store.GetReservation(reservationID)
-	if err != nil {
-		respondError(s, i, "予約が見つかりませんでした。予約IDを確認してください。")
+	// 3. 完了処理
+	completeReservation(s, i, store, logger, allowedChannelID, isDM, reservationID, comment)
+}
+
+// completeReservation は予約を完了にする（/complete と予約メッセージのボタンで共通）
+func completeReservation(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, reservationID string, comment string) {
+	// 1. ユーザー情報取得
+	userID, _ := getUserInfo(i, isDM)
+
+	// 2. ビジネスロジック - 予約の確認と完了への更新を1つのロックの中で行う
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found_check_id"))
 		return
 	}
 
-	// 予約を完了に更新
-	reservation.Status = models.StatusCompleted
-	reservation.UpdatedAt = time.Now()
+	var rejection error
+	reservation, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		switch {
+		case r.UserID != userID:
+			// 予約の所有者チェック
+			rejection = newError("complete.not_owner")
+		case r.Status != models.StatusPending:
+			// ステータスチェック
+			rejection = newError("complete.not_pending")
+		}
+		if rejection != nil {
+			return rejection
+		}
 
-	if err := store.UpdateReservation(reservation); err != nil {
-		respondError(s, i, "予約の更新に失敗しました")
-		logger.LogError("ERROR", "handlers.handleComplete", "Failed to update reservation", err, map[string]interface{}{
-			"reservation_id": reservationID,
-		})
+		r.Status = models.StatusCompleted
+		r.UpdatedAt = time.Now()
+		return nil
+	})
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
 		return
 	}
-
-	if err := store.Save(); err != nil {
-		respondError(s, i, "予約の保存に失敗しました")
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.save_failed"))
 		logger.LogError("ERROR", "handlers.handleComplete", "Failed to save reservations", err, map[string]interface{}{
 			"reservation_id": reservationID,
 		})
 		return
 	}
 
-	// 4. レスポンス - 応答
-	respondEmbed(s, i, "🔵 予約を完了にしました", fmt.Sprintf("予約ID: `%s`", reservationID), 0x5865F2, true)
+	// 3. レスポンス - 応答
+	respondEmbed(s, i, tr(i, "complete.done"), tr(i, "reservation.id_line", reservationID), 0x5865F2, true)
 
-	// 5. チャンネル通知
+	// 4. チャンネル通知（チャンネルの全員に見えるため既定の言語で送る）
+	pub := i18n.DefaultLocale()
 	completeFields := []*discordgo.MessageEmbedField{
 		{
-			Name:   "👤 予約者",
+			Name:   i18n.T(pub, "label.user"),
 			Value:  fmt.Sprintf("<@%s>", reservation.UserID),
 			Inline: false,
 		},
 		{
-			Name:   "📅 日付",
+			Name:   i18n.T(pub, "label.date"),
 			Value:  formatDate(reservation.Date),
 			Inline: true,
 		},
 		{
-			Name:   "🕐 時間",
+			Name:   i18n.T(pub, "label.time"),
 			Value:  fmt.Sprintf("%s - %s", reservation.StartTime, reservation.EndTime),
 			Inline: true,
 		},
 	}
 	if comment != "" {
 		completeFields = append(completeFields, &discordgo.MessageEmbedField{
-			Name:   "💬 コメント",
+			Name:   i18n.T(pub, "label.comment"),
 			Value:  comment,
 			Inline: false,
 		})
 	}
 	// DMから実行された場合も、指定チャンネルに通知
-	sendChannelEmbed(s, allowedChannelID, "🔵 予約が終わりました", "", completeFields, 0x5865F2, "部室予約システム  |  complete")
+	sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "complete.announce"), "", completeFields, 0x5865F2, footer(pub, "complete"))
+
+	// 5. 終了時刻前に完了した場合は、残りの時間が空くので空き待ちのユーザーに通知
+	if end, err := reservation.GetEndDateTime(); err == nil && time.Now().Before(end) {
+		notifyWatchers(s, store, logger, reservation, reservation.Date, reservation.StartTime, reservation.EndTime)
+	}
+	disableSourceMessageButtons(s, i)
 
 	// 6. Botステータス更新
 	if UpdateStatusCallback != nil {
@@ -1,16 +1,68 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
 	"github.com/dice/hxs_reservation_system/internal/logging"
 	"github.com/dice/hxs_reservation_system/internal/models"
 	"github.com/dice/hxs_reservation_system/internal/storage"
 )
 
+// editCommand は /edit の定義を返す
+func editCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "edit",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "reservation_id",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "date",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "start_time",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "end_time",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "duration",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "comment",
+					Required: false,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleEdit(c.Session, c.Interaction, c.Store, c.Logger, c.AllowedChannelID, c.IsDM)
+		},
+		Help: "help.edit",
+	}
+}
+
 // handleEdit は予約編集コマンドを処理する
 func handleEdit(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool) {
 	// 1. オプション取得
@@ -20,236 +72,229 @@ func handleEdit(s *discordgo.Session, i *discordgo.InteractionCreate, store *sto
 		optionMap[opt.Name] = opt
 	}
 
-	// 2. ユーザー情報取得
-	userID, username := getUserInfo(i, isDM)
-
-	// 3. パラメータ抽出 - 予約IDを取得
+	// 2. パラメータ抽出 - 予約IDと変更する項目を取得
 	reservationID := optionMap["reservation_id"].StringValue()
 
-	// 4. ビジネスロジック - 予約を取得
-	reservation, err := store.GetReservation(reservationID)
-	if err != nil {
-		respondError(s, i, "指定された予約が見つかりません。")
-		return
+	var req editRequest
+	if opt, ok := optionMap["date"]; ok {
+		value := opt.StringValue()
+		req.Date = &value
 	}
-
-	// 予約の所有者チェック
-	if reservation.UserID != userID {
-		respondError(s, i, "他のユーザーの予約は編集できません。")
-		return
+	if opt, ok := optionMap["start_time"]; ok {
+		value := opt.StringValue()
+		req.StartTime = &value
 	}
-
-	// ステータスチェック
-	if reservation.Status != models.StatusPending {
-		respondError(s, i, "完了またはキャンセルされた予約は編集できません。")
-		return
+	if opt, ok := optionMap["end_time"]; ok {
+		value := opt.StringValue()
+		req.EndTime = &value
+	}
+	if opt, ok := optionMap["duration"]; ok {
+		value := opt.StringValue()
+		req.Duration = &value
+	}
+	if opt, ok := optionMap["comment"]; ok {
+		value := opt.StringValue()
+		req.Comment = &value
 	}
 
-	// 変更前の情報を保持
-	oldDate := reservation.Date
-	oldStartTime := reservation.StartTime
-	oldEndTime := reservation.EndTime
-	oldComment := reservation.Comment
-
-	// 新しい値を取得（指定されていない場合は現在の値を保持）
-	newDate := oldDate
-	newStartTime := oldStartTime
-	newEndTime := oldEndTime
-	newComment := oldComment
-
-	hasChanges := false
+	// 3. 編集処理
+	editReservation(s, i, store, logger, allowedChannelID, isDM, reservationID, req)
+}
 
-	// 日付の変更
-	if opt, ok := optionMap["date"]; ok {
-		dateStr := opt.StringValue()
-		// 日付を正規化
-		dateStr = normalizeDate(dateStr)
+// errEditInvalid は編集内容に誤りや重複があり、予約を変更しなかったことを表す（内容は呼び出し側で応答する）
+var errEditInvalid = errors.New("invalid edit request")
 
-		// 日付の形式を検証
-		var parsedDate time.Time
-		if t, err := time.Parse("2006-01-02", dateStr); err != nil {
-			if t2, err2 := time.Parse("2006/01/02", dateStr); err2 == nil {
-				dateStr = t2.Format("2006-01-02")
-				parsedDate = t2
-			} else {
-				respondError(s, i, "日付の形式が正しくありません（YYYY-MM-DD または YYYY/MM/DD 形式で入力してください）")
-				return
-			}
-		} else {
-			parsedDate = t
-		}
+// editRequest は予約編集の入力値を表す（nil の項目は変更しない）
+type editRequest struct {
+	Date      *string // 予約日（正規化前の入力値）
+	StartTime *string // 開始時間（正規化前の入力値）
+	EndTime   *string // 終了時間（正規化前の入力値）
+	Duration  *string // 利用時間（変更後の開始時間からの長さ、終了時間とは同時に指定できない）
+	Comment   *string // コメント
+}
 
-		// 過去の日付チェック
-		jst := time.FixedZone("Asia/Tokyo", 9*60*60)
-		now := time.Now().In(jst)
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, jst)
-		if parsedDate.Before(today) {
-			respondError(s, i, "過去の日付には変更できません。")
-			return
-		}
+// editReservation は入力値を検証して予約を編集する（/edit と予約メッセージの編集フォームで共通）
+func editReservation(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, reservationID string, req editRequest) {
+	// 1. ユーザー情報取得
+	userID, username := getUserInfo(i, isDM)
 
-		newDate = dateStr
-		hasChanges = true
+	// 2. ビジネスロジック - 予約の確認・入力値の検証・重複チェック・保存を1つのロックの中で行う
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found"))
+		return
 	}
 
-	// 開始時間の変更
-	if opt, ok := optionMap["start_time"]; ok {
-		timeStr := opt.StringValue()
-		// 時刻を正規化
-		timeStr = normalizeTime(timeStr)
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
+
+	var (
+		before      models.Reservation  // 変更前の予約
+		rejection   error               // 所有者・状態・変更の有無による拒否
+		limitErr    error               // 予約の上限による拒否
+		errs        fieldErrors         // 入力値の誤り
+		overlapping *models.Reservation // 時間が重なる他の予約
+		overlapErr  error               // 重複チェックの失敗
+	)
+	reservation, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		// 予約の所有者チェック・ステータスチェック
+		switch {
+		case r.UserID != userID:
+			rejection = newError("edit.not_owner")
+		case r.Status != models.StatusPending:
+			rejection = newError("edit.not_pending")
+		}
+		if rejection != nil {
+			return rejection
+		}
+		before = *r
 
-		if _, err := time.Parse("15:04", timeStr); err != nil {
-			respondError(s, i, "開始時間の形式が正しくありません（HH:MM形式で入力してください）")
-			return
+		// 入力値の検証（指定されていない項目は現在の値を保持）
+		updated, hasChanges, fieldErrs := validateEditRequest(r, req, now)
+		if !hasChanges {
+			rejection = newError("edit.no_changes")
+			return rejection
+		}
+		if len(fieldErrs) > 0 {
+			errs = fieldErrs
+			return errEditInvalid
 		}
-		newStartTime = timeStr
-		hasChanges = true
-	}
 
-	// 終了時間の変更
-	if opt, ok := optionMap["end_time"]; ok {
-		timeStr := opt.StringValue()
-		// 時刻を正規化
-		timeStr = normalizeTime(timeStr)
+		candidate := *r
+		candidate.Date = updated.Date
+		candidate.StartTime = updated.StartTime
+		candidate.EndTime = updated.EndTime
+		candidate.Comment = updated.Comment
 
-		if _, err := time.Parse("15:04", timeStr); err != nil {
-			respondError(s, i, "終了時間の形式が正しくありません（HH:MM形式で入力してください）")
-			return
+		// 予約の上限をチェック（変更前の予約は数えない）
+		if limitErr = currentReservationLimits().check(others, userID, &candidate); limitErr != nil {
+			return limitErr
 		}
-		newEndTime = timeStr
-		hasChanges = true
-	}
 
-	// コメントの変更
-	if opt, ok := optionMap["comment"]; ok {
-		newComment = opt.StringValue()
-		hasChanges = true
-	}
+		// 時間の重複をチェック（自分の予約以外と）
+		overlapping, overlapErr = storage.FindOverlap(&candidate, others)
+		if overlapErr != nil {
+			return overlapErr
+		}
+		if overlapping != nil {
+			return errEditInvalid
+		}
 
-	// 変更がない場合
-	if !hasChanges {
-		respondError(s, i, "変更する項目を少なくとも1つ指定してください。")
+		r.Date = candidate.Date
+		r.StartTime = candidate.StartTime
+		r.EndTime = candidate.EndTime
+		r.Comment = candidate.Comment
+		r.UpdatedAt = time.Now()
+		return nil
+	})
+	switch {
+	case rejection != nil:
+		respondError(s, i, localize(lang(i), rejection))
 		return
-	}
-
-	// 時刻の整合性チェック
-	if newEndTime <= newStartTime {
-		respondError(s, i, "終了時間は開始時間より後である必要があります。")
+	case len(errs) > 0:
+		respondFieldErrors(s, i, tr(i, "edit.failed"), errs, footer(lang(i), "edit"), formRetryComponents(i, userID))
 		return
-	}
-
-	// 重複チェック用に一時的な予約オブジェクトを作成
-	tempReservation := &models.Reservation{
-		ID:        reservationID, // 自分の予約は除外するためにIDを設定
-		UserID:    userID,
-		Username:  username,
-		Date:      newDate,
-		StartTime: newStartTime,
-		EndTime:   newEndTime,
-		Comment:   newComment,
-		Status:    models.StatusPending,
-	}
-
-	// 時間の重複をチェック（自分の予約以外と）
-	overlappingReservation, err := store.CheckOverlap(tempReservation)
-	if err != nil {
-		respondError(s, i, "予約の重複チェックに失敗しました")
-		logger.LogError("ERROR", "handleEdit", "Failed to check overlap", err, map[string]interface{}{
+	case limitErr != nil:
+		respondEmbedWithFooter(s, i, tr(i, "edit.failed"), localize(lang(i), limitErr), nil, 0xED4245, footer(lang(i), "edit"), true)
+		return
+	case overlapErr != nil:
+		respondError(s, i, tr(i, "reservation.overlap_check_failed"))
+		logger.LogError("ERROR", "handleEdit", "Failed to check overlap", overlapErr, map[string]interface{}{
 			"reservation_id": reservationID,
 		})
 		return
-	}
-
-	if overlappingReservation != nil {
+	case overlapping != nil:
 		fields := []*discordgo.MessageEmbedField{
 			{
-				Name:   "📅 日付",
-				Value:  strings.ReplaceAll(newDate, "-", "/"),
+				Name:   tr(i, "label.date"),
+				Value:  strings.ReplaceAll(overlapping.Date, "-", "/"),
 				Inline: false,
 			},
 			{
-				Name:   "👤 予約者",
-				Value:  fmt.Sprintf("<@%s>", overlappingReservation.UserID),
+				Name:   tr(i, "label.user"),
+				Value:  fmt.Sprintf("<@%s>", overlapping.UserID),
 				Inline: true,
 			},
 			{
-				Name:   "🕐 時間",
-				Value:  fmt.Sprintf("%s - %s", overlappingReservation.StartTime, overlappingReservation.EndTime),
+				Name:   tr(i, "label.time"),
+				Value:  fmt.Sprintf("%s - %s", overlapping.StartTime, overlapping.EndTime),
 				Inline: true,
 			},
 		}
-
-		respondEmbedWithFooter(s, i, "🔴 予約を編集できませんでした", "指定された時間は既に予約されています。", fields, 0xED4245, "部室予約システム  |  edit", true)
+		respondEmbedWithFooter(s, i, tr(i, "edit.failed"), tr(i, "reservation.time_taken"), fields, 0xED4245, footer(lang(i), "edit"), true)
 		return
-	}
-
-	// 予約を更新
-	reservation.Date = newDate
-	reservation.StartTime = newStartTime
-	reservation.EndTime = newEndTime
-	reservation.Comment = newComment
-
-	if err := store.Save(); err != nil {
-		respondError(s, i, "予約の更新に失敗しました。")
+	case err != nil:
+		respondError(s, i, tr(i, "reservation.update_failed"))
 		logger.LogError("ERROR", "handleEdit", "Failed to save reservation", err, map[string]interface{}{
 			"reservation_id": reservationID,
 		})
 		return
 	}
 
-	// 成功メッセージ
-	fields := []*discordgo.MessageEmbedField{
-		{
-			Name:   "🆔 予約ID",
-			Value:  reservation.ID,
-			Inline: false,
-		},
-	}
+	oldDate, oldStartTime, oldEndTime, oldComment := before.Date, before.StartTime, before.EndTime, before.Comment
+	newDate, newStartTime, newEndTime, newComment := reservation.Date, reservation.StartTime, reservation.EndTime, reservation.Comment
 
-	// 変更内容を表示
-	if oldDate != newDate {
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   "📅 日付",
-			Value:  fmt.Sprintf("%s → %s", strings.ReplaceAll(oldDate, "-", "/"), strings.ReplaceAll(newDate, "-", "/")),
-			Inline: false,
-		})
-	}
-	if oldStartTime != newStartTime || oldEndTime != newEndTime {
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   "🕐 時間",
-			Value:  fmt.Sprintf("%s-%s → %s-%s", oldStartTime, oldEndTime, newStartTime, newEndTime),
-			Inline: false,
-		})
-	}
-	if oldComment != newComment {
-		oldCommentDisplay := oldComment
-		if oldCommentDisplay == "" {
-			oldCommentDisplay = "（なし）"
+	// 成功メッセージ（変更した項目だけを表示する）
+	changeFields := func(locale string) []*discordgo.MessageEmbedField {
+		var fields []*discordgo.MessageEmbedField
+		if oldDate != newDate {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:   i18n.T(locale, "label.date"),
+				Value:  fmt.Sprintf("%s → %s", strings.ReplaceAll(oldDate, "-", "/"), strings.ReplaceAll(newDate, "-", "/")),
+				Inline: false,
+			})
 		}
-		newCommentDisplay := newComment
-		if newCommentDisplay == "" {
-			newCommentDisplay = "（なし）"
+		if oldStartTime != newStartTime || oldEndTime != newEndTime {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:   i18n.T(locale, "label.time"),
+				Value:  fmt.Sprintf("%s-%s → %s-%s", oldStartTime, oldEndTime, newStartTime, newEndTime),
+				Inline: false,
+			})
 		}
+		if oldComment != newComment {
+			oldCommentDisplay := oldComment
+			if oldCommentDisplay == "" {
+				oldCommentDisplay = i18n.T(locale, "common.none")
+			}
+			newCommentDisplay := newComment
+			if newCommentDisplay == "" {
+				newCommentDisplay = i18n.T(locale, "common.none")
+			}
 
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   "💬 コメント",
-			Value:  fmt.Sprintf("%s → %s", oldCommentDisplay, newCommentDisplay),
-			Inline: false,
-		})
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:   i18n.T(locale, "label.comment"),
+				Value:  fmt.Sprintf("%s → %s", oldCommentDisplay, newCommentDisplay),
+				Inline: false,
+			})
+		}
+		return fields
 	}
 
-	// 5. レスポンス
-	respondEmbedWithFooter(s, i, "🟡 予約を編集しました", "", fields, 0xFEE75C, "部室予約システム  |  edit", true)
+	fields := append([]*discordgo.MessageEmbedField{
+		{
+			Name:   tr(i, "label.reservation_id"),
+			Value:  reservation.ID,
+			Inline: false,
+		},
+	}, changeFields(lang(i))...)
+
+	// 3. レスポンス
+	respondEmbedWithFooter(s, i, tr(i, "edit.done"), "", fields, 0xFEE75C, footer(lang(i), "edit"), true)
 
-	// 6. チャンネル通知(変更がある場合) - 予約IDを除外したfieldsを使用
+	// 4. チャンネル通知(変更がある場合) - 予約IDを除外し、既定の言語で送る
+	pub := i18n.DefaultLocale()
 	if !isDM {
-		sendChannelEmbed(s, allowedChannelID, "🟡 予約が編集されました", fmt.Sprintf("<@%s> さんが予約を編集しました", userID), fields[1:], 0xFEE75C, "部室予約システム  |  edit")
+		sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "edit.announce"), i18n.T(pub, "edit.announce_by", fmt.Sprintf("<@%s>", userID)), changeFields(pub), 0xFEE75C, footer(pub, "edit"))
 	} else if allowedChannelID != "" {
 		// DMから実行された場合も、指定チャンネルに通知
-		sendChannelEmbed(s, allowedChannelID, "🟡 予約が編集されました", fmt.Sprintf("%s さんが予約を編集しました", username), fields[1:], 0xFEE75C, "部室予約システム  |  edit")
+		sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "edit.announce"), i18n.T(pub, "edit.announce_by", username), changeFields(pub), 0xFEE75C, footer(pub, "edit"))
+	}
+
+	// 5. 時間帯が変わった場合は、元の時間帯が空くので空き待ちのユーザーに通知
+	if oldDate != newDate || oldStartTime != newStartTime || oldEndTime != newEndTime {
+		notifyWatchers(s, store, logger, reservation, oldDate, oldStartTime, oldEndTime)
 	}
 
-	// 7. Botステータス更新
+	// 6. Botステータス更新
 	if UpdateStatusCallback != nil {
 		UpdateStatusCallback()
 	}
@@ -0,0 +1,391 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// 予約の延長・終了ボタンのアクション名
+// 延長のカスタムIDは「res_extend:予約ID:延長する分数」、終了は「res_finish:予約ID」の形式
+const (
+	actionReservationExtend = "res_extend"
+	actionReservationFinish = "res_finish"
+)
+
+// extendButtonDurations は延長ボタンで提案する延長時間
+var extendButtonDurations = []time.Duration{30 * time.Minute, time.Hour}
+
+// finishWords は /extend の by で「今すぐ終了」を表す入力
+var finishWords = []string{"now", "finish", "end", "今", "今すぐ", "終了", "今すぐ終了"}
+
+// extendChange は予約の終了時刻の変更内容を表す
+type extendChange struct {
+	Delta  time.Duration // 終了時刻をずらす長さ（負の場合は短縮）
+	Finish bool          // 今すぐ終了する場合は true（Delta は使わない）
+}
+
+// parseExtendInput は /extend の by の入力を解釈する
+// 「30m」「+1h」は延長、「-30m」は短縮、「now」「終了」は今すぐ終了として扱う
+func parseExtendInput(input string) (extendChange, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	for _, word := range finishWords {
+		if input == word {
+			return extendChange{Finish: true}, nil
+		}
+	}
+
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(input, "-"), strings.HasPrefix(input, "－"):
+		sign = -1
+		input = strings.TrimLeft(input, "-－")
+	case strings.HasPrefix(input, "+"), strings.HasPrefix(input, "＋"):
+		input = strings.TrimLeft(input, "+＋")
+	}
+
+	d, err := parseDurationInput(input)
+	if err != nil {
+		return extendChange{}, err
+	}
+	return extendChange{Delta: sign * d}, nil
+}
+
+// newEndTime は予約の終了時刻を変更した後の終了時刻を検証して返す
+// 延長の場合は新しく使う時間帯（現在の終了時刻〜新しい終了時刻）だけを他の予約と照合し、閉室時刻を超えないことを確認する
+// 短縮・終了の場合は開始時刻と現在時刻より後であることを確認する（今すぐ終了は開始時刻を過ぎている場合のみ）。now は日本時間で渡すこと
+func newEndTime(r *models.Reservation, others []*models.Reservation, change extendChange, now time.Time) (string, error) {
+	if r.Status != models.StatusPending {
+		return "", newError("extend.not_pending")
+	}
+
+	today := now.Format("2006-01-02")
+	currentTime := now.Format("15:04")
+	if r.Date < today || (r.Date == today && r.EndTime <= currentTime) {
+		return "", newError("extend.already_ended")
+	}
+
+	if change.Finish {
+		// 開始時刻ちょうどに終了すると利用時間が0分になるため、開始時刻を過ぎてから受け付ける
+		if r.Date != today || currentTime <= r.StartTime {
+			return "", newError("extend.not_started")
+		}
+		return currentTime, nil
+	}
+
+	endMinute := schedule.ToMinutes(r.EndTime) + int(change.Delta/time.Minute)
+	if change.Delta == 0 {
+		return "", newError("extend.zero")
+	}
+
+	if change.Delta < 0 {
+		newEnd := schedule.FromMinutes(max(endMinute, 0))
+		if endMinute <= schedule.ToMinutes(r.StartTime) {
+			return "", newError("extend.before_start", r.StartTime)
+		}
+		if r.Date == today && newEnd <= currentTime {
+			return "", newError("extend.before_now")
+		}
+		return newEnd, nil
+	}
+
+	if endMinute > schedule.ToMinutes(schedule.ClosingTime) {
+		return "", newError("extend.after_closing", schedule.ClosingTime)
+	}
+	newEnd := schedule.FromMinutes(endMinute)
+
+	// 新しく使う時間帯だけを他の予約と照合する
+	claimed := &models.Reservation{ID: r.ID, Date: r.Date, StartTime: r.EndTime, EndTime: newEnd, Status: models.StatusPending}
+	for _, other := range others {
+		if other.ID == r.ID {
+			continue
+		}
+		if overlaps, err := claimed.OverlapsWith(other); err == nil && overlaps {
+			return "", newError("extend.overlap", other.StartTime, other.UserID, other.EndTime)
+		}
+	}
+	return newEnd, nil
+}
+
+// sessionEndComponents は予約の終了前に表示する延長・終了ボタンを作成する
+// 延長ボタンは、その長さだけ後ろの時間帯が空いている場合のみ表示する
+func sessionEndComponents(locale string, r *models.Reservation, others []*models.Reservation, now time.Time) []discordgo.MessageComponent {
+	var buttons []discordgo.MessageComponent
+	for _, d := range extendButtonDurations {
+		newEnd, err := newEndTime(r, others, extendChange{Delta: d}, now)
+		if err != nil {
+			continue
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    i18n.T(locale, "extend.button", formatDuration(locale, d), newEnd),
+			Style:    discordgo.PrimaryButton,
+			CustomID: encodeCustomID(actionReservationExtend, r.ID, strconv.Itoa(int(d/time.Minute))),
+		})
+	}
+	buttons = append(buttons, discordgo.Button{
+		Label:    i18n.T(locale, "extend.finish_button"),
+		Style:    discordgo.SecondaryButton,
+		CustomID: encodeCustomID(actionReservationFinish, r.ID),
+	})
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
+
+// extendCommand は /extend の定義を返す
+func extendCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "extend",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "reservation_id",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "by",
+					Required:     true,
+					Autocomplete: true,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleExtend(c.Session, c.Interaction, c.Store, c.Logger, c.AllowedChannelID, c.IsDM)
+		},
+		Help: "help.extend",
+	}
+}
+
+// handleExtend は予約の終了時刻の延長・短縮・今すぐ終了のコマンドを処理する
+func handleExtend(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. パラメータ抽出
+	reservationID := optionMap["reservation_id"].StringValue()
+	change, err := parseExtendInput(optionMap["by"].StringValue())
+	if err != nil {
+		respondError(s, i, tr(i, "extend.invalid_by"))
+		return
+	}
+
+	// 3. 変更処理
+	extendReservation(s, i, store, logger, allowedChannelID, isDM, reservationID, change)
+}
+
+// handleExtendButton は終了前のリマインダーなどに付けた延長・終了ボタンを処理する
+func handleExtendButton(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, action string, args []string) {
+	if len(args) == 0 || args[0] == "" {
+		respondError(s, i, tr(i, "reservation.invalid_args"))
+		return
+	}
+
+	change := extendChange{Finish: true}
+	if action == actionReservationExtend {
+		minutes, err := strconv.Atoi(strings.Join(args[1:], ""))
+		if err != nil || minutes <= 0 {
+			respondError(s, i, tr(i, "extend.invalid_minutes"))
+			return
+		}
+		change = extendChange{Delta: time.Duration(minutes) * time.Minute}
+	}
+
+	userID, username := getUserInfo(i, isDM)
+	logger.LogCommand("extend", userID, username, i.ChannelID, true, "", map[string]interface{}{
+		"reservation_id": args[0],
+		"via":            "button",
+		"finish":         change.Finish,
+		"minutes":        int(change.Delta / time.Minute),
+	})
+
+	extendReservation(s, i, store, logger, allowedChannelID, isDM, args[0], change)
+}
+
+// extendReservation は予約の終了時刻を変更する（/extend と延長・終了ボタンで共通）
+// 検証と保存は1つのロックの中で行い、延長中に他の予約が入った場合は変更しない
+func extendReservation(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, reservationID string, change extendChange) {
+	// 1. ユーザー情報取得
+	userID, _ := getUserInfo(i, isDM)
+
+	// 2. ビジネスロジック - 予約を取得
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found"))
+		return
+	}
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
+
+	// 予約者の確認も同じロックの中で行う（確認後に譲渡・割り当て直しがあった場合は変更しない）
+	var (
+		oldEndTime string
+		rejection  error
+	)
+	updated, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		if r.UserID != userID {
+			rejection = newError("extend.not_owner")
+			return rejection
+		}
+		oldEndTime = r.EndTime
+
+		newEnd, err := newEndTime(r, others, change, now)
+		if err != nil {
+			rejection = err
+			return err
+		}
+
+		candidate := *r
+		candidate.EndTime = newEnd
+		if err := currentReservationLimits().check(others, userID, &candidate); err != nil {
+			rejection = err
+			return err
+		}
+
+		action := "extend"
+		if change.Finish {
+			action = "finish"
+			r.Status = models.StatusCompleted
+		}
+		r.AddHistory(action, userID, "", fmt.Sprintf("%s → %s", r.EndTime, newEnd))
+		r.EndTime = newEnd
+		r.UpdatedAt = time.Now()
+		return nil
+	})
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	}
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.save_failed"))
+		logger.LogError("ERROR", "extendReservation", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	// 3. レスポンス
+	titleKey, publicTitleKey := "extend.done", "extend.announce"
+	switch {
+	case change.Finish:
+		titleKey, publicTitleKey = "extend.finished", "complete.announce"
+	case change.Delta < 0:
+		titleKey, publicTitleKey = "extend.shortened", "extend.announce_shortened"
+	}
+
+	fields := func(locale string) []*discordgo.MessageEmbedField {
+		return []*discordgo.MessageEmbedField{
+			{
+				Name:   i18n.T(locale, "label.date"),
+				Value:  formatDate(updated.Date),
+				Inline: true,
+			},
+			{
+				Name:   i18n.T(locale, "label.time"),
+				Value:  fmt.Sprintf("%s - %s → %s - %s", updated.StartTime, oldEndTime, updated.StartTime, updated.EndTime),
+				Inline: true,
+			},
+		}
+	}
+	respondEmbedWithFooter(s, i, tr(i, titleKey), tr(i, "reservation.id_line", updated.ID), fields(lang(i)), 0xFEE75C, footer(lang(i), "extend"), true)
+
+	pub := i18n.DefaultLocale()
+	publicFields := append([]*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(pub, "label.user"),
+			Value:  fmt.Sprintf("<@%s>", updated.UserID),
+			Inline: false,
+		},
+	}, fields(pub)...)
+	sendChannelEmbed(s, allowedChannelID, i18n.T(pub, publicTitleKey), "", publicFields, 0xFEE75C, footer(pub, "extend"))
+
+	// 4. 短縮・終了で空いた時間帯を空き待ちのユーザーに通知
+	if updated.EndTime < oldEndTime {
+		notifyWatchers(s, store, logger, updated, updated.Date, updated.EndTime, oldEndTime)
+	}
+	disableSourceMessageButtons(s, i)
+
+	// 5. Botステータス更新
+	if UpdateStatusCallback != nil {
+		UpdateStatusCallback()
+	}
+}
+
+// getExtendSuggestions は /extend の by の候補を生成する
+// 予約が分かる場合は、延長できる長さ（後ろが空いている長さ）だけを新しい終了時刻付きで表示する
+func getExtendSuggestions(locale string, store *storage.Storage, reservationID, userID, input string) []*discordgo.ApplicationCommandOptionChoice {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
+
+	reservation, err := store.GetReservation(reservationID)
+	if err != nil || reservation.UserID != userID {
+		reservation = nil
+	}
+
+	type candidate struct {
+		label  string
+		value  string
+		change extendChange
+	}
+	var candidates []candidate
+	for _, c := range []struct {
+		value  string
+		change extendChange
+	}{
+		{"30m", extendChange{Delta: 30 * time.Minute}},
+		{"1h", extendChange{Delta: time.Hour}},
+		{"1h30m", extendChange{Delta: 90 * time.Minute}},
+		{"2h", extendChange{Delta: 2 * time.Hour}},
+		{"-30m", extendChange{Delta: -30 * time.Minute}},
+		{"-1h", extendChange{Delta: -time.Hour}},
+		{"now", extendChange{Finish: true}},
+	} {
+		candidates = append(candidates, candidate{extendChangeLabel(locale, c.change), c.value, c.change})
+	}
+	if input = strings.TrimSpace(input); input != "" {
+		if change, err := parseExtendInput(input); err == nil {
+			candidates = []candidate{{input, input, change}}
+		}
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, c := range candidates {
+		name := c.label
+		if reservation != nil {
+			newEnd, err := newEndTime(reservation, store.GetAllReservations(), c.change, now)
+			if err != nil {
+				continue
+			}
+			name = i18n.T(locale, "extend.choice", c.label, reservation.StartTime, newEnd)
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: truncateText(name, 100), Value: c.value})
+	}
+	return choices
+}
+
+// extendChangeLabel は変更内容の表示名（「30分延長」「今すぐ終了」など）を返す
+func extendChangeLabel(locale string, change extendChange) string {
+	switch {
+	case change.Finish:
+		return i18n.T(locale, "extend.finish_now")
+	case change.Delta < 0:
+		return i18n.T(locale, "extend.shorten_by", formatDuration(locale, -change.Delta))
+	default:
+		return i18n.T(locale, "extend.extend_by", formatDuration(locale, change.Delta))
+	}
+}
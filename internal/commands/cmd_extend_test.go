@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+func TestParseExtendInput(t *testing.T) {
+	tests := []struct {
+		input string
+		want  extendChange
+	}{
+		{"30m", extendChange{Delta: 30 * time.Minute}},
+		{"+1h", extendChange{Delta: time.Hour}},
+		{"-30m", extendChange{Delta: -30 * time.Minute}},
+		{"－1時間", extendChange{Delta: -time.Hour}},
+		{"90", extendChange{Delta: 90 * time.Minute}},
+		{"now", extendChange{Finish: true}},
+		{"終了", extendChange{Finish: true}},
+	}
+	for _, tt := range tests {
+		got, err := parseExtendInput(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("parseExtendInput(%q) = %+v, %v; want %+v", tt.input, got, err, tt.want)
+		}
+	}
+
+	if _, err := parseExtendInput("later"); err == nil {
+		t.Error("Expected error for invalid input")
+	}
+}
+
+func TestNewEndTime(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 14, 10, 0, 0, jst)
+	r := &models.Reservation{ID: "r1", UserID: "u1", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusPending}
+	others := []*models.Reservation{
+		r,
+		// 延長で新しく使う時間帯と重ならない予約
+		{ID: "r2", UserID: "u2", Date: "2025-11-20", StartTime: "13:00", EndTime: "14:00", Status: models.StatusPending},
+		{ID: "r3", UserID: "u2", Date: "2025-11-20", StartTime: "16:00", EndTime: "17:00", Status: models.StatusPending},
+		{ID: "r4", UserID: "u3", Date: "2025-11-20", StartTime: "15:00", EndTime: "16:00", Status: models.StatusCancelled},
+	}
+
+	tests := []struct {
+		name    string
+		r       *models.Reservation
+		change  extendChange
+		want    string
+		wantErr string
+	}{
+		{"extend into free slot", r, extendChange{Delta: time.Hour}, "16:00", ""},
+		{"extend into next reservation", r, extendChange{Delta: 90 * time.Minute}, "", "16:00 から"},
+		{"shorten", r, extendChange{Delta: -30 * time.Minute}, "14:30", ""},
+		{"shorten before now", r, extendChange{Delta: -50 * time.Minute}, "", "現在時刻より前"},
+		{"shorten before start", r, extendChange{Delta: -time.Hour}, "", "開始時刻"},
+		{"finish", r, extendChange{Finish: true}, "14:10", ""},
+		{"past closing time", &models.Reservation{ID: "r5", Date: "2025-11-20", StartTime: "19:00", EndTime: "20:30", Status: models.StatusPending},
+			extendChange{Delta: time.Hour}, "", "閉室時刻"},
+		{"finish before start", &models.Reservation{ID: "r6", Date: "2025-11-21", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending},
+			extendChange{Finish: true}, "", "始まっていない"},
+		{"finish at start time", &models.Reservation{ID: "r9", Date: "2025-11-20", StartTime: "14:10", EndTime: "15:00", Status: models.StatusPending},
+			extendChange{Finish: true}, "", "始まっていない"},
+		{"already ended", &models.Reservation{ID: "r7", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending},
+			extendChange{Delta: 30 * time.Minute}, "", "終了時刻を過ぎた"},
+		{"completed", &models.Reservation{ID: "r8", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusCompleted},
+			extendChange{Delta: 30 * time.Minute}, "", "完了またはキャンセル"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newEndTime(tt.r, others, tt.change, now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %q, %v", tt.wantErr, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("newEndTime() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionEndComponents(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 14, 50, 0, 0, jst)
+	r := &models.Reservation{ID: "r1", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusPending}
+	others := []*models.Reservation{
+		{ID: "r2", Date: "2025-11-20", StartTime: "15:30", EndTime: "16:00", Status: models.StatusPending},
+	}
+
+	// 15:30 から予約があるため、30分延長と終了のボタンだけを表示する
+	row := sessionEndComponents("ja", r, others, now)[0].(discordgo.ActionsRow)
+	var ids []string
+	for _, c := range row.Components {
+		ids = append(ids, c.(discordgo.Button).CustomID)
+	}
+	want := []string{"res_extend:r1:30", "res_finish:r1"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected buttons: %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// historyLineLimit は履歴に一覧表示する最大件数（新しい順）
+const historyLineLimit = 20
+
+// historySummary は予約履歴の集計結果を表す
+type historySummary struct {
+	Total     int           // 件数
+	Completed int           // 完了した予約の件数
+	Cancelled int           // キャンセルした予約の件数
+	Pending   int           // 予約中の件数
+	UsedTime  time.Duration // 完了した予約の合計利用時間
+}
+
+// summarizeHistory は予約の一覧を集計する
+func summarizeHistory(reservations []*models.Reservation) historySummary {
+	summary := historySummary{Total: len(reservations)}
+	for _, r := range reservations {
+		switch r.Status {
+		case models.StatusCompleted:
+			summary.Completed++
+			if minutes := schedule.ToMinutes(r.EndTime) - schedule.ToMinutes(r.StartTime); minutes > 0 {
+				summary.UsedTime += time.Duration(minutes) * time.Minute
+			}
+		case models.StatusCancelled:
+			summary.Cancelled++
+		case models.StatusPending:
+			summary.Pending++
+		}
+	}
+	return summary
+}
+
+// historyCommand は /history の定義を返す
+func historyCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "history",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "status",
+					Required: false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Value: "active"},
+						{Value: "completed"},
+						{Value: "cancelled"},
+						{Value: "past"},
+						{Value: "all"},
+					},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "from",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "to",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:     discordgo.ApplicationCommandOptionUser,
+					Name:     "user",
+					Required: false,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleHistory(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.history",
+	}
+}
+
+// handleHistory は過去を含む予約履歴と利用時間の合計を表示する（自分だけに表示される）
+// 保持期間を過ぎてアーカイブされた予約も対象にする。管理者は他のユーザーの履歴も表示できる
+func handleHistory(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. ユーザー情報取得
+	userID, _ := getUserInfo(i, isDM)
+
+	// 2. パラメータ抽出 - 絞り込み条件（状態は省略時すべて）
+	q, err := listQueryFromOptions(listKindMine, i.ApplicationCommandData().Options, s)
+	if err != nil {
+		respondError(s, i, localize(lang(i), err))
+		return
+	}
+	hasStatus := false
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "status" {
+			hasStatus = true
+		}
+	}
+	if !hasStatus {
+		q.Status = statusFilterAll
+	}
+
+	targetID := userID
+	if q.UserID != "" && q.UserID != userID {
+		if !isAdmin(i) {
+			respondError(s, i, tr(i, "history.admin_only"))
+			return
+		}
+		targetID = q.UserID
+	}
+
+	// 3. ビジネスロジック - 現在の予約とアーカイブから検索
+	reservations, err := store.FindHistory(storage.ReservationFilter{
+		UserID:   targetID,
+		Statuses: q.statuses(),
+		FromDate: q.From,
+		ToDate:   q.To,
+	})
+	if err != nil {
+		respondError(s, i, tr(i, "history.load_failed"))
+		logger.LogError("ERROR", "handleHistory", "Failed to load reservation history", err, map[string]interface{}{
+			"target_user_id": targetID,
+		})
+		return
+	}
+
+	summary := summarizeHistory(reservations)
+
+	// 4. レスポンス
+	locale := lang(i)
+	q.UserID = targetID
+	condition := q.describe(locale)
+	if q.From == "" && q.To == "" {
+		condition += " / " + i18n.T(locale, "history.all_periods")
+	}
+
+	var lines []string
+	for idx := len(reservations) - 1; idx >= 0 && len(lines) < historyLineLimit; idx-- {
+		r := reservations[idx]
+		line := fmt.Sprintf("%s `%s` %s - %s", statusEmoji(r.Status), formatDate(r.Date), r.StartTime, r.EndTime)
+		if r.Comment != "" {
+			line += "  " + truncateText(r.Comment, 40)
+		}
+		lines = append(lines, line)
+	}
+
+	description := condition + "\n\n"
+	if len(lines) == 0 {
+		description += i18n.T(locale, "list.empty_filtered")
+	} else {
+		description += strings.Join(lines, "\n")
+		if len(reservations) > len(lines) {
+			description += "\n" + i18n.T(locale, "history.more", len(reservations)-len(lines))
+		}
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(locale, "history.total"),
+			Value:  i18n.T(locale, "history.count", summary.Total),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "history.used_time"),
+			Value:  i18n.T(locale, "history.used_time_value", formatHours(locale, summary.UsedTime), summary.Completed),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "stats.cancelled"),
+			Value:  i18n.T(locale, "history.count", summary.Cancelled),
+			Inline: true,
+		},
+	}
+	if summary.Pending > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(locale, "history.pending"),
+			Value:  i18n.T(locale, "history.count", summary.Pending),
+			Inline: true,
+		})
+	}
+
+	respondEmbedWithFooter(s, i, i18n.T(locale, "history.title"), description, fields, 0x99AAB5, footer(locale, "history"), true)
+}
+
+// formatHours は時間の長さを「12.5時間」のような形式にフォーマットする
+func formatHours(locale string, d time.Duration) string {
+	return i18n.T(locale, "history.hours", strings.TrimSuffix(fmt.Sprintf("%.1f", d.Hours()), ".0"))
+}
+
+// truncateText は文字列を指定の文字数に切り詰める
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
+}
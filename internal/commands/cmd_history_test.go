@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+func TestSummarizeHistory(t *testing.T) {
+	reservations := []*models.Reservation{
+		{StartTime: "10:00", EndTime: "11:30", Status: models.StatusCompleted},
+		{StartTime: "13:00", EndTime: "14:00", Status: models.StatusCompleted},
+		{StartTime: "15:00", EndTime: "17:00", Status: models.StatusCancelled},
+		{StartTime: "18:00", EndTime: "19:00", Status: models.StatusPending},
+	}
+
+	summary := summarizeHistory(reservations)
+	if summary.Total != 4 || summary.Completed != 2 || summary.Cancelled != 1 || summary.Pending != 1 {
+		t.Errorf("Unexpected counts: %+v", summary)
+	}
+
+	// 利用時間は完了した予約のみ合計する
+	if summary.UsedTime != 150*time.Minute {
+		t.Errorf("Expected 2h30m used, got %v", summary.UsedTime)
+	}
+	if got := formatHours("ja", summary.UsedTime); got != "2.5時間" {
+		t.Errorf("Expected 2.5時間, got %s", got)
+	}
+	if got := formatHours("ja", 2*time.Hour); got != "2時間" {
+		t.Errorf("Expected 2時間, got %s", got)
+	}
+}
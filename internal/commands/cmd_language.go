@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// languageAuto は /language で設定を解除し、Discordの言語設定に従うことを表す値
+const languageAuto = "auto"
+
+// languageCommand は /language の定義を返す
+func languageCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "language",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "lang",
+					Required: false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Value: i18n.Japanese},
+						{Value: i18n.English},
+						{Value: languageAuto},
+					},
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleLanguage(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.language",
+	}
+}
+
+// handleLanguage は表示言語を設定する（自分だけに表示される）
+// lang を省略した場合は現在の設定を表示する。設定はDMでのお知らせにも使う
+func handleLanguage(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. ユーザー情報取得
+	userID, _ := getUserInfo(i, isDM)
+
+	// 3. 省略時は現在の設定を表示
+	opt, ok := optionMap["lang"]
+	if !ok {
+		current := tr(i, "language.auto")
+		if locale := store.GetUserSettings(userID).Locale; i18n.IsSupported(locale) {
+			current = i18n.T(locale, "language.name")
+		}
+		respondEmbed(s, i, tr(i, "language.title"), tr(i, "language.current", current), 0x5865F2, true)
+		return
+	}
+
+	locale := opt.StringValue()
+	if locale != languageAuto && !i18n.IsSupported(locale) {
+		respondError(s, i, tr(i, "language.invalid"))
+		return
+	}
+
+	// 4. ビジネスロジック - 設定を保存
+	err := store.UpdateUserSettings(userID, func(settings *models.UserSettings) {
+		settings.Locale = ""
+		if locale != languageAuto {
+			settings.Locale = locale
+		}
+	})
+	if err != nil {
+		respondError(s, i, tr(i, "language.save_failed"))
+		logger.LogError("ERROR", "handleLanguage", "Failed to save user settings", err, map[string]interface{}{
+			"user_id": userID,
+			"locale":  locale,
+		})
+		return
+	}
+
+	// 5. レスポンス - 設定した言語で表示する（自動に戻した場合は次の操作からDiscordの言語設定に従う）
+	if locale == languageAuto {
+		respondEmbed(s, i, tr(i, "language.title"), tr(i, "language.reset"), 0x57F287, true)
+		return
+	}
+	i.Locale = discordgo.Locale(locale)
+	respondEmbed(s, i, tr(i, "language.title"), tr(i, "language.set", tr(i, "language.name")), 0x57F287, true)
+}
@@ -1,164 +1,81 @@
 package commands
 
 import (
-	"fmt"
-	"sort"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/dice/hxs_reservation_system/internal/logging"
-	"github.com/dice/hxs_reservation_system/internal/models"
 	"github.com/dice/hxs_reservation_system/internal/storage"
 )
 
+// myReservationsCommand は /my-reservations の定義を返す
+func myReservationsCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name:    "my-reservations",
+			Options: listFilterOptions(),
+		},
+		Handler: func(c *CommandContext) {
+			handleMyReservations(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.my_reservations",
+	}
+}
+
+// viewReservationsCommand はメンバーの右クリックメニュー（アプリ）に表示する「予約を見る」の定義を返す
+// ユーザーコマンドは説明・オプションを指定できないため、他の言語での表示名だけを設定する
+func viewReservationsCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name:              ViewReservationsCommandName,
+			NameLocalizations: localizationsOf("command.view_reservations"),
+			Type:              discordgo.UserApplicationCommand,
+		},
+		Handler: func(c *CommandContext) {
+			handleViewMemberReservations(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		GuildOnly: true,
+		Help:      "help.view_reservations",
+	}
+}
+
 // handleMyReservations は自分の予約一覧を表示する
 func handleMyReservations(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
 	// 1. ユーザー情報取得
 	userID, _ := getUserInfo(i, isDM)
 
-	// 2. データ取得 - 自分の予約を取得
-	allReservations := store.GetUserReservations(userID)
-
-	// 3. データ処理 - 完了・キャンセル済みを除外
-	reservations := make([]*models.Reservation, 0)
-	for _, r := range allReservations {
-		if r.Status != models.StatusCompleted && r.Status != models.StatusCancelled {
-			reservations = append(reservations, r)
-		}
-	}
-
-	// 4. レスポンス - 予約がない場合
-	if len(reservations) == 0 {
-		respondEmbed(s, i, "⚪ あなたの予約一覧", "あなたの予約はありません。", 0xFFFFFF, true)
+	// 2. パラメータ抽出 - 絞り込み条件
+	q, err := listQueryFromOptions(listKindMine, i.ApplicationCommandData().Options, s)
+	if err != nil {
+		respondError(s, i, localize(lang(i), err))
 		return
 	}
 
-	// 5. データ処理 - 日時でソート
-	sort.Slice(reservations, func(a, b int) bool {
-		tA, errA := reservations[a].GetStartDateTime()
-		tB, errB := reservations[b].GetStartDateTime()
-		if errA != nil || errB != nil {
-			return a < b
-		}
-		return tA.Before(tB)
+	// 3. レスポンス - 1ページ目を表示（以降はボタンでページ送り）
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: buildListPage(lang(i), store, q, userID),
 	})
+}
 
-	// 6. レスポンス - 最初のメッセージ（ヘッダー + 最初の予約9件）
-	embeds := []*discordgo.MessageEmbed{}
-
-	// ヘッダー
-	headerDescription := fmt.Sprintf("現在 %d 件の予約があります", len(reservations))
-	headerEmbed := createHeaderEmbed("⚪ あなたの予約一覧", headerDescription, 0xFFFFFF, "部室予約システム  |  my-reservations")
-	embeds = append(embeds, headerEmbed)
-
-	// 最初の9件を表示
-	maxFirstMessage := 9
-	for idx := 0; idx < len(reservations) && idx < maxFirstMessage; idx++ {
-		r := reservations[idx]
-
-		fields := []*discordgo.MessageEmbedField{
-			{
-				Name:   "🆔 予約ID",
-				Value:  fmt.Sprintf("`%s`", r.ID),
-				Inline: false,
-			},
-			{
-				Name:   "📅 日付",
-				Value:  formatDate(r.Date),
-				Inline: true,
-			},
-			{
-				Name:   "🕐 時間",
-				Value:  fmt.Sprintf("%s - %s", r.StartTime, r.EndTime),
-				Inline: true,
-			},
-		}
-
-		if r.Comment != "" {
-			fields = append(fields, &discordgo.MessageEmbedField{
-				Name:   "💬 コメント",
-				Value:  r.Comment,
-				Inline: false,
-			})
-		}
+// handleViewMemberReservations はメンバーの右クリックメニュー「予約を見る」を処理する（自分だけに表示される）
+// /my-reservations と同じ表示で対象メンバーの今後の予約を表示し、予約IDは本人が実行した場合のみ表示する
+func handleViewMemberReservations(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. ユーザー情報取得
+	userID, _ := getUserInfo(i, isDM)
 
-		reservationEmbed := createReservationEmbed(
-			fmt.Sprintf("No.%d", idx+1),
-			fields,
-			0xFFFFFF,
-			fmt.Sprintf("部室予約システム  |  my-reservations  |  予約 %d/%d", idx+1, len(reservations)),
-		)
-		embeds = append(embeds, reservationEmbed)
+	// 2. パラメータ抽出 - 対象メンバーと今日以降の予約中の予約
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	q := listQuery{
+		Kind:   listKindMember,
+		Status: statusFilterActive,
+		From:   time.Now().In(jst).Format("2006-01-02"),
+		UserID: i.ApplicationCommandData().TargetID,
 	}
 
-	// 最初のメッセージを送信
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	// 3. レスポンス - 1ページ目を表示（以降はボタンでページ送り）
+	respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: embeds,
-			Flags:  discordgo.MessageFlagsEphemeral,
-		},
+		Data: buildListPage(lang(i), store, q, userID),
 	})
-
-	// 残りの予約を複数のメッセージで送信（10件ごと）
-	if len(reservations) > maxFirstMessage {
-		// 10件ずつ（ヘッダーなし）のフォローアップメッセージを送信
-		itemsPerMessage := 10
-		for startIdx := maxFirstMessage; startIdx < len(reservations); startIdx += itemsPerMessage {
-			endIdx := startIdx + itemsPerMessage
-			if endIdx > len(reservations) {
-				endIdx = len(reservations)
-			}
-
-			messageEmbeds := []*discordgo.MessageEmbed{}
-			for idx := startIdx; idx < endIdx; idx++ {
-				r := reservations[idx]
-
-				fields := []*discordgo.MessageEmbedField{
-					{
-						Name:   "🆔 予約ID",
-						Value:  fmt.Sprintf("`%s`", r.ID),
-						Inline: false,
-					},
-					{
-						Name:   "📅 日付",
-						Value:  formatDate(r.Date),
-						Inline: true,
-					},
-					{
-						Name:   "🕐 時間",
-						Value:  fmt.Sprintf("%s - %s", r.StartTime, r.EndTime),
-						Inline: true,
-					},
-				}
-
-				if r.Comment != "" {
-					fields = append(fields, &discordgo.MessageEmbedField{
-						Name:   "💬 コメント",
-						Value:  r.Comment,
-						Inline: false,
-					})
-				}
-
-				reservationEmbed := createReservationEmbed(
-					fmt.Sprintf("No.%d", idx+1),
-					fields,
-					0xFFFFFF,
-					fmt.Sprintf("部室予約システム  |  my-reservations  |  予約 %d/%d", idx+1, len(reservations)),
-				)
-				messageEmbeds = append(messageEmbeds, reservationEmbed)
-			}
-
-			// フォローアップメッセージを送信（Ephemeral）
-			_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Embeds: messageEmbeds,
-				Flags:  discordgo.MessageFlagsEphemeral,
-			})
-			if err != nil {
-				logger.LogError("ERROR", "handleMyReservations", "Failed to send followup message", err, map[string]interface{}{
-					"start_idx": startIdx,
-					"end_idx":   endIdx,
-				})
-			}
-		}
-	}
 }
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// rankingCommand は /ranking の定義を返す
+func rankingCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "ranking",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:     discordgo.ApplicationCommandOptionBoolean,
+					Name:     "show",
+					Required: false,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleRanking(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.ranking",
+	}
+}
+
+// handleRanking は週間レポートの利用時間のランキングに名前を載せるかどうかを設定する（自分だけに表示される）
+// show を省略した場合は現在の設定を表示する。既定では載せない
+func handleRanking(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. ユーザー情報取得
+	userID, _ := getUserInfo(i, isDM)
+
+	// 3. 省略時は現在の設定を表示
+	opt, ok := optionMap["show"]
+	if !ok {
+		respondEmbed(s, i, tr(i, "ranking.title"), rankingStatus(i, store.GetUserSettings(userID).ShowInRanks), 0x5865F2, true)
+		return
+	}
+	show := opt.BoolValue()
+
+	// 4. ビジネスロジック - 設定を保存
+	err := store.UpdateUserSettings(userID, func(settings *models.UserSettings) {
+		settings.ShowInRanks = show
+	})
+	if err != nil {
+		respondError(s, i, tr(i, "ranking.save_failed"))
+		logger.LogError("ERROR", "handleRanking", "Failed to save user settings", err, map[string]interface{}{
+			"user_id": userID,
+			"show":    show,
+		})
+		return
+	}
+
+	// 5. レスポンス
+	respondEmbed(s, i, tr(i, "ranking.title"), rankingStatus(i, show), 0x57F287, true)
+}
+
+// rankingStatus はランキングに名前を載せるかどうかの設定の説明を返す
+func rankingStatus(i *discordgo.InteractionCreate, show bool) string {
+	if show {
+		return tr(i, "ranking.shown")
+	}
+	return tr(i, "ranking.hidden")
+}
@@ -5,11 +5,56 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
 	"github.com/dice/hxs_reservation_system/internal/logging"
 	"github.com/dice/hxs_reservation_system/internal/models"
 	"github.com/dice/hxs_reservation_system/internal/storage"
 )
 
+// reserveCommand は /reserve の定義を返す
+func reserveCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "reserve",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "date",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "start_time",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "end_time",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "duration",
+					Required:     false,
+					Autocomplete: true,
+				},
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "comment",
+					Required: false,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleReserve(c.Session, c.Interaction, c.Store, c.Logger, c.AllowedChannelID, c.IsDM)
+		},
+		Help: "help.reserve",
+	}
+}
+
 // handleReserve は予約作成コマンドを処理する
 func handleReserve(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool) {
 	// 1. オプション取得
@@ -19,131 +64,82 @@ func handleReserve(s *discordgo.Session, i *discordgo.InteractionCreate, store *
 		optionMap[opt.Name] = opt
 	}
 
-	// 2. ユーザー情報取得
-	userID, username := getUserInfo(i, isDM)
-
-	// 3. パラメータ抽出 - 必須パラメータを取得
-	date := optionMap["date"].StringValue()
-	startTime := optionMap["start_time"].StringValue()
-
-	// 日付を正規化（YYYY/M/D → YYYY/MM/DD）
-	date = normalizeDate(date)
-
-	// 時刻を正規化（H:MM → HH:MM）
-	startTime = normalizeTime(startTime)
+	// 2. パラメータ抽出 - 必須パラメータを取得
+	req := reservationRequest{
+		Date: optionMap["date"].StringValue(),
+	}
 
-	// オプションパラメータを取得
-	var endTime string
+	// オプションパラメータを取得（開始時間は日付に「明日14時」のように時刻を含めた場合は省略できる）
+	if opt, ok := optionMap["start_time"]; ok {
+		req.StartTime = opt.StringValue()
+	}
 	if opt, ok := optionMap["end_time"]; ok {
-		endTime = opt.StringValue()
-		// 時刻を正規化（H:MM → HH:MM）
-		endTime = normalizeTime(endTime)
-	} else {
-		// 終了時間が指定されていない場合は開始時刻+1時間
-		start, err := time.Parse("15:04", startTime)
-		if err != nil {
-			respondError(s, i, "開始時間の形式が正しくありません（HH:MM形式で入力してください）")
-			return
-		}
-		endTime = start.Add(1 * time.Hour).Format("15:04")
+		req.EndTime = opt.StringValue()
+	}
+	if opt, ok := optionMap["duration"]; ok {
+		req.Duration = opt.StringValue()
 	}
-
-	comment := ""
 	if opt, ok := optionMap["comment"]; ok {
-		comment = opt.StringValue()
+		req.Comment = opt.StringValue()
 	}
 
+	// 3. 予約作成
+	createReservation(s, i, store, logger, allowedChannelID, isDM, req)
+}
+
+// reservationRequest は予約作成の入力値を表す
+type reservationRequest struct {
+	Date      string // 予約日（正規化前の入力値）
+	StartTime string // 開始時間（正規化前の入力値、空の場合は日付に含まれる時刻）
+	EndTime   string // 終了時間（空の場合は開始時刻+利用時間、利用時間も空の場合は+1時間）
+	Duration  string // 利用時間（30m・1h30m・90 など、終了時間とは同時に指定できない）
+	Comment   string // コメント（任意）
+}
+
+// createReservation は入力値を検証して予約を作成する（/reserve と予約フォームで共通）
+func createReservation(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, req reservationRequest) {
+	// 1. ユーザー情報取得
+	userID, username := getUserInfo(i, isDM)
+
+	// 2. ビジネスロジック - 入力値の検証と正規化
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	input, errs := validateReservationRequest(req, time.Now().In(jst))
+
 	// ログ用パラメータを構築
 	parameters := map[string]interface{}{
-		"date":       date,
-		"start_time": startTime,
-		"end_time":   endTime,
-	}
-	if comment != "" {
-		parameters["comment"] = comment
+		"date":       req.Date,
+		"start_time": req.StartTime,
+		"end_time":   input.EndTime,
 	}
-
-	// 4. ビジネスロジック - 日付と時間の形式を検証（YYYY-MM-DD または YYYY/MM/DD を許可）
-	var reservationDate time.Time
-	if parsedDate, err := time.Parse("2006-01-02", date); err != nil {
-		if t2, err2 := time.Parse("2006/01/02", date); err2 == nil {
-			// 正規化して保存用は YYYY-MM-DD に統一
-			date = t2.Format("2006-01-02")
-			reservationDate = t2
-		} else {
-			errorMsg := "日付の形式が正しくありません（YYYY-MM-DD または YYYY/MM/DD）"
-			logger.LogCommand("reserve", userID, username, i.ChannelID, false, errorMsg, parameters)
-			respondError(s, i, errorMsg)
-			return
-		}
-	} else {
-		reservationDate = parsedDate
-	}
-
-	var startTimeParsed time.Time
-	if t, err := time.Parse("15:04", startTime); err != nil {
-		errorMsg := "開始時間の形式が正しくありません（HH:MM形式で入力してください）"
-		logger.LogCommand("reserve", userID, username, i.ChannelID, false, errorMsg, parameters)
-		respondError(s, i, errorMsg)
-		return
-	} else {
-		startTimeParsed = t
+	if req.Duration != "" {
+		parameters["duration"] = req.Duration
 	}
-
-	if _, err := time.Parse("15:04", endTime); err != nil {
-		errorMsg := "終了時間の形式が正しくありません（HH:MM形式で入力してください）"
-		logger.LogCommand("reserve", userID, username, i.ChannelID, false, errorMsg, parameters)
-		respondError(s, i, errorMsg)
-		return
+	if req.Comment != "" {
+		parameters["comment"] = req.Comment
 	}
 
-	// 終了時刻が開始時刻より前または同じ時刻でないかチェック
-	if endTime <= startTime {
-		errorMsg := fmt.Sprintf("❌ 終了時刻は開始時刻より後である必要があります\n\n"+
-			"**開始時刻:** %s\n"+
-			"**終了時刻:** %s\n\n"+
-			"終了時刻を開始時刻より後の時刻に設定してください。",
-			startTime,
-			endTime,
-		)
-		logger.LogCommand("reserve", userID, username, i.ChannelID, false, "End time before start time", parameters)
-		respondEphemeral(s, i, errorMsg)
+	if len(errs) > 0 {
+		logger.LogCommand("reserve", userID, username, i.ChannelID, false, errs.Error(), parameters)
+		respondFieldErrors(s, i, tr(i, "reserve.failed"), errs, footer(lang(i), "reserve"), formRetryComponents(i, userID))
 		return
 	}
 
-	// 過去日時のチェック
-	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
-	nowJST := time.Now().In(jst)
-
-	// 予約日時を構築（日付 + 開始時刻）
-	reservationDateTime := time.Date(
-		reservationDate.Year(),
-		reservationDate.Month(),
-		reservationDate.Day(),
-		startTimeParsed.Hour(),
-		startTimeParsed.Minute(),
-		0, 0, jst,
-	)
+	// 3. 予約を保存して通知
+	insertReservation(s, i, store, logger, allowedChannelID, "reserve", userID, username, input, parameters)
+}
 
-	// 現在時刻より過去の場合はエラー
-	if reservationDateTime.Before(nowJST) {
-		errorMsg := fmt.Sprintf("❌ 過去の日時は予約できません\n\n"+
-			"**指定された日時:** %s %s\n"+
-			"**現在日時:** %s\n\n"+
-			"現在時刻以降の日時を指定してください。",
-			formatDate(date),
-			startTime,
-			nowJST.Format("2006-01-02 15:04"),
-		)
-		logger.LogCommand("reserve", userID, username, i.ChannelID, false, "Past datetime", parameters)
-		respondEphemeral(s, i, errorMsg)
-		return
-	}
+// insertReservation は検証済みの入力値で予約を作成して保存し、予約者とチャンネルに通知する（/reserve と /reserve-now で共通）
+// 予約の上限と時間の重複はここでチェックする。command はログに記録するコマンド名
+func insertReservation(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID, command, userID, username string, input reservationRequest, parameters map[string]interface{}) {
+	date := input.Date
+	startTime := input.StartTime
+	endTime := input.EndTime
+	comment := input.Comment
 
 	// 予約IDを生成
 	reservationID, err := models.GenerateReservationID()
 	if err != nil {
-		respondError(s, i, "予約IDの生成に失敗しました")
+		respondError(s, i, tr(i, "reserve.id_failed"))
 		return
 	}
 
@@ -162,52 +158,65 @@ func handleReserve(s *discordgo.Session, i *discordgo.InteractionCreate, store *
 		ChannelID: allowedChannelID, // 公開メッセージの送信先は常に指定チャンネル
 	}
 
-	// 時間の重複をチェック
-	overlappingReservation, err := store.CheckOverlap(reservation)
-	if err != nil {
-		respondError(s, i, "予約の重複チェックに失敗しました")
-		logger.LogError("ERROR", "handlers.handleReserve", "Failed to check overlap", err, map[string]interface{}{
+	// 予約の上限と時間の重複の確認・保存を1つのロックの中で行う（同時に予約された場合も重ならない）
+	var (
+		rejection              error
+		overlapErr             error
+		overlappingReservation *models.Reservation
+	)
+	err = store.InsertReservation(reservation, func(others []*models.Reservation) error {
+		if err := currentReservationLimits().check(others, userID, reservation); err != nil {
+			rejection = err
+			return rejection
+		}
+		overlapping, err := storage.FindOverlap(reservation, others)
+		if err != nil {
+			overlapErr = err
+			return err
+		}
+		if overlapping != nil {
+			overlappingReservation = overlapping
+			return errEditInvalid
+		}
+		return nil
+	})
+	if overlapErr != nil {
+		respondError(s, i, tr(i, "reservation.overlap_check_failed"))
+		logger.LogError("ERROR", "handlers.handleReserve", "Failed to check overlap", overlapErr, map[string]interface{}{
 			"user_id": userID,
 			"date":    date,
 		})
 		return
 	}
-
 	if overlappingReservation != nil {
 		fields := []*discordgo.MessageEmbedField{
 			{
-				Name:   "📅 重複している予約",
+				Name:   tr(i, "reserve.overlapping"),
 				Value:  formatDate(overlappingReservation.Date),
 				Inline: false,
 			},
 			{
-				Name:   "👤 予約者",
+				Name:   tr(i, "label.user"),
 				Value:  fmt.Sprintf("<@%s>", overlappingReservation.UserID),
 				Inline: true,
 			},
 			{
-				Name:   "🕐 時間",
+				Name:   tr(i, "label.time"),
 				Value:  fmt.Sprintf("%s - %s", overlappingReservation.StartTime, overlappingReservation.EndTime),
 				Inline: true,
 			},
 		}
 
-		respondEmbedWithFooter(s, i, "🔴 予約できませんでした", "指定された時間は既に予約されています。", fields, 0xED4245, "部室予約システム  |  reserve", true)
+		respondEmbedWithFooter(s, i, tr(i, "reserve.failed"), tr(i, "reservation.time_taken"), fields, 0xED4245, footer(lang(i), "reserve"), true)
 		return
 	}
-
-	// 予約を保存
-	if err := store.AddReservation(reservation); err != nil {
-		respondError(s, i, "予約の保存に失敗しました")
-		logger.LogError("ERROR", "handlers.handleReserve", "Failed to add reservation", err, map[string]interface{}{
-			"user_id":        userID,
-			"reservation_id": reservation.ID,
-		})
+	if rejection != nil {
+		logger.LogCommand(command, userID, username, i.ChannelID, false, rejection.Error(), parameters)
+		respondEmbedWithFooter(s, i, tr(i, "reserve.failed"), localize(lang(i), rejection), nil, 0xED4245, footer(lang(i), "reserve"), true)
 		return
 	}
-
-	if err := store.Save(); err != nil {
-		respondError(s, i, "予約の保存に失敗しました")
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.save_failed"))
 		logger.LogError("ERROR", "handlers.handleReserve", "Failed to save reservations", err, map[string]interface{}{
 			"user_id":        userID,
 			"reservation_id": reservation.ID,
@@ -215,47 +224,31 @@ func handleReserve(s *discordgo.Session, i *discordgo.InteractionCreate, store *
 		return
 	}
 
-	// 5. レスポンス - 予約者にはIDを含めたメッセージを送信（Ephemeral）
-	fields := []*discordgo.MessageEmbedField{
+	// レスポンス - 予約者にはIDを含めたメッセージを送信（Ephemeral）
+	fields := append([]*discordgo.MessageEmbedField{
 		{
-			Name:   "予約ID",
+			Name:   tr(i, "label.reservation_id"),
 			Value:  fmt.Sprintf("`%s`", reservation.ID),
 			Inline: false,
 		},
-		{
-			Name:   "📅 日付",
-			Value:  formatDate(reservation.Date),
-			Inline: true,
-		},
-		{
-			Name:   "🕐 時間",
-			Value:  fmt.Sprintf("%s - %s", reservation.StartTime, reservation.EndTime),
-			Inline: true,
-		},
-	}
-	if comment != "" {
-		fields = append(fields, &discordgo.MessageEmbedField{
-			Name:   "💬 コメント",
-			Value:  comment,
-			Inline: false,
-		})
-	}
+	}, reservationDetailFields(lang(i), reservation)...)
 
-	respondEmbedWithFooter(s, i, "🟢 予約が完了しました！", "", fields, 0x57F287, "部室予約システム  |  reserve", true)
+	respondEmbedWithComponents(s, i, tr(i, "reserve.done"), "", fields, 0x57F287, footer(lang(i), "reserve"), reservationActionComponents(lang(i), reservation, false), true)
 
-	// 6. チャンネル通知 - 予約IDを除外し、予約者フィールドを追加
-	publicFields := []*discordgo.MessageEmbedField{
+	// チャンネル通知 - 予約IDを除外し、予約者フィールドを追加（チャンネルの全員に見えるため既定の言語で送る）
+	pub := i18n.DefaultLocale()
+	publicFields := append([]*discordgo.MessageEmbedField{
 		{
-			Name:   "👤 予約者",
+			Name:   i18n.T(pub, "label.user"),
 			Value:  fmt.Sprintf("<@%s>", reservation.UserID),
 			Inline: false,
 		},
-	}
-	publicFields = append(publicFields, fields[1:]...) // 予約ID以降のフィールドを追加
+	}, reservationDetailFields(pub, reservation)...)
 	// DMから実行された場合も、指定チャンネルに通知
-	sendChannelEmbed(s, allowedChannelID, "🟢 新しい予約が追加されました", "", publicFields, 0x57F287, "部室予約システム  |  reserve")
+	// 取り消し・完了・編集は予約者のみ、空き通知は予約者以外が利用できる
+	sendChannelEmbedWithComponents(s, allowedChannelID, i18n.T(pub, "reserve.announce"), "", publicFields, 0x57F287, footer(pub, "reserve"), reservationActionComponents(pub, reservation, true))
 
-	// 7. Botステータス更新
+	// Botステータス更新
 	if UpdateStatusCallback != nil {
 		UpdateStatusCallback()
 	}
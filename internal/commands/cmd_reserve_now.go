@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// actionReserveNow は「今すぐ予約」ボタンのアクション名
+// カスタムIDは「reserve_now:利用時間（分）」の形式で、日時を含まないためいつ押しても使える
+const actionReserveNow = "reserve_now"
+
+// reserveNowDefaultDuration は /reserve-now で利用時間を省略した場合と、ボタンで予約する場合の利用時間
+const reserveNowDefaultDuration = time.Hour
+
+// reserveNowStart は今すぐ予約する場合の開始時刻を返す
+// 現在時刻を予約枠の刻みに切り捨て、その間に終わった予約がある場合はその終了時刻から始める
+func reserveNowStart(reservations []*models.Reservation, now time.Time) string {
+	current := now.Format("15:04")
+	start := schedule.RoundDownToSlot(current)
+	for _, r := range schedule.ActiveReservationsOn(reservations, now.Format("2006-01-02")) {
+		if r.EndTime > start && r.EndTime <= current {
+			start = r.EndTime
+		}
+	}
+	return start
+}
+
+// reserveNowBusyError は今すぐ予約しようとした時間帯が他の予約で埋まっていることを表す
+type reserveNowBusyError struct {
+	Holder   *models.Reservation // 重なっている予約
+	FreeFrom string              // 同じ長さで予約できる今日の次の開始時刻（空の場合は今日は空きなし）
+}
+
+func (e *reserveNowBusyError) Error() string {
+	return i18n.T(i18n.Japanese, "reserve_now.busy", e.Holder.StartTime, e.Holder.EndTime, e.Holder.UserID)
+}
+
+// reserveNowSlot は現在時刻から duration だけ予約する場合の時間帯を返す
+// 開室時間外・休室日の場合や閉室時刻を超える場合はエラー、他の予約と重なる場合は *reserveNowBusyError を返す。now は日本時間で渡すこと
+func reserveNowSlot(reservations []*models.Reservation, now time.Time, duration time.Duration) (schedule.Slot, error) {
+	current := now.Format("15:04")
+	if current < schedule.OpeningTime || current >= schedule.ClosingTime {
+		return schedule.Slot{}, newError("reserve_now.closed", schedule.OpeningTime, schedule.ClosingTime)
+	}
+	if reason, closed := schedule.ClosedOn(now.Format("2006-01-02")); closed {
+		if reason != "" {
+			return schedule.Slot{}, newError("reserve_now.closed_day_reason", reason)
+		}
+		return schedule.Slot{}, newError("reserve_now.closed_day")
+	}
+
+	start := reserveNowStart(reservations, now)
+	endMinute := schedule.ToMinutes(start) + int(duration/time.Minute)
+	if endMinute > schedule.ToMinutes(schedule.ClosingTime) {
+		return schedule.Slot{}, newError("reserve_now.after_closing", schedule.ClosingTime)
+	}
+	slot := schedule.Slot{Start: start, End: schedule.FromMinutes(endMinute)}
+	if slot.End <= current {
+		return schedule.Slot{}, newError("reserve_now.too_short")
+	}
+
+	date := now.Format("2006-01-02")
+	for _, r := range schedule.ActiveReservationsOn(reservations, date) {
+		if r.StartTime < slot.End && r.EndTime > slot.Start {
+			busy := &reserveNowBusyError{Holder: r}
+			if free := schedule.FilterByDuration(schedule.FreeSlots(reservations, date, current), duration); len(free) > 0 {
+				busy.FreeFrom = free[0].Start
+			}
+			return schedule.Slot{}, busy
+		}
+	}
+	return slot, nil
+}
+
+// reserveNowButton は今すぐ予約する「今すぐ予約」ボタンを作成する
+func reserveNowButton(locale string, duration time.Duration) discordgo.Button {
+	return discordgo.Button{
+		Label:    i18n.T(locale, "reserve_now.button", formatDuration(locale, duration)),
+		Style:    discordgo.PrimaryButton,
+		CustomID: encodeCustomID(actionReserveNow, strconv.Itoa(int(duration/time.Minute))),
+		Emoji: discordgo.ComponentEmoji{
+			Name: "⚡",
+		},
+	}
+}
+
+// BoardComponents はチャンネルに掲示するメッセージに付ける「今すぐ予約」「新しく予約」「今日の空き状況」ボタンを作成する
+// ボタンはBotの再起動後も使える。locale はボタンの表示言語（チャンネルに掲示する場合は i18n.DefaultLocale()）
+func BoardComponents(locale string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				reserveNowButton(locale, reserveNowDefaultDuration),
+				newReservationButton(locale),
+				availabilityTodayButton(locale),
+				scheduleTodayButton(locale),
+			},
+		},
+	}
+}
+
+// reserveNowCommand は /reserve-now の定義を返す
+func reserveNowCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "reserve-now",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "duration",
+					Required:     false,
+					Autocomplete: true,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleReserveNow(c.Session, c.Interaction, c.Store, c.Logger, c.AllowedChannelID, c.IsDM)
+		},
+		Help: "help.reserve_now",
+	}
+}
+
+// handleReserveNow は現在時刻から予約する /reserve-now コマンドを処理する
+func handleReserveNow(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. パラメータ抽出（利用時間は省略時1時間）
+	duration := reserveNowDefaultDuration
+	if opt, ok := optionMap["duration"]; ok {
+		d, err := parseDurationInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, localize(lang(i), errInvalidDuration))
+			return
+		}
+		duration = d
+	}
+
+	// 3. 予約作成
+	reserveNow(s, i, store, logger, allowedChannelID, isDM, duration)
+}
+
+// handleReserveNowButton は「今すぐ予約」ボタンを処理する
+func handleReserveNowButton(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, args []string) {
+	if len(args) != 1 {
+		respondError(s, i, tr(i, "reserve_now.invalid_duration"))
+		return
+	}
+	minutes, err := strconv.Atoi(args[0])
+	if err != nil || minutes <= 0 {
+		respondError(s, i, tr(i, "reserve_now.invalid_duration"))
+		return
+	}
+
+	userID, username := getUserInfo(i, isDM)
+	logger.LogCommand("reserve-now", userID, username, i.ChannelID, true, "", map[string]interface{}{
+		"via":      "button",
+		"duration": minutes,
+	})
+
+	reserveNow(s, i, store, logger, allowedChannelID, isDM, time.Duration(minutes)*time.Minute)
+}
+
+// reserveNow は現在時刻から duration だけ予約する（/reserve-now と「今すぐ予約」ボタンで共通）
+// 埋まっている場合は、予約している人と同じ長さで予約できる次の時刻を表示する
+func reserveNow(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, duration time.Duration) {
+	// 1. ユーザー情報取得
+	userID, username := getUserInfo(i, isDM)
+
+	// 2. ビジネスロジック - 空いているかチェック
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
+	parameters := map[string]interface{}{
+		"duration": formatDuration(i18n.Japanese, duration),
+	}
+
+	slot, err := reserveNowSlot(store.GetAllReservations(), now, duration)
+	var busy *reserveNowBusyError
+	switch {
+	case errors.As(err, &busy):
+		logger.LogCommand("reserve-now", userID, username, i.ChannelID, false, err.Error(), parameters)
+
+		freeFrom := tr(i, "reserve_now.no_free_slot")
+		if busy.FreeFrom != "" {
+			freeFrom = tr(i, "reserve_now.free_from", busy.FreeFrom, formatDuration(lang(i), duration))
+		}
+		fields := []*discordgo.MessageEmbedField{
+			{
+				Name:   tr(i, "label.user"),
+				Value:  fmt.Sprintf("<@%s>", busy.Holder.UserID),
+				Inline: true,
+			},
+			{
+				Name:   tr(i, "label.time"),
+				Value:  fmt.Sprintf("%s - %s", busy.Holder.StartTime, busy.Holder.EndTime),
+				Inline: true,
+			},
+			{
+				Name:   tr(i, "reserve_now.next_free"),
+				Value:  freeFrom,
+				Inline: false,
+			},
+		}
+		respondEmbedWithFooter(s, i, tr(i, "reserve.failed"), tr(i, "reserve_now.busy_description"), fields, 0xED4245, footer(lang(i), "reserve-now"), true)
+		return
+	case err != nil:
+		logger.LogCommand("reserve-now", userID, username, i.ChannelID, false, err.Error(), parameters)
+		respondEmbedWithFooter(s, i, tr(i, "reserve.failed"), localize(lang(i), err), nil, 0xED4245, footer(lang(i), "reserve-now"), true)
+		return
+	}
+
+	// 3. 予約を保存して通知
+	input := reservationRequest{
+		Date:      now.Format("2006-01-02"),
+		StartTime: slot.Start,
+		EndTime:   slot.End,
+	}
+	insertReservation(s, i, store, logger, allowedChannelID, "reserve-now", userID, username, input, parameters)
+}
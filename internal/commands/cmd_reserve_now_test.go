@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+func TestReserveNowSlot(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 11, 20, hour, minute, 0, 0, jst)
+	}
+	reservations := []*models.Reservation{
+		{ID: "r1", UserID: "u1", Date: "2025-11-20", StartTime: "13:00", EndTime: "14:15", Status: models.StatusPending},
+		{ID: "r2", UserID: "u2", Date: "2025-11-20", StartTime: "16:00", EndTime: "17:00", Status: models.StatusPending},
+		{ID: "r3", UserID: "u3", Date: "2025-11-20", StartTime: "11:00", EndTime: "12:00", Status: models.StatusCancelled},
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		duration time.Duration
+		want     schedule.Slot
+		wantErr  string
+	}{
+		{"rounded down to slot", at(11, 10), time.Hour, schedule.Slot{Start: "11:00", End: "12:00"}, ""},
+		{"starts after reservation that just ended", at(14, 20), time.Hour, schedule.Slot{Start: "14:15", End: "15:15"}, ""},
+		{"outside opening hours", at(8, 30), time.Hour, schedule.Slot{}, "開室時間外"},
+		{"past closing time", at(20, 40), time.Hour, schedule.Slot{}, "閉室時刻"},
+		{"short duration", at(11, 40), 30 * time.Minute, schedule.Slot{Start: "11:30", End: "12:00"}, ""},
+		{"ends before now", at(11, 40), 5 * time.Minute, schedule.Slot{}, "短すぎます"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reserveNowSlot(reservations, tt.now, tt.duration)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %+v, %v", tt.wantErr, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("reserveNowSlot() = %+v, %v; want %+v", got, err, tt.want)
+			}
+		})
+	}
+
+	// 使用中の場合は予約者と、同じ長さで予約できる次の時刻を返す
+	_, err := reserveNowSlot(reservations, at(13, 40), 2*time.Hour)
+	var busy *reserveNowBusyError
+	if !errors.As(err, &busy) {
+		t.Fatalf("Expected busy error, got %v", err)
+	}
+	if busy.Holder.ID != "r1" || busy.FreeFrom != "17:00" {
+		t.Errorf("Unexpected busy error: holder=%s free_from=%s", busy.Holder.ID, busy.FreeFrom)
+	}
+}
@@ -0,0 +1,328 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+	"github.com/dice/hxs_reservation_system/internal/timeline"
+)
+
+// scheduleImageName は添付するタイムライン画像のファイル名
+const scheduleImageName = "schedule.png"
+
+// scheduleLegendLimit は凡例に表示する最大件数
+const scheduleLegendLimit = 40
+
+// 予約状態ごとのタイムラインの色（埋め込みメッセージの色に合わせる）
+var statusColors = map[models.ReservationStatus]color.RGBA{
+	models.StatusPending:   {0x57, 0xF2, 0x87, 0xFF},
+	models.StatusCompleted: {0x58, 0x65, 0xF2, 0xFF},
+	models.StatusCancelled: {0xED, 0x42, 0x45, 0xFF},
+}
+
+// scheduleCommand は /schedule の定義を返す
+func scheduleCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "schedule",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "view",
+					Required: false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Value: "day"},
+						{Value: "week"},
+					},
+				},
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "date",
+					Required:     false,
+					Autocomplete: true,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleSchedule(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.schedule",
+	}
+}
+
+// handleSchedule は日・週単位のタイムライン画像を表示する（自分だけに表示される）
+func handleSchedule(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. パラメータ抽出（省略時は今日の日表示）
+	view := "day"
+	if opt, ok := optionMap["view"]; ok {
+		view = opt.StringValue()
+	}
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	date := time.Now().In(jst)
+	if opt, ok := optionMap["date"]; ok {
+		_, parsedDate, err := parseDateInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, localize(lang(i), err))
+			return
+		}
+		date = parsedDate
+	}
+
+	// 3. 画像生成（時間がかかる場合があるため、先に遅延応答しておく）
+	deferResponse(s, i, true)
+	embed, file, err := BuildScheduleImage(lang(i), store, view, date)
+	if err != nil {
+		respondError(s, i, tr(i, "schedule.render_failed"))
+		logger.LogError("ERROR", "handleSchedule", "Failed to render schedule image", err, map[string]interface{}{
+			"view": view,
+			"date": date.Format("2006-01-02"),
+		})
+		return
+	}
+
+	// 4. レスポンス
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Files:  []*discordgo.File{file},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleScheduleToday は掲示板の「タイムライン」ボタンから今日のタイムライン画像を表示する（押した人にだけ表示される）
+func handleScheduleToday(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	today := time.Now().In(jst)
+
+	deferResponse(s, i, true)
+	embed, file, err := BuildScheduleImage(lang(i), store, "day", today)
+	if err != nil {
+		respondError(s, i, tr(i, "schedule.render_failed"))
+		logger.LogError("ERROR", "handleScheduleToday", "Failed to render schedule image", err, map[string]interface{}{
+			"date": today.Format("2006-01-02"),
+		})
+		return
+	}
+
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Files:  []*discordgo.File{file},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// scheduleTodayButton は今日のタイムライン画像を表示する「タイムライン」ボタンを作成する
+func scheduleTodayButton(locale string) discordgo.Button {
+	return discordgo.Button{
+		Label:    i18n.T(locale, "schedule.today_button"),
+		Style:    discordgo.SecondaryButton,
+		CustomID: encodeCustomID(actionScheduleToday),
+		Emoji: discordgo.ComponentEmoji{
+			Name: "📊",
+		},
+	}
+}
+
+// BuildScheduleImage は指定日を含む日・週のタイムライン画像と、予約者の凡例を載せた埋め込みメッセージを作成する
+// view は "day" または "week"
+func BuildScheduleImage(locale string, store *storage.Storage, view string, date time.Time) (*discordgo.MessageEmbed, *discordgo.File, error) {
+	days := scheduleDays(view, date)
+	title := i18n.T(locale, "schedule.title_day", formatDateWithWeekday(locale, date))
+	if view == "week" {
+		title = i18n.T(locale, "schedule.title_week", formatDateWithWeekday(locale, days[0]), formatDateWithWeekday(locale, days[len(days)-1]))
+	}
+
+	reservations := store.FindReservations(storage.ReservationFilter{
+		FromDate: days[0].Format("2006-01-02"),
+		ToDate:   days[len(days)-1].Format("2006-01-02"),
+	})
+	png, entries, err := timelineImage(reservations, view, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var legend []string
+	for _, entry := range entries {
+		if len(legend) >= scheduleLegendLimit {
+			break
+		}
+		r := entry.Reservation
+		line := fmt.Sprintf("`#%d` %s-%s <@%s> %s", entry.Number, r.StartTime, r.EndTime, r.UserID, statusEmoji(r.Status))
+		if view == "week" {
+			line = fmt.Sprintf("`#%d` %s %s-%s <@%s> %s", entry.Number, entry.Day.Format("01/02"), r.StartTime, r.EndTime, r.UserID, statusEmoji(r.Status))
+		}
+		legend = append(legend, line)
+	}
+
+	description := i18n.T(locale, "schedule.empty")
+	if len(entries) > 0 {
+		if len(entries) > len(legend) {
+			legend = append(legend, i18n.T(locale, "schedule.more", len(entries)-len(legend)))
+		}
+		description = strings.Join(legend, "\n") + "\n\n" + i18n.T(locale, "schedule.legend")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       0x5865F2,
+		Image:       timelineEmbedImage(),
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: footer(locale, "schedule"),
+		},
+	}
+	return embed, timelineFile(png), nil
+}
+
+// timelineEntry はタイムライン画像に番号付きで描いた予約を表す（凡例に使う）
+type timelineEntry struct {
+	Number      int                 // 画像の #番号
+	Day         time.Time           // 予約日
+	Reservation *models.Reservation // 予約
+}
+
+// scheduleDays は view（"day" または "week"）で表示する日付を返す。週は月曜日始まり
+func scheduleDays(view string, date time.Time) []time.Time {
+	if view != "week" {
+		return []time.Time{date}
+	}
+	offset := (int(date.Weekday()) + 6) % 7
+	monday := date.AddDate(0, 0, -offset)
+	days := make([]time.Time, 7)
+	for idx := range days {
+		days[idx] = monday.AddDate(0, 0, idx)
+	}
+	return days
+}
+
+// timelineImage は date を含む日・週（view は "day" または "week"）の reservations をタイムライン画像（PNG）にする
+// 予約には日時順に #1, #2, ... の番号を付け、描いた予約を番号順に返す（/schedule・チャンネルボード・朝のダイジェストで共通）
+func timelineImage(reservations []*models.Reservation, view string, date time.Time) ([]byte, []timelineEntry, error) {
+	days := scheduleDays(view, date)
+	chartTitle := fmt.Sprintf("%s %s", date.Format("2006/01/02"), strings.ToUpper(date.Weekday().String()[:3]))
+	if view == "week" {
+		chartTitle = fmt.Sprintf("WEEK %s - %s", days[0].Format("2006/01/02"), days[6].Format("01/02"))
+	}
+
+	chart := timeline.Chart{
+		Title:       chartTitle,
+		StartMinute: schedule.ToMinutes(schedule.OpeningTime),
+		EndMinute:   schedule.ToMinutes(schedule.ClosingTime),
+	}
+
+	var entries []timelineEntry
+	for _, day := range days {
+		dateKey := day.Format("2006-01-02")
+		var dayReservations []*models.Reservation
+		for _, r := range reservations {
+			if r.Date == dateKey {
+				dayReservations = append(dayReservations, r)
+			}
+		}
+		sort.SliceStable(dayReservations, func(a, b int) bool {
+			return dayReservations[a].StartTime < dayReservations[b].StartTime
+		})
+
+		var weekRow timeline.Row
+		if view == "week" {
+			weekRow.Label = fmt.Sprintf("%s %s", day.Format("01/02"), strings.ToUpper(day.Weekday().String()[:3]))
+		}
+
+		for _, r := range dayReservations {
+			number := len(entries) + 1
+			entries = append(entries, timelineEntry{Number: number, Day: day, Reservation: r})
+			start := schedule.ToMinutes(r.StartTime)
+			end := schedule.ToMinutes(r.EndTime)
+			if start < 0 || end < 0 {
+				continue
+			}
+
+			// 開室時間外の予約がある場合は横軸を広げる
+			if start < chart.StartMinute {
+				chart.StartMinute = start - start%60
+			}
+			if end > chart.EndMinute {
+				chart.EndMinute = end
+			}
+
+			label := fmt.Sprintf("#%d", number)
+			if view == "day" && timeline.CanRender(r.Username) {
+				label = fmt.Sprintf("#%d %s", number, r.Username)
+			}
+			bar := timeline.Bar{
+				StartMinute: start,
+				EndMinute:   end,
+				Label:       label,
+				Color:       statusColors[r.Status],
+				Outline:     r.Status == models.StatusCancelled,
+			}
+
+			if view == "week" {
+				weekRow.Bars = append(weekRow.Bars, bar)
+			} else {
+				chart.Rows = append(chart.Rows, timeline.Row{Label: fmt.Sprintf("#%d", number), Bars: []timeline.Bar{bar}})
+			}
+		}
+
+		if view == "week" {
+			chart.Rows = append(chart.Rows, weekRow)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := timeline.Render(&buf, chart); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), entries, nil
+}
+
+// timelineFile はタイムライン画像を添付ファイルにする（埋め込みメッセージからは timelineEmbedImage で参照する）
+func timelineFile(png []byte) *discordgo.File {
+	return &discordgo.File{
+		Name:        scheduleImageName,
+		ContentType: "image/png",
+		Reader:      bytes.NewReader(png),
+	}
+}
+
+// timelineEmbedImage は添付したタイムライン画像を埋め込みメッセージに表示する
+func timelineEmbedImage() *discordgo.MessageEmbedImage {
+	return &discordgo.MessageEmbedImage{URL: "attachment://" + scheduleImageName}
+}
+
+// statusEmoji は予約状態を表す絵文字を返す
+func statusEmoji(status models.ReservationStatus) string {
+	switch status {
+	case models.StatusPending:
+		return "🟩"
+	case models.StatusCompleted:
+		return "🟦"
+	case models.StatusCancelled:
+		return "🟥"
+	}
+	return ""
+}
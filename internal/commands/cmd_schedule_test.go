@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+func TestScheduleDays(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	date := time.Date(2025, 11, 20, 0, 0, 0, 0, jst) // 木曜日
+
+	if days := scheduleDays("day", date); len(days) != 1 || !days[0].Equal(date) {
+		t.Errorf("Expected only the date for day view, got %v", days)
+	}
+
+	days := scheduleDays("week", date)
+	if len(days) != 7 || days[0].Format("2006-01-02") != "2025-11-17" || days[6].Format("2006-01-02") != "2025-11-23" {
+		t.Errorf("Expected Monday to Sunday, got %v", days)
+	}
+}
+
+func TestTimelineImage(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	date := time.Date(2025, 11, 20, 0, 0, 0, 0, jst)
+	reservations := []*models.Reservation{
+		{ID: "r1", Username: "bob", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusPending},
+		{ID: "r2", Username: "alice", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCancelled},
+		{ID: "r3", Username: "carol", Date: "2025-11-18", StartTime: "09:00", EndTime: "10:00", Status: models.StatusCompleted},
+		{ID: "r4", Username: "dave", Date: "2025-11-25", StartTime: "09:00", EndTime: "10:00", Status: models.StatusPending},
+	}
+
+	// 日表示はその日の予約だけを開始時刻順に番号付けする（キャンセル済みも含む）
+	png, entries, err := timelineImage(reservations, "day", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(png, []byte("\x89PNG")) {
+		t.Error("Expected a PNG image")
+	}
+	if len(entries) != 2 || entries[0].Reservation.ID != "r2" || entries[1].Reservation.ID != "r1" || entries[1].Number != 2 {
+		t.Errorf("Unexpected day entries: %+v", entries)
+	}
+
+	// 週表示は月曜日からの日付順
+	_, entries, err = timelineImage(reservations, "week", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.Reservation.ID)
+	}
+	if len(ids) != 3 || ids[0] != "r3" || ids[1] != "r2" || ids[2] != "r1" {
+		t.Errorf("Unexpected week entries: %v", ids)
+	}
+	if entries[0].Day.Format("2006-01-02") != "2025-11-18" {
+		t.Errorf("Expected the reservation day, got %s", entries[0].Day)
+	}
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/stats"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// statsRankingLimit は統計の各ランキングに表示する件数
+const statsRankingLimit = 5
+
+// statsCommand は /stats の定義を返す
+func statsCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "stats",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:     discordgo.ApplicationCommandOptionString,
+					Name:     "month",
+					Required: false,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleStats(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.stats",
+	}
+}
+
+// handleStats は指定月のコマンド利用状況と部室の利用率を表示する（自分だけに表示される）
+// 管理者にはメンバーごとのランキングも表示し、それ以外のユーザーには集計値のみを表示する
+func handleStats(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. パラメータ抽出（省略時は今月）
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	month := time.Now().In(jst)
+	if opt, ok := optionMap["month"]; ok {
+		parsed, err := parseMonthInput(opt.StringValue())
+		if err != nil {
+			respondError(s, i, localize(lang(i), err))
+			return
+		}
+		month = parsed
+	}
+
+	// 3. ビジネスロジック - 現在の予約とアーカイブから利用状況を集計
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, jst)
+	to := from.AddDate(0, 1, -1)
+	filter := storage.ReservationFilter{
+		FromDate: from.Format("2006-01-02"),
+		ToDate:   to.Format("2006-01-02"),
+	}
+	reservations, err := store.FindHistory(filter)
+	if err != nil {
+		respondError(s, i, tr(i, "stats.load_failed"))
+		logger.LogError("ERROR", "handleStats", "Failed to load reservation history", err, map[string]interface{}{
+			"month": from.Format("2006-01"),
+		})
+		return
+	}
+
+	report := stats.Compute(reservations, filter.FromDate, filter.ToDate)
+	commandStats := logger.GetStats()
+	monthly := commandStats.MonthlyStats[from.Format("2006-01")]
+	admin := isAdmin(i)
+
+	// 4. レスポンス
+	locale := lang(i)
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(locale, "stats.bookings"),
+			Value:  i18n.T(locale, "stats.bookings_value", report.Bookings, report.Completed),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "stats.cancelled"),
+			Value:  i18n.T(locale, "stats.cancelled_value", report.Cancelled, report.CancelRate()*100),
+			Inline: true,
+		},
+		{
+			Name: i18n.T(locale, "stats.utilization"),
+			Value: i18n.T(locale, "stats.utilization_value", report.UtilizationRate()*100,
+				formatHours(locale, time.Duration(report.BookedMinutes)*time.Minute), formatHours(locale, time.Duration(report.OpenMinutes)*time.Minute)),
+			Inline: false,
+		},
+		{
+			Name:   i18n.T(locale, "stats.busiest_weekdays"),
+			Value:  formatRanked(locale, report.BusiestWeekdays(3), func(key int) string { return i18n.T(locale, "stats.weekday", i18n.Weekday(locale, time.Weekday(key))) }),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "stats.busiest_hours"),
+			Value:  formatRanked(locale, report.BusiestHours(3), func(key int) string { return i18n.T(locale, "stats.hour", key) }),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "stats.command_usage"),
+			Value:  formatCommandUsage(locale, monthly),
+			Inline: false,
+		},
+	}
+
+	if admin {
+		var bookers []string
+		for idx, usage := range report.TopUsers(statsRankingLimit) {
+			bookers = append(bookers, i18n.T(locale, "stats.top_booker", idx+1, usage.UserID,
+				formatHours(locale, time.Duration(usage.Minutes)*time.Minute), usage.Bookings))
+		}
+		fields = append(fields,
+			&discordgo.MessageEmbedField{
+				Name:   i18n.T(locale, "stats.top_bookers"),
+				Value:  joinOrNone(locale, bookers),
+				Inline: false,
+			},
+			&discordgo.MessageEmbedField{
+				Name:   i18n.T(locale, "stats.top_command_users"),
+				Value:  joinOrNone(locale, topCounts(monthly.UserCounts, statsRankingLimit, func(userID string, count int) string { return i18n.T(locale, "stats.command_user", userID, count) })),
+				Inline: false,
+			},
+		)
+	}
+
+	description := i18n.T(locale, "stats.period", formatDate(filter.FromDate), formatDate(filter.ToDate))
+	if !admin {
+		description += "\n" + i18n.T(locale, "stats.admin_only")
+	}
+	footerText := i18n.T(locale, "stats.footer", commandStats.TotalCommands)
+
+	respondEmbedWithFooter(s, i, i18n.T(locale, "stats.title", from.Year(), int(from.Month())), description, fields, 0x5865F2, footerText, true)
+}
+
+// parseMonthInput は YYYY-MM または YYYY/MM 形式の月の入力を解釈する
+func parseMonthInput(input string) (time.Time, error) {
+	input = strings.ReplaceAll(strings.TrimSpace(input), "/", "-")
+	t, err := time.Parse("2006-1", input)
+	if err != nil {
+		return time.Time{}, errInvalidMonth
+	}
+	return t, nil
+}
+
+// formatCommandUsage は月別のコマンド利用状況をまとめる（ユーザーごとの内訳は含まない）
+func formatCommandUsage(locale string, monthly logging.MonthlyStat) string {
+	if monthly.TotalCommands == 0 {
+		return i18n.T(locale, "stats.no_records")
+	}
+	top := topCounts(monthly.CommandCounts, statsRankingLimit, func(command string, count int) string {
+		return fmt.Sprintf("`/%s` %d", command, count)
+	})
+	return i18n.T(locale, "stats.command_total", monthly.TotalCommands, len(monthly.UserCounts)) + "\n" + strings.Join(top, " / ")
+}
+
+// formatRanked は順位付けした集計値を「月曜 5時間」の形式で1行ずつまとめる
+func formatRanked(locale string, ranked []stats.Ranked, label func(key int) string) string {
+	parts := make([]string, 0, len(ranked))
+	for _, item := range ranked {
+		parts = append(parts, fmt.Sprintf("%s %s", label(item.Key), formatHours(locale, time.Duration(item.Minutes)*time.Minute)))
+	}
+	return joinOrNone(locale, parts)
+}
+
+// topCounts は回数の多い順に最大 n 件を整形して返す（同じ回数の場合はキーの昇順）
+func topCounts(counts map[string]int, n int, format func(key string, count int) string) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if counts[keys[a]] != counts[keys[b]] {
+			return counts[keys[a]] > counts[keys[b]]
+		}
+		return keys[a] < keys[b]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, format(key, counts[key]))
+	}
+	return lines
+}
+
+// joinOrNone は行を改行で連結する（空の場合は「なし」）
+func joinOrNone(locale string, lines []string) string {
+	if len(lines) == 0 {
+		return i18n.T(locale, "stats.none")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,296 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// 譲渡の申し出に付けるボタンのアクション名
+// カスタムIDは「アクション名:予約ID」の形式（例: transfer_accept:0123456789abcdef0123456789abcdef）
+const (
+	actionTransferAccept  = "transfer_accept"
+	actionTransferDecline = "transfer_decline"
+)
+
+// errTransferOfferReplaced はDMを送れなかった申し出を取り消す前に、別の申し出に置き換わっていたことを表す
+var errTransferOfferReplaced = errors.New("transfer offer replaced")
+
+// transferOfferComponents は譲渡先に送るDMの承諾・辞退ボタンを作成する
+func transferOfferComponents(locale, reservationID string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(locale, "transfer.accept"),
+					Style:    discordgo.SuccessButton,
+					CustomID: encodeCustomID(actionTransferAccept, reservationID),
+				},
+				discordgo.Button{
+					Label:    i18n.T(locale, "transfer.decline"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: encodeCustomID(actionTransferDecline, reservationID),
+				},
+			},
+		},
+	}
+}
+
+// transferCommand は /transfer の定義を返す
+func transferCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "transfer",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "reservation_id",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:     discordgo.ApplicationCommandOptionUser,
+					Name:     "to",
+					Required: true,
+				},
+			},
+		},
+		Handler: func(c *CommandContext) {
+			handleTransfer(c.Session, c.Interaction, c.Store, c.Logger, c.IsDM)
+		},
+		Help: "help.transfer",
+	}
+}
+
+// handleTransfer は自分の予約を別のメンバーに譲渡する申し出を処理する
+// 譲渡先にはDMで承諾・辞退のボタンを送り、承諾されるまで予約者は変わらない
+func handleTransfer(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, isDM bool) {
+	// 1. オプション取得
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	// 2. パラメータ抽出
+	userID, _ := getUserInfo(i, isDM)
+	reservationID := optionMap["reservation_id"].StringValue()
+	recipient := optionMap["to"].UserValue(s)
+
+	// 3. ビジネスロジック - 予約と譲渡先のチェック
+	reservation, err := store.GetReservation(reservationID)
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.not_found"))
+		return
+	}
+	if reservation.UserID != userID {
+		respondError(s, i, tr(i, "transfer.not_owner"))
+		return
+	}
+	if reservation.Status != models.StatusPending {
+		respondError(s, i, tr(i, "transfer.not_pending"))
+		return
+	}
+	if recipient == nil || recipient.Bot {
+		respondError(s, i, tr(i, "transfer.invalid_recipient"))
+		return
+	}
+	if recipient.ID == userID {
+		respondError(s, i, tr(i, "transfer.self"))
+		return
+	}
+
+	// 譲渡先の予約の上限を事前にチェックする（承諾時にも改めてチェックする）
+	if err := currentReservationLimits().check(store.GetAllReservations(), recipient.ID, reservation); err != nil {
+		respondError(s, i, tr(i, "transfer.recipient_limit", localize(lang(i), err)))
+		return
+	}
+
+	// 表示名を解決（サーバーのニックネームを優先）
+	recipientName := recipient.Username
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+		if member, ok := resolved.Members[recipient.ID]; ok && member.Nick != "" {
+			recipientName = member.Nick
+		}
+	}
+
+	// 申し出を保存する（同じ予約への以前の申し出は無効になる）
+	// 予約者と状態はロックの中で改めて確認する（確認後に取り消しや割り当て直しがあった場合は保存しない）
+	var rejection error
+	updated, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		switch {
+		case r.UserID != userID:
+			rejection = newError("transfer.not_owner")
+		case r.Status != models.StatusPending:
+			rejection = newError("transfer.not_pending")
+		}
+		if rejection != nil {
+			return rejection
+		}
+
+		r.Transfer = &models.TransferOffer{
+			FromUserID: userID,
+			ToUserID:   recipient.ID,
+			ToUsername: recipientName,
+			OfferedAt:  time.Now(),
+		}
+		r.AddHistory("transfer_offer", userID, "", fmt.Sprintf("%s → %s", userID, recipient.ID))
+		return nil
+	})
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	}
+	if err != nil {
+		respondError(s, i, tr(i, "transfer.offer_save_failed"))
+		logger.LogError("ERROR", "handleTransfer", "Failed to save transfer offer", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	// 4. 譲渡先にDMで申し出を送る（譲渡先の言語で送る）
+	recipientLang := userLocale(store, recipient.ID)
+	description := i18n.T(recipientLang, "transfer.offer_description", userID)
+	if err := sendDirectEmbed(s, recipient.ID, i18n.T(recipientLang, "transfer.offer_title"), description, reservationDetailFields(recipientLang, updated), 0x5865F2, footer(recipientLang, "transfer"), transferOfferComponents(recipientLang, reservationID)); err != nil {
+		logger.LogError("WARN", "handleTransfer", "Failed to send DM to transfer recipient", err, map[string]interface{}{
+			"reservation_id": reservationID,
+			"user_id":        recipient.ID,
+		})
+		// 申し出を取り消す（その間に別の申し出に置き換わっていた場合はそのままにする）
+		offer := updated.Transfer
+		if _, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+			if r.Transfer == nil || r.Transfer.ToUserID != offer.ToUserID || !r.Transfer.OfferedAt.Equal(offer.OfferedAt) {
+				return errTransferOfferReplaced
+			}
+			r.Transfer = nil
+			return nil
+		}); err != nil && !errors.Is(err, errTransferOfferReplaced) {
+			logger.LogError("ERROR", "handleTransfer", "Failed to withdraw transfer offer", err, map[string]interface{}{
+				"reservation_id": reservationID,
+			})
+		}
+		respondError(s, i, tr(i, "transfer.dm_failed"))
+		return
+	}
+
+	// 5. レスポンス
+	respondEmbedWithFooter(s, i, tr(i, "transfer.offered"), tr(i, "transfer.offered_description", recipient.ID),
+		reservationDetailFields(lang(i), updated), 0x5865F2, footer(lang(i), "transfer"), true)
+}
+
+// handleTransferResponse は譲渡の申し出への承諾・辞退のボタンを処理する
+// 申し出の確認・上限のチェック・予約者の変更は1つのロックの中で行い、途中で予約が変わった場合は何も変更しない
+func handleTransferResponse(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, action string, args []string) {
+	if len(args) != 1 || args[0] == "" {
+		respondError(s, i, tr(i, "reservation.invalid_args"))
+		return
+	}
+	reservationID := args[0]
+	userID, username := getUserInfo(i, isDM)
+	accept := action == actionTransferAccept
+
+	response := "decline"
+	if accept {
+		response = "accept"
+	}
+	logger.LogCommand("transfer", userID, username, i.ChannelID, true, "", map[string]interface{}{
+		"reservation_id": reservationID,
+		"via":            "button",
+		"response":       response,
+	})
+
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found"))
+		disableSourceMessageButtons(s, i)
+		return
+	}
+
+	var previousOwnerID string
+	var rejection error
+	updated, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		// 申し出た後に予約者が変わった場合（管理者による割り当て直しなど）は、前の予約者の申し出を承諾させない
+		if r.Transfer == nil || r.Transfer.ToUserID != userID || r.Transfer.FromUserID != r.UserID {
+			rejection = newError("transfer.offer_gone")
+			return rejection
+		}
+		if r.Status != models.StatusPending {
+			rejection = newError("transfer.reservation_closed")
+			return rejection
+		}
+
+		previousOwnerID = r.UserID
+		if !accept {
+			r.Transfer = nil
+			r.AddHistory("transfer_decline", userID, "", "")
+			return nil
+		}
+
+		if err := currentReservationLimits().check(others, userID, r); err != nil {
+			rejection = newError("transfer.limit_on_accept", err)
+			return rejection
+		}
+		r.UserID = userID
+		r.Username = r.Transfer.ToUsername
+		r.Transfer = nil
+		r.UpdatedAt = time.Now()
+		r.AddHistory("transfer", userID, "", fmt.Sprintf("%s → %s", previousOwnerID, userID))
+		return nil
+	})
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
+		disableSourceMessageButtons(s, i)
+		return
+	}
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.save_failed"))
+		logger.LogError("ERROR", "handleTransferResponse", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	disableSourceMessageButtons(s, i)
+	ownerLang := userLocale(store, previousOwnerID)
+
+	if !accept {
+		respondEmbed(s, i, tr(i, "transfer.declined"), tr(i, "transfer.declined_description"), 0x99AAB5, true)
+		sendDirectEmbed(s, previousOwnerID, i18n.T(ownerLang, "transfer.declined_notice"),
+			i18n.T(ownerLang, "transfer.declined_notice_description", userID), reservationDetailFields(ownerLang, updated), 0x99AAB5, footer(ownerLang, "transfer"), nil)
+		return
+	}
+
+	// 新しい予約者には予約IDと操作ボタンを表示する
+	ownerFields := append([]*discordgo.MessageEmbedField{
+		{
+			Name:   tr(i, "label.reservation_id"),
+			Value:  fmt.Sprintf("`%s`", updated.ID),
+			Inline: false,
+		},
+	}, reservationDetailFields(lang(i), updated)...)
+	respondEmbedWithComponents(s, i, tr(i, "transfer.accepted"), tr(i, "transfer.accepted_description"), ownerFields, 0x57F287, footer(lang(i), "transfer"), reservationActionComponents(lang(i), updated, false), true)
+
+	if err := sendDirectEmbed(s, previousOwnerID, i18n.T(ownerLang, "transfer.accepted_notice"),
+		i18n.T(ownerLang, "transfer.accepted_notice_description", userID), reservationDetailFields(ownerLang, updated), 0x57F287, footer(ownerLang, "transfer"), nil); err != nil {
+		logger.LogError("WARN", "handleTransferResponse", "Failed to send DM to previous owner", err, map[string]interface{}{
+			"reservation_id": reservationID,
+			"user_id":        previousOwnerID,
+		})
+	}
+
+	pub := i18n.DefaultLocale()
+	changeFields := append([]*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(pub, "transfer.owner_change"),
+			Value:  fmt.Sprintf("<@%s> → <@%s>", previousOwnerID, userID),
+			Inline: false,
+		},
+	}, reservationDetailFields(pub, updated)...)
+	sendChannelEmbed(s, allowedChannelID, i18n.T(pub, "transfer.announce"), "", changeFields, 0x5865F2, footer(pub, "transfer"))
+}
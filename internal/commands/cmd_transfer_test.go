@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+func TestHandleTransferResponseStaleOffer(t *testing.T) {
+	s, _ := newRecordingSession(t)
+	logger := logging.NewLogger(t.TempDir())
+	store := newAdminTestStore(t,
+		// 申し出の後に管理者が u2 に割り当て直した予約
+		&models.Reservation{ID: "r1", UserID: "u2", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending,
+			Transfer: &models.TransferOffer{FromUserID: "u1", ToUserID: "u3", ToUsername: "carol", OfferedAt: time.Now()}},
+		&models.Reservation{ID: "r2", UserID: "u1", Date: "2025-11-20", StartTime: "12:00", EndTime: "13:00", Status: models.StatusPending,
+			Transfer: &models.TransferOffer{FromUserID: "u1", ToUserID: "u3", ToUsername: "carol", OfferedAt: time.Now()}},
+	)
+	i := testInteraction(discordgo.InteractionMessageComponent)
+	i.User = &discordgo.User{ID: "u3", Username: "carol"}
+
+	// 予約者が変わった後の申し出は承諾できない
+	handleTransferResponse(s, i, store, logger, "", true, actionTransferAccept, []string{"r1"})
+	if got, _ := store.GetReservation("r1"); got.UserID != "u2" || got.Transfer == nil {
+		t.Errorf("Expected stale offer to be rejected, got owner %s with offer %+v", got.UserID, got.Transfer)
+	}
+
+	handleTransferResponse(s, i, store, logger, "", true, actionTransferAccept, []string{"r2"})
+	got, _ := store.GetReservation("r2")
+	if got.UserID != "u3" || got.Username != "carol" || got.Transfer != nil {
+		t.Errorf("Expected accepted transfer, got owner %s with offer %+v", got.UserID, got.Transfer)
+	}
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// customIDSeparator はコンポーネントのカスタムIDで使う区切り文字
+// 予約IDは16進数文字列、日付は YYYY-MM-DD、時刻は HHMM 形式で埋め込むため衝突しない
+const customIDSeparator = ":"
+
+// コンポーネント・モーダルのアクション名（カスタムIDの先頭要素）
+const (
+	actionAvailabilityBook  = "availability_book"
+	actionAvailabilityToday = "availability_today"
+	actionReserveForm       = "reserve_form"
+	actionReserveNew        = "reserve_new"
+	actionFormRetry         = "form_retry"
+	actionListPage          = "list_page"
+	actionScheduleToday     = "schedule_today"
+)
+
+// modalActions はモーダルを開くアクション（モーダルは遅延応答の後に開けないため、自動で遅延応答しない）
+var modalActions = map[string]bool{
+	actionAvailabilityBook: true,
+	actionReserveNew:       true,
+	actionFormRetry:        true,
+	actionReservationEdit:  true,
+}
+
+// encodeCustomID はアクション名と引数からカスタムIDを作成する
+func encodeCustomID(action string, args ...string) string {
+	return strings.Join(append([]string{action}, args...), customIDSeparator)
+}
+
+// decodeCustomID はカスタムIDをアクション名と引数に分解する
+func decodeCustomID(customID string) (string, []string) {
+	parts := strings.Split(customID, customIDSeparator)
+	return parts[0], parts[1:]
+}
+
+// encodeTimeArg は HH:MM 形式の時刻をカスタムID用の HHMM 形式に変換する
+func encodeTimeArg(hhmm string) string {
+	return strings.ReplaceAll(hhmm, ":", "")
+}
+
+// decodeTimeArg はカスタムID用の HHMM 形式を HH:MM 形式の時刻に戻す
+func decodeTimeArg(hhmm string) string {
+	if len(hhmm) != 4 {
+		return hhmm
+	}
+	return hhmm[:2] + ":" + hhmm[2:]
+}
+
+// HandleComponent はボタンなどのメッセージコンポーネントのインタラクションを処理する（処理中のパニックは recoverInteraction で復旧する）
+func HandleComponent(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string) {
+	data := i.MessageComponentData()
+	action, args := decodeCustomID(data.CustomID)
+	isDM := i.GuildID == ""
+	applyUserLocale(i, store)
+	defer trackResponse(s, i, logger, !modalActions[action])()
+	defer recoverInteraction(s, i, logger, "HandleComponent", action)
+
+	userID, username := getUserInfo(i, isDM)
+
+	if !isDM && allowedChannelID != "" && i.ChannelID != allowedChannelID {
+		respondEphemeral(s, i, tr(i, "common.channel_only"))
+		logger.LogCommand(action, userID, username, i.ChannelID, false, "Not allowed channel", nil)
+		return
+	}
+
+	switch action {
+	case actionAvailabilityBook:
+		handleAvailabilityBook(s, i, args)
+	case actionAvailabilityToday:
+		handleAvailabilityToday(s, i, store)
+	case actionScheduleToday:
+		handleScheduleToday(s, i, store, logger)
+	case actionReserveNow:
+		handleReserveNowButton(s, i, store, logger, allowedChannelID, isDM, args)
+	case actionReserveNew:
+		openNewReservationForm(s, i, store)
+	case actionFormRetry:
+		handleFormRetry(s, i, store, args, userID)
+	case actionListPage:
+		handleListPage(s, i, store, args, userID)
+	case actionReservationCancel, actionReservationComplete, actionReservationEdit, actionReservationWatch, actionReservationCheckIn:
+		handleReservationAction(s, i, store, logger, allowedChannelID, isDM, action, args)
+	case actionReservationExtend, actionReservationFinish:
+		handleExtendButton(s, i, store, logger, allowedChannelID, isDM, action, args)
+	case actionTransferAccept, actionTransferDecline:
+		handleTransferResponse(s, i, store, logger, allowedChannelID, isDM, action, args)
+	default:
+		respondError(s, i, tr(i, "common.unavailable"))
+	}
+}
+
+// HandleModalSubmit はモーダル（フォーム）の送信を処理する（処理中のパニックは recoverInteraction で復旧する）
+func HandleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string) {
+	data := i.ModalSubmitData()
+	action, args := decodeCustomID(data.CustomID)
+	isDM := i.GuildID == ""
+	applyUserLocale(i, store)
+	defer trackResponse(s, i, logger, true)()
+	defer recoverInteraction(s, i, logger, "HandleModalSubmit", action)
+
+	userID, username := getUserInfo(i, isDM)
+	values := modalValues(data)
+
+	if !isDM && allowedChannelID != "" && i.ChannelID != allowedChannelID {
+		respondEphemeral(s, i, tr(i, "common.channel_only"))
+		logger.LogCommand(action, userID, username, i.ChannelID, false, "Not allowed channel", nil)
+		return
+	}
+
+	switch action {
+	case actionReserveForm:
+		logger.LogCommand("reserve-form", userID, username, i.ChannelID, true, "", map[string]interface{}{
+			"date":       values["date"],
+			"start_time": values["start_time"],
+			"end_time":   values["end_time"],
+		})
+		createReservation(s, i, store, logger, allowedChannelID, isDM, reservationRequest{
+			Date:      values["date"],
+			StartTime: values["start_time"],
+			EndTime:   values["end_time"],
+			Comment:   values["comment"],
+		})
+	case actionEditForm:
+		if len(args) != 1 {
+			respondError(s, i, tr(i, "reservation.invalid_args"))
+			return
+		}
+		reservation, err := store.GetReservation(args[0])
+		if err != nil {
+			respondError(s, i, tr(i, "reservation.not_found"))
+			return
+		}
+		req := editRequestFromModal(reservation, values)
+		logger.LogCommand("edit", userID, username, i.ChannelID, true, "", map[string]interface{}{
+			"reservation_id": reservation.ID,
+			"via":            "form",
+		})
+		editReservation(s, i, store, logger, allowedChannelID, isDM, reservation.ID, req)
+	default:
+		respondError(s, i, tr(i, "form.unavailable"))
+	}
+}
+
+// modalValues はモーダルの入力値をカスタムIDごとのマップにまとめる
+func modalValues(data discordgo.ModalSubmitInteractionData) map[string]string {
+	values := make(map[string]string)
+	for _, row := range data.Components {
+		actionsRow, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, component := range actionsRow.Components {
+			if input, ok := component.(*discordgo.TextInput); ok {
+				values[input.CustomID] = strings.TrimSpace(input.Value)
+			}
+		}
+	}
+	return values
+}
+
+// openReservationModal は予約フォームのモーダルを開く（各項目は初期値で埋めておく）
+func openReservationModal(s *discordgo.Session, i *discordgo.InteractionCreate, date, startTime, endTime, comment string) {
+	err := respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: encodeCustomID(actionReserveForm),
+			Title:    tr(i, "form.reserve_title"),
+			Components: []discordgo.MessageComponent{
+				modalTextInput("date", tr(i, "form.date"), formatDate(date), true, discordgo.TextInputShort),
+				modalTextInput("start_time", tr(i, "form.start_time"), startTime, true, discordgo.TextInputShort),
+				modalTextInput("end_time", tr(i, "form.end_time_optional"), endTime, false, discordgo.TextInputShort),
+				modalTextInput("comment", tr(i, "form.comment"), comment, false, discordgo.TextInputParagraph),
+			},
+		},
+	})
+	if err != nil {
+		respondError(s, i, tr(i, "form.reserve_open_failed"))
+	}
+}
+
+// modalTextInput はモーダル用のテキスト入力欄を作成する
+func modalTextInput(customID, label, value string, required bool, style discordgo.TextInputStyle) discordgo.ActionsRow {
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.TextInput{
+				CustomID: customID,
+				Label:    label,
+				Style:    style,
+				Value:    value,
+				Required: required,
+			},
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// TestHandleComponentRecoversPanic はボタンとモーダルの処理中のパニックでプロセスが止まらず、エラーログと応答が残ることを確認する
+func TestHandleComponentRecoversPanic(t *testing.T) {
+	s, transport := newRecordingSession(t)
+	logDir := t.TempDir()
+	logger := logging.NewLogger(logDir)
+	store := storage.NewStorageIn(t.TempDir())
+
+	// サーバー内のインタラクションなのにメンバー情報がない（getUserInfo がパニックする）
+	button := testInteraction(discordgo.InteractionMessageComponent)
+	button.GuildID = "g1"
+	button.Data = discordgo.MessageComponentInteractionData{CustomID: encodeCustomID(actionListPage, "1")}
+	HandleComponent(s, button, store, logger, "")
+
+	modal := testInteraction(discordgo.InteractionModalSubmit)
+	modal.ID = "i2"
+	modal.GuildID = "g1"
+	modal.Data = discordgo.ModalSubmitInteractionData{CustomID: actionReserveForm}
+	HandleModalSubmit(s, modal, store, logger, "")
+
+	if paths := transport.paths(); len(paths) != 2 {
+		t.Errorf("Expected an error response for each interaction, got %v", paths)
+	}
+	logs, _ := filepath.Glob(filepath.Join(logDir, "errors_*.log"))
+	if len(logs) != 1 {
+		t.Fatalf("Expected an error log, got %v", logs)
+	}
+	data, err := os.ReadFile(logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, source := range []string{"HandleComponent", "HandleModalSubmit"} {
+		if !strings.Contains(string(data), source) {
+			t.Errorf("Expected a panic logged from %s, got %s", source, data)
+		}
+	}
+}
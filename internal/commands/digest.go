@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/stats"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// digestStateKey は最後に朝のダイジェストを投稿した日付（YYYY-MM-DD）を保存する状態のキー
+const digestStateKey = "digest_last_date"
+
+// digestClosureDays は朝のダイジェストで休室日を知らせる日数（今日を除く）
+const digestClosureDays = 7
+
+// PostDailyDigest は channelID のチャンネルに今日の朝のダイジェストを投稿する（表示言語は i18n.DefaultLocale()）
+// now は日本時間の現在時刻。今日のタイムライン画像を添付し、休室日と、今日のダイジェストを投稿済みの場合は投稿せずに false を返す
+// 投稿した日付は保存するため、再起動しても同じ日に2回投稿することはない
+func PostDailyDigest(s *discordgo.Session, store *storage.Storage, channelID string, now time.Time) (bool, error) {
+	today := now.Format("2006-01-02")
+	if _, closed := schedule.ClosedOn(today); closed {
+		return false, nil
+	}
+	if store.GetState(digestStateKey) == today {
+		return false, nil
+	}
+
+	// 今日のタイムライン画像を添付する（/schedule と同じ画像）
+	reservations := store.GetAllReservations()
+	png, _, err := timelineImage(reservations, "day", now)
+	if err != nil {
+		return false, err
+	}
+
+	locale := i18n.DefaultLocale()
+	embed := DailyDigestEmbed(locale, reservations, now)
+	embed.Image = timelineEmbedImage()
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Files:      []*discordgo.File{timelineFile(png)},
+		Components: BoardComponents(locale),
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, store.SetState(digestStateKey, today)
+}
+
+// DailyDigestEmbed は朝のダイジェストの埋め込みを作成する
+// 今日の予約と空いている時間帯、今後の休室日、昨日の無断欠席とキャンセルの件数を表示する
+func DailyDigestEmbed(locale string, reservations []*models.Reservation, now time.Time) *discordgo.MessageEmbed {
+	today := now.Format("2006-01-02")
+
+	// 1. 今日の予約
+	todays := schedule.ActiveReservationsOn(reservations, today)
+	var reservationLines []string
+	for _, r := range todays {
+		reservationLines = append(reservationLines, boardEntry(r))
+	}
+	if len(reservationLines) == 0 {
+		reservationLines = []string{i18n.T(locale, "board.no_reservations")}
+	}
+
+	// 2. 空いている時間帯
+	var slotLines []string
+	for _, slot := range schedule.FreeSlots(reservations, today, "") {
+		slotLines = append(slotLines, i18n.T(locale, "availability.slot", slot.Start, slot.End, formatDuration(locale, slot.Duration())))
+	}
+	if len(slotLines) == 0 {
+		slotLines = []string{i18n.T(locale, "digest.no_free_slots")}
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:  i18n.T(locale, "digest.reservations", len(todays)),
+			Value: joinFieldLines(reservationLines),
+		},
+		{
+			Name:  i18n.T(locale, "digest.free_slots"),
+			Value: joinFieldLines(slotLines),
+		},
+	}
+
+	// 3. 今後の休室日（ない場合は表示しない）
+	var closureLines []string
+	for day := 1; day <= digestClosureDays; day++ {
+		date := now.AddDate(0, 0, day).Format("2006-01-02")
+		if reason, closed := schedule.ClosedOn(date); closed {
+			closureLines = append(closureLines, "**"+boardDate(locale, date)+"** "+closedLabel(locale, reason))
+		}
+	}
+	if len(closureLines) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "digest.closures", digestClosureDays),
+			Value: joinFieldLines(closureLines),
+		})
+	}
+
+	// 4. 昨日の記録
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+	report := stats.Compute(reservations, yesterday, yesterday)
+	fields = append(fields, &discordgo.MessageEmbedField{
+		Name:  i18n.T(locale, "digest.yesterday"),
+		Value: i18n.T(locale, "digest.yesterday_counts", report.NoShows, report.Cancelled) + "\n" + noShowNote(locale),
+	})
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "digest.title", formatDateWithWeekday(locale, now)),
+		Description: i18n.T(locale, "digest.description", schedule.OpeningTime, schedule.ClosingTime),
+		Color:       0xFEE75C,
+		Fields:      fields,
+		Timestamp:   now.Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(locale, "digest.footer"),
+		},
+	}
+}
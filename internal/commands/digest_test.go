@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+func TestDailyDigestEmbed(t *testing.T) {
+	defer schedule.SetClosedDays("")
+	if err := schedule.SetClosedDays("2025-11-23=勤労感謝の日"); err != nil {
+		t.Fatal(err)
+	}
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 8, 0, 0, 0, jst)
+	reservations := []*models.Reservation{
+		{ID: "r1", Username: "bob", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Comment: "練習", Status: models.StatusPending},
+		{ID: "r2", Username: "alice", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending},
+		{ID: "r3", Username: "carol", Date: "2025-11-20", StartTime: "16:00", EndTime: "17:00", Status: models.StatusCancelled},
+		// 昨日: 無断欠席1件、チェックイン済み1件、キャンセル1件
+		{ID: "r4", UserID: "u1", Date: "2025-11-19", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCompleted, ReminderSentFor: "2025-11-19 10:00"},
+		{ID: "r5", UserID: "u2", Date: "2025-11-19", StartTime: "12:00", EndTime: "13:00", Status: models.StatusCompleted, ReminderSentFor: "2025-11-19 12:00", CheckedInAt: &now},
+		{ID: "r6", UserID: "u3", Date: "2025-11-19", StartTime: "15:00", EndTime: "16:00", Status: models.StatusCancelled},
+	}
+
+	embed := DailyDigestEmbed(i18n.Japanese, reservations, now)
+	if len(embed.Fields) != 4 {
+		t.Fatalf("Expected 4 fields, got %d", len(embed.Fields))
+	}
+
+	// 今日の予約は時刻順で、キャンセルされたものを除く
+	if embed.Fields[0].Name != i18n.T(i18n.Japanese, "digest.reservations", 2) {
+		t.Errorf("Unexpected reservations field name: %q", embed.Fields[0].Name)
+	}
+	if want := "`10:00-11:00` alice\n`14:00-15:00` bob — 練習"; embed.Fields[0].Value != want {
+		t.Errorf("Expected %q, got %q", want, embed.Fields[0].Value)
+	}
+
+	slots := embed.Fields[1].Value
+	if !strings.Contains(slots, "**09:00 - 10:00**") || !strings.Contains(slots, "**15:00 - 21:00**") {
+		t.Errorf("Unexpected free slots: %q", slots)
+	}
+
+	if !strings.Contains(embed.Fields[2].Value, "**11/23 (日)** ") || !strings.Contains(embed.Fields[2].Value, "勤労感謝の日") {
+		t.Errorf("Expected the upcoming closure, got %q", embed.Fields[2].Value)
+	}
+
+	if want := i18n.T(i18n.Japanese, "digest.yesterday_counts", 1, 1) + "\n" + i18n.T(i18n.Japanese, "weekly_report.no_show_rule"); embed.Fields[3].Value != want {
+		t.Errorf("Expected %q, got %q", want, embed.Fields[3].Value)
+	}
+}
+
+func TestDailyDigestEmbedWithoutClosures(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	embed := DailyDigestEmbed(i18n.English, nil, time.Date(2025, 11, 20, 8, 0, 0, 0, jst))
+
+	// 休室日がない場合は休室日のフィールドを表示しない
+	if len(embed.Fields) != 3 {
+		t.Fatalf("Expected 3 fields, got %d", len(embed.Fields))
+	}
+	if embed.Fields[0].Value != i18n.T(i18n.English, "board.no_reservations") {
+		t.Errorf("Expected no reservations, got %q", embed.Fields[0].Value)
+	}
+}
+
+func TestPostDailyDigestSkipsClosedDays(t *testing.T) {
+	defer schedule.SetClosedDays("")
+	if err := schedule.SetClosedDays("thu"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 休室日は送信しない（セッションを使わずに戻る）
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	posted, err := PostDailyDigest(nil, storage.NewStorageIn(t.TempDir()), "channel", time.Date(2025, 11, 20, 8, 0, 0, 0, jst))
+	if posted || err != nil {
+		t.Errorf("Expected closed day to be skipped, got posted=%v err=%v", posted, err)
+	}
+}
+
+func TestPostDailyDigest(t *testing.T) {
+	s, transport := newRecordingSession(t)
+	store := storage.NewStorageIn(t.TempDir())
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 8, 0, 0, 0, jst)
+
+	// 今日のタイムライン画像を添付して投稿し、同じ日には2回投稿しない
+	posted, err := PostDailyDigest(s, store, "channel", now)
+	if !posted || err != nil {
+		t.Fatalf("Expected digest to be posted, got posted=%v err=%v", posted, err)
+	}
+	if paths := transport.paths(); len(paths) != 1 || paths[0] != "POST /api/v9/channels/channel/messages" {
+		t.Fatalf("Unexpected requests: %v", paths)
+	}
+	if contentType := transport.contentType(0); !strings.HasPrefix(contentType, "multipart/form-data") {
+		t.Errorf("Expected the timeline image to be attached, got %s", contentType)
+	}
+
+	posted, err = PostDailyDigest(s, store, "channel", now.Add(time.Hour))
+	if posted || err != nil {
+		t.Errorf("Expected second digest to be skipped, got posted=%v err=%v", posted, err)
+	}
+}
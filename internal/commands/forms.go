@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// formDraftTTL は入力エラーになったフォームの入力内容を保持する時間
+const formDraftTTL = 30 * time.Minute
+
+// nextFreeSlotSearchDays は予約フォームの初期値として空き時間帯を探す日数
+const nextFreeSlotSearchDays = 14
+
+// formDraft は入力エラーになったフォームの入力内容を表す
+type formDraft struct {
+	Values  map[string]string
+	SavedAt time.Time
+}
+
+// formDrafts は「入力し直す」ボタンでフォームを開き直すための入力内容（キー: ユーザーID:フォームのカスタムID）
+// モーダルはエラー時に開いたままにできないため、再入力の手間を省くためにメモリ上に一時保存する
+var (
+	formDraftsMu sync.Mutex
+	formDrafts   = make(map[string]formDraft)
+)
+
+// saveFormDraft はフォームの入力内容を一時保存する（期限切れのものはここで削除する）
+func saveFormDraft(userID, formID string, values map[string]string) {
+	formDraftsMu.Lock()
+	defer formDraftsMu.Unlock()
+
+	for key, draft := range formDrafts {
+		if time.Since(draft.SavedAt) > formDraftTTL {
+			delete(formDrafts, key)
+		}
+	}
+	formDrafts[userID+customIDSeparator+formID] = formDraft{Values: values, SavedAt: time.Now()}
+}
+
+// takeFormDraft は一時保存したフォームの入力内容を取り出す（取り出した入力内容は削除する）
+func takeFormDraft(userID, formID string) (map[string]string, bool) {
+	formDraftsMu.Lock()
+	defer formDraftsMu.Unlock()
+
+	key := userID + customIDSeparator + formID
+	draft, ok := formDrafts[key]
+	if !ok {
+		return nil, false
+	}
+	delete(formDrafts, key)
+	if time.Since(draft.SavedAt) > formDraftTTL {
+		return nil, false
+	}
+	return draft.Values, true
+}
+
+// formRetryComponents はフォームからの送信が入力エラーになった場合に、入力内容を保存して「入力し直す」ボタンを作成する
+// スラッシュコマンドからの実行の場合は nil を返す
+func formRetryComponents(i *discordgo.InteractionCreate, userID string) []discordgo.MessageComponent {
+	if i.Type != discordgo.InteractionModalSubmit {
+		return nil
+	}
+
+	data := i.ModalSubmitData()
+	saveFormDraft(userID, data.CustomID, modalValues(data))
+
+	// 例: form_retry:edit_form:<予約ID>
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    tr(i, "form.retry"),
+					Style:    discordgo.PrimaryButton,
+					CustomID: actionFormRetry + customIDSeparator + data.CustomID,
+				},
+			},
+		},
+	}
+}
+
+// handleFormRetry は「入力し直す」ボタンを処理し、前回の入力内容でフォームを開き直す
+func handleFormRetry(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, args []string, userID string) {
+	if len(args) == 0 {
+		respondError(s, i, tr(i, "form.invalid_args"))
+		return
+	}
+	formID := encodeCustomID(args[0], args[1:]...)
+	values, ok := takeFormDraft(userID, formID)
+
+	switch args[0] {
+	case actionReserveForm:
+		if !ok {
+			openNewReservationForm(s, i, store)
+			return
+		}
+		openReservationModal(s, i, values["date"], values["start_time"], values["end_time"], values["comment"])
+	case actionEditForm:
+		if len(args) != 2 {
+			respondError(s, i, tr(i, "form.invalid_args"))
+			return
+		}
+		if !ok {
+			openEditModal(s, i, store, args[1], userID)
+			return
+		}
+		showEditModal(s, i, args[1], values)
+	default:
+		respondError(s, i, tr(i, "form.unavailable"))
+	}
+}
+
+// reserveFormCommand は /reserve-form の定義を返す
+func reserveFormCommand() *Command {
+	return &Command{
+		Definition: &discordgo.ApplicationCommand{
+			Name: "reserve-form",
+		},
+		Handler: func(c *CommandContext) {
+			openNewReservationForm(c.Session, c.Interaction, c.Store)
+		},
+		Help:  "help.reserve_form",
+		Modal: true,
+	}
+}
+
+// openNewReservationForm は直近の空き時間帯（最大1時間）を初期値にして予約フォームを開く（/reserve-form と「新しく予約」ボタンで共通）
+// 空きが見つからない場合は日付のみ今日を入力しておく
+func openNewReservationForm(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	nowJST := time.Now().In(jst)
+
+	date, slot, ok := schedule.NextFreeSlot(store.GetAllReservations(), nowJST, nextFreeSlotSearchDays, schedule.SlotMinutes*time.Minute)
+	if !ok {
+		openReservationModal(s, i, nowJST.Format("2006-01-02"), "", "", "")
+		return
+	}
+
+	end := schedule.ToMinutes(slot.Start) + 60
+	if end > schedule.ToMinutes(slot.End) {
+		end = schedule.ToMinutes(slot.End)
+	}
+	openReservationModal(s, i, date, slot.Start, schedule.FromMinutes(end), "")
+}
+
+// newReservationButton は予約フォームを開く「新しく予約」ボタンを作成する
+func newReservationButton(locale string) discordgo.Button {
+	return discordgo.Button{
+		Label:    i18n.T(locale, "form.new_reservation"),
+		Style:    discordgo.SuccessButton,
+		CustomID: encodeCustomID(actionReserveNew),
+		Emoji: discordgo.ComponentEmoji{
+			Name: "➕",
+		},
+	}
+}
+
+// showEditModal は指定の入力値で予約の編集フォームを開く
+func showEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, reservationID string, values map[string]string) {
+	err := respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: encodeCustomID(actionEditForm, reservationID),
+			Title:    tr(i, "form.edit_title"),
+			Components: []discordgo.MessageComponent{
+				modalTextInput("date", tr(i, "form.date"), values["date"], true, discordgo.TextInputShort),
+				modalTextInput("start_time", tr(i, "form.start_time"), values["start_time"], true, discordgo.TextInputShort),
+				modalTextInput("end_time", tr(i, "form.end_time"), values["end_time"], true, discordgo.TextInputShort),
+				modalTextInput("comment", tr(i, "form.comment"), values["comment"], false, discordgo.TextInputParagraph),
+			},
+		},
+	})
+	if err != nil {
+		respondError(s, i, tr(i, "form.edit_open_failed"))
+	}
+}
+
+// reservationFormValues は予約の現在の値を編集フォームの入力値にする
+func reservationFormValues(r *models.Reservation) map[string]string {
+	return map[string]string{
+		"date":       formatDate(r.Date),
+		"start_time": r.StartTime,
+		"end_time":   r.EndTime,
+		"comment":    r.Comment,
+	}
+}
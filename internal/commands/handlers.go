@@ -2,49 +2,25 @@ package commands
 
 import (
 	"github.com/bwmarrin/discordgo"
-	"github.com/dice/hxs_reservation_system/internal/logging"
-	"github.com/dice/hxs_reservation_system/internal/storage"
 )
 
 var UpdateStatusCallback func()
 
-func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string) {
-	// コマンドインタラクションの処理
-	commandName := i.ApplicationCommandData().Name
-	isDM := i.GuildID == ""
-	channelID := i.ChannelID
+// ViewReservationsCommandName はメンバーの右クリックメニューから予約を表示するユーザーコマンドの名前
+// 他の言語での表示名はコマンド定義の NameLocalizations で設定する（インタラクションにはこの名前で届く）
+const ViewReservationsCommandName = "予約を見る"
 
-	userID, username := getUserInfo(i, isDM)
-
-	if !isDM && allowedChannelID != "" && channelID != allowedChannelID {
-		respondEphemeral(s, i, "This command can only be used in the allowed channel or DM.")
-		logger.LogCommand(commandName, userID, username, channelID, false, "Not allowed channel", nil)
-		return
+// commandType はインタラクションのコマンドの種類を判定する
+// discordgo の ApplicationCommandInteractionData は種類を持たないため、右クリックメニューの対象から判定する
+func commandType(data discordgo.ApplicationCommandInteractionData) discordgo.ApplicationCommandType {
+	if data.TargetID == "" || data.Resolved == nil {
+		return discordgo.ChatApplicationCommand
 	}
-
-	parameters := make(map[string]interface{})
-	for _, opt := range i.ApplicationCommandData().Options {
-		parameters[opt.Name] = opt.Value
+	if _, ok := data.Resolved.Users[data.TargetID]; ok {
+		return discordgo.UserApplicationCommand
 	}
-
-	logger.LogCommand(commandName, userID, username, channelID, true, "", parameters)
-
-	switch commandName {
-	case "reserve":
-		handleReserve(s, i, store, logger, allowedChannelID, isDM)
-	case "cancel":
-		handleCancel(s, i, store, logger, allowedChannelID, isDM)
-	case "complete":
-		handleComplete(s, i, store, logger, allowedChannelID, isDM)
-	case "edit":
-		handleEdit(s, i, store, logger, allowedChannelID, isDM)
-	case "list":
-		handleList(s, i, store, logger, isDM)
-	case "my-reservations":
-		handleMyReservations(s, i, store, logger, isDM)
-	case "help":
-		handleHelp(s, i, logger, isDM)
-	case "feedback":
-		handleFeedback(s, i, logger, isDM)
+	if _, ok := data.Resolved.Messages[data.TargetID]; ok {
+		return discordgo.MessageApplicationCommand
 	}
+	return discordgo.ChatApplicationCommand
 }
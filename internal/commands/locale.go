@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// applyUserLocale はユーザーが /language で表示言語を設定している場合、インタラクションの言語をその言語に置き換える
+// インタラクションの処理の最初に呼び出し、以降は lang(i) で表示言語を取得する
+func applyUserLocale(i *discordgo.InteractionCreate, store *storage.Storage) {
+	var userID string
+	switch {
+	case i.Member != nil && i.Member.User != nil:
+		userID = i.Member.User.ID
+	case i.User != nil:
+		userID = i.User.ID
+	default:
+		return
+	}
+
+	if locale := store.GetUserSettings(userID).Locale; i18n.IsSupported(locale) {
+		i.Locale = discordgo.Locale(locale)
+	}
+}
+
+// lang はインタラクションの表示言語を返す
+func lang(i *discordgo.InteractionCreate) string {
+	return i18n.FromDiscord(i.Locale)
+}
+
+// tr はインタラクションの表示言語でメッセージを返す
+func tr(i *discordgo.InteractionCreate, key string, args ...interface{}) string {
+	return i18n.T(lang(i), key, args...)
+}
+
+// userLocale はインタラクション以外でユーザーに送るメッセージ（DMなど）の表示言語を返す
+// ユーザーが /language で設定していない場合は既定の言語
+func userLocale(store *storage.Storage, userID string) string {
+	if locale := store.GetUserSettings(userID).Locale; i18n.IsSupported(locale) {
+		return locale
+	}
+	return i18n.DefaultLocale()
+}
+
+// footer は埋め込みメッセージのフッター（「部室予約システム  |  reserve」など）を返す
+func footer(locale, command string) string {
+	return i18n.T(locale, "footer", command)
+}
+
+// localizedError はメッセージカタログのキーと引数で表すエラー
+// 検証などの処理は表示言語を知らずにエラーを返し、表示する側で localize を使って利用者の言語に変換する
+type localizedError struct {
+	key  string
+	args []interface{}
+}
+
+// newError はメッセージカタログのキーからエラーを作成する
+// 引数の time.Duration とエラーは、表示する言語に合わせて変換される
+func newError(key string, args ...interface{}) error {
+	return &localizedError{key: key, args: args}
+}
+
+// Error はログなどに使う既定の言語（日本語）のメッセージを返す
+func (e *localizedError) Error() string {
+	return localize(i18n.Japanese, e)
+}
+
+// localize はエラーを指定した言語のメッセージにする（newError 以外のエラーはそのまま）
+func localize(locale string, err error) string {
+	var le *localizedError
+	if !errors.As(err, &le) {
+		return err.Error()
+	}
+
+	args := make([]interface{}, len(le.args))
+	for idx, arg := range le.args {
+		switch v := arg.(type) {
+		case time.Duration:
+			args[idx] = formatDuration(locale, v)
+		case error:
+			args[idx] = localize(locale, v)
+		default:
+			args[idx] = v
+		}
+	}
+	return i18n.T(locale, le.key, args...)
+}
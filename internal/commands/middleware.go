@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+)
+
+const (
+	rateLimitCount    = 5                // 同じメンバーが rateLimitWindow の間に実行できるコマンドの回数
+	rateLimitWindow   = 10 * time.Second // 実行回数を数える期間
+	slowCommandWarnAt = 2 * time.Second  // これより時間がかかったコマンドを警告として記録する（応答期限は3秒）
+)
+
+// recoverMiddleware はコマンドの処理中のパニックから復旧し、エラーを記録して実行者に知らせる
+func recoverMiddleware(next HandlerFunc) HandlerFunc {
+	return func(c *CommandContext) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			userID, _ := getUserInfo(c.Interaction, c.IsDM)
+			c.Logger.LogError("ERROR", "recoverMiddleware", "Panic while handling command", fmt.Errorf("%v", rec), map[string]interface{}{
+				"command": c.Command.Name(),
+				"user_id": userID,
+				"stack":   string(debug.Stack()),
+			})
+			respondErrorAfterPanic(c.Session, c.Interaction)
+		}()
+		next(c)
+	}
+}
+
+// recoverInteraction はボタン・モーダルの処理中のパニックから recoverMiddleware と同じように復旧する（defer で直接呼ぶこと）
+// source はログに記録する処理の名前、action はカスタムIDのアクション名
+func recoverInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, logger *logging.Logger, source, action string) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+	// ユーザー情報がないためにパニックした場合もあるので getUserInfo は使わない
+	var userID string
+	switch {
+	case i.Member != nil && i.Member.User != nil:
+		userID = i.Member.User.ID
+	case i.User != nil:
+		userID = i.User.ID
+	}
+	logger.LogError("ERROR", source, "Panic while handling interaction", fmt.Errorf("%v", rec), map[string]interface{}{
+		"action":  action,
+		"user_id": userID,
+		"stack":   string(debug.Stack()),
+	})
+	respondErrorAfterPanic(s, i)
+}
+
+// respondErrorAfterPanic はパニックしたコマンドの実行者にエラーを表示する
+// すでに応答済みの場合は respond がフォローアップメッセージで知らせる
+func respondErrorAfterPanic(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	respondEphemeral(s, i, tr(i, "common.unexpected_error"))
+}
+
+// timingMiddleware はコマンドの処理時間を計測し、時間がかかった場合に警告として記録する
+func timingMiddleware(next HandlerFunc) HandlerFunc {
+	return func(c *CommandContext) {
+		start := time.Now()
+		next(c)
+		if elapsed := time.Since(start); elapsed >= slowCommandWarnAt {
+			c.Logger.LogError("WARN", "timingMiddleware", "Slow command", nil, map[string]interface{}{
+				"command":     c.Command.Name(),
+				"duration_ms": elapsed.Milliseconds(),
+			})
+		}
+	}
+}
+
+// channelMiddleware は指定チャンネルとDM以外からのコマンドを拒否する
+func channelMiddleware(next HandlerFunc) HandlerFunc {
+	return func(c *CommandContext) {
+		if !c.IsDM && c.AllowedChannelID != "" && c.Interaction.ChannelID != c.AllowedChannelID {
+			userID, username := getUserInfo(c.Interaction, c.IsDM)
+			respondEphemeral(c.Session, c.Interaction, tr(c.Interaction, "common.channel_only"))
+			c.Logger.LogCommand(c.Command.Name(), userID, username, c.Interaction.ChannelID, false, "Not allowed channel", nil)
+			return
+		}
+		next(c)
+	}
+}
+
+// rateLimitMiddleware は同じメンバーが短時間に続けて実行したコマンドを拒否する
+func rateLimitMiddleware(limiter *rateLimiter) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *CommandContext) {
+			userID, username := getUserInfo(c.Interaction, c.IsDM)
+			if !limiter.allow(userID, time.Now()) {
+				respondEphemeral(c.Session, c.Interaction, tr(c.Interaction, "common.rate_limited"))
+				c.Logger.LogCommand(c.Command.Name(), userID, username, c.Interaction.ChannelID, false, "Rate limited", nil)
+				return
+			}
+			next(c)
+		}
+	}
+}
+
+// permissionMiddleware はサーバー専用のコマンドをDMで、管理者専用のコマンドを管理者以外が実行した場合に拒否する
+func permissionMiddleware(next HandlerFunc) HandlerFunc {
+	return func(c *CommandContext) {
+		if c.Command.GuildOnly && c.IsDM {
+			respondError(c.Session, c.Interaction, tr(c.Interaction, "common.guild_only"))
+			return
+		}
+		if c.Command.Permission == PermissionAdmin && !isAdmin(c.Interaction) {
+			userID, username := getUserInfo(c.Interaction, c.IsDM)
+			respondError(c.Session, c.Interaction, tr(c.Interaction, "admin.permission_denied"))
+			c.Logger.LogCommand(c.Command.Name(), userID, username, c.Interaction.ChannelID, false, "Permission denied", nil)
+			return
+		}
+		next(c)
+	}
+}
+
+// loggingMiddleware はコマンドの実行とオプションの値を記録する
+// 右クリックメニューのコマンドはオプションを持たないため、対象のメンバーを記録する
+func loggingMiddleware(next HandlerFunc) HandlerFunc {
+	return func(c *CommandContext) {
+		data := c.Interaction.ApplicationCommandData()
+		userID, username := getUserInfo(c.Interaction, c.IsDM)
+
+		parameters := make(map[string]interface{})
+		if commandType(data) == discordgo.UserApplicationCommand {
+			parameters["target_user_id"] = data.TargetID
+		}
+		for _, opt := range data.Options {
+			// サブコマンドの場合はその名前とオプションを記録
+			if opt.Type == discordgo.ApplicationCommandOptionSubCommand {
+				parameters["subcommand"] = opt.Name
+				for _, subOpt := range opt.Options {
+					parameters[subOpt.Name] = subOpt.Value
+				}
+				continue
+			}
+			parameters[opt.Name] = opt.Value
+		}
+
+		c.Logger.LogCommand(c.Command.Name(), userID, username, c.Interaction.ChannelID, true, "", parameters)
+		next(c)
+	}
+}
+
+// rateLimiter はメンバーごとのコマンドの実行時刻を覚え、一定期間の実行回数を制限する
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	calls  map[string][]time.Time
+}
+
+// newRateLimiter は window の間に limit 回まで実行を許可する rateLimiter を作成する
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		window: window,
+		calls:  make(map[string][]time.Time),
+	}
+}
+
+// allow は userID のコマンドの実行を許可するかどうかを返し、許可した場合は実行時刻を記録する
+func (l *rateLimiter) allow(userID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	recent := l.calls[userID][:0]
+	for _, t := range l.calls[userID] {
+		if now.Sub(t) < l.window {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= l.limit {
+		l.calls[userID] = recent
+		return false
+	}
+	if len(recent) == 0 {
+		// 古い記録だけのメンバーを残し続けないように作り直す
+		recent = nil
+	}
+	l.calls[userID] = append(recent, now)
+	return true
+}
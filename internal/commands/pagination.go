@@ -0,0 +1,322 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// reservationsPerPage は一覧の1ページに表示する予約の件数（ヘッダーと合わせて埋め込み10件以内）
+const reservationsPerPage = 9
+
+// 一覧の種類
+const (
+	listKindAll    = "list"
+	listKindMine   = "my"
+	listKindMember = "member" // 右クリックメニューから表示する特定メンバーの今後の予約
+)
+
+// 一覧の状態フィルタ
+const (
+	statusFilterActive    = "active"    // 予約中のみ（既定）
+	statusFilterCompleted = "completed" // 完了のみ
+	statusFilterCancelled = "cancelled" // キャンセル済みのみ
+	statusFilterPast      = "past"      // 完了・キャンセル済み
+	statusFilterAll       = "all"       // すべて
+)
+
+// listQuery は予約一覧の表示条件を表す
+// ボタンのカスタムIDにそのまま埋め込み、ページ送りの間も条件を保持する
+type listQuery struct {
+	Kind   string // listKindAll / listKindMine / listKindMember
+	Page   int    // 0始まりのページ番号
+	Status string // 状態フィルタ
+	From   string // 開始日（YYYY-MM-DD形式、空の場合は制限なし）
+	To     string // 終了日（YYYY-MM-DD形式、空の場合は制限なし）
+	UserID string // 予約者で絞り込む場合のDiscord ID（/list と listKindMember のみ）
+}
+
+// customID は指定ページを表示するボタンのカスタムIDを作成する
+// 例: list_page:list:2:active:20251101:20251130:123456789012345678
+func (q listQuery) customID(page int) string {
+	return encodeCustomID(actionListPage, q.Kind, strconv.Itoa(page), q.Status,
+		strings.ReplaceAll(q.From, "-", ""), strings.ReplaceAll(q.To, "-", ""), q.UserID)
+}
+
+// parseListQuery はボタンのカスタムIDの引数から表示条件を復元する
+func parseListQuery(args []string) (listQuery, error) {
+	if len(args) != 6 {
+		return listQuery{}, errors.New("invalid list page arguments")
+	}
+
+	page, err := strconv.Atoi(args[1])
+	if err != nil || page < 0 {
+		return listQuery{}, errors.New("invalid page number")
+	}
+
+	return listQuery{
+		Kind:   args[0],
+		Page:   page,
+		Status: args[2],
+		From:   expandDateArg(args[3]),
+		To:     expandDateArg(args[4]),
+		UserID: args[5],
+	}, nil
+}
+
+// expandDateArg はカスタムID用の YYYYMMDD 形式を YYYY-MM-DD 形式に戻す
+func expandDateArg(yyyymmdd string) string {
+	if len(yyyymmdd) != 8 {
+		return ""
+	}
+	return yyyymmdd[:4] + "-" + yyyymmdd[4:6] + "-" + yyyymmdd[6:]
+}
+
+// statuses は状態フィルタに対応する予約状態を返す（nil はすべて）
+func (q listQuery) statuses() []models.ReservationStatus {
+	switch q.Status {
+	case statusFilterCompleted:
+		return []models.ReservationStatus{models.StatusCompleted}
+	case statusFilterCancelled:
+		return []models.ReservationStatus{models.StatusCancelled}
+	case statusFilterPast:
+		return []models.ReservationStatus{models.StatusCompleted, models.StatusCancelled}
+	case statusFilterAll:
+		return nil
+	default:
+		return []models.ReservationStatus{models.StatusPending}
+	}
+}
+
+// describe は絞り込み条件の説明文を返す（条件がない場合は空文字）
+func (q listQuery) describe(locale string) string {
+	var parts []string
+	switch q.Status {
+	case statusFilterCompleted, statusFilterCancelled, statusFilterPast, statusFilterAll:
+		parts = append(parts, i18n.T(locale, "list.condition_status", i18n.T(locale, "list.status."+q.Status)))
+	}
+	if q.From != "" || q.To != "" {
+		parts = append(parts, i18n.T(locale, "list.condition_period", formatDate(q.From), formatDate(q.To)))
+	}
+	if q.UserID != "" {
+		parts = append(parts, i18n.T(locale, "list.condition_user", q.UserID))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// listQueryFromOptions はスラッシュコマンドのオプションから表示条件を作成する
+func listQueryFromOptions(kind string, options []*discordgo.ApplicationCommandInteractionDataOption, s *discordgo.Session) (listQuery, error) {
+	q := listQuery{Kind: kind, Status: statusFilterActive}
+
+	for _, opt := range options {
+		switch opt.Name {
+		case "status":
+			q.Status = opt.StringValue()
+		case "from", "to":
+			date, _, err := parseDateInput(opt.StringValue())
+			if err != nil {
+				return q, err
+			}
+			if opt.Name == "from" {
+				q.From = date
+			} else {
+				q.To = date
+			}
+		case "user":
+			if user := opt.UserValue(s); user != nil {
+				q.UserID = user.ID
+			}
+		}
+	}
+	return q, nil
+}
+
+// buildListPage は表示条件に従って一覧の1ページ分のメッセージを作成する
+func buildListPage(locale string, store *storage.Storage, q listQuery, viewerID string) *discordgo.InteractionResponseData {
+	filter := storage.ReservationFilter{
+		UserID:   q.UserID,
+		Statuses: q.statuses(),
+		FromDate: q.From,
+		ToDate:   q.To,
+	}
+
+	title, color, command := i18n.T(locale, "list.title_all"), 0x000000, "list"
+	switch q.Kind {
+	case listKindMine:
+		filter.UserID = viewerID
+		title, color, command = i18n.T(locale, "list.title_mine"), 0xFFFFFF, "my-reservations"
+	case listKindMember:
+		title, color, command = i18n.T(locale, "list.title_member"), 0x99AAB5, "member-reservations"
+	}
+
+	// 予約IDは予約者本人にのみ表示する
+	showIDs := q.Kind == listKindMine || (q.Kind == listKindMember && q.UserID == viewerID)
+
+	reservations := store.FindReservations(filter)
+
+	// 予約がない場合
+	if len(reservations) == 0 {
+		description := i18n.T(locale, "list.empty")
+		if q.Kind == listKindMine {
+			description = i18n.T(locale, "list.empty_mine")
+		}
+		if condition := q.describe(locale); condition != "" {
+			description = i18n.T(locale, "list.empty_filtered") + "\n" + condition
+		}
+		if q.Kind == listKindMember {
+			description = i18n.T(locale, "list.empty_member", q.UserID)
+		}
+		return &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       title,
+					Description: description,
+					Color:       color,
+					Timestamp:   time.Now().Format(time.RFC3339),
+				},
+			},
+			Components: paginationComponents(locale, q, 0, 1),
+			Flags:      discordgo.MessageFlagsEphemeral,
+		}
+	}
+
+	// ページ範囲を決定
+	totalPages := (len(reservations) + reservationsPerPage - 1) / reservationsPerPage
+	page := q.Page
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+	startIdx := page * reservationsPerPage
+	endIdx := startIdx + reservationsPerPage
+	if endIdx > len(reservations) {
+		endIdx = len(reservations)
+	}
+
+	// ヘッダー
+	headerDescription := i18n.T(locale, "list.count", len(reservations))
+	if condition := q.describe(locale); condition != "" {
+		headerDescription += "\n" + condition
+	}
+	if q.Kind == listKindMember {
+		headerDescription = i18n.T(locale, "list.count_member", q.UserID, len(reservations))
+	}
+	embeds := []*discordgo.MessageEmbed{
+		createHeaderEmbed(title, headerDescription, color, i18n.T(locale, "list.footer_page", command, page+1, totalPages)),
+	}
+
+	for idx := startIdx; idx < endIdx; idx++ {
+		r := reservations[idx]
+
+		// 予約者本人には予約ID、それ以外は予約者を先頭に表示する
+		firstField := &discordgo.MessageEmbedField{
+			Name:   i18n.T(locale, "label.user"),
+			Value:  fmt.Sprintf("<@%s>", r.UserID),
+			Inline: false,
+		}
+		if showIDs {
+			firstField = &discordgo.MessageEmbedField{
+				Name:   i18n.T(locale, "label.reservation_id"),
+				Value:  fmt.Sprintf("`%s`", r.ID),
+				Inline: false,
+			}
+		}
+
+		fields := []*discordgo.MessageEmbedField{
+			firstField,
+			{
+				Name:   i18n.T(locale, "label.date"),
+				Value:  formatDate(r.Date),
+				Inline: true,
+			},
+			{
+				Name:   i18n.T(locale, "label.time"),
+				Value:  fmt.Sprintf("%s - %s", r.StartTime, r.EndTime),
+				Inline: true,
+			},
+		}
+
+		if r.Comment != "" {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:   i18n.T(locale, "label.comment"),
+				Value:  r.Comment,
+				Inline: false,
+			})
+		}
+
+		embedTitle := fmt.Sprintf("No.%d", idx+1)
+		if r.Status != models.StatusPending {
+			embedTitle = fmt.Sprintf("No.%d  [%s]", idx+1, statusLabel(locale, r.Status))
+		}
+
+		embeds = append(embeds, createReservationEmbed(
+			embedTitle,
+			fields,
+			color,
+			i18n.T(locale, "list.footer_item", command, idx+1, len(reservations)),
+		))
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds:     embeds,
+		Components: paginationComponents(locale, q, page, totalPages),
+		Flags:      discordgo.MessageFlagsEphemeral,
+	}
+}
+
+// paginationComponents は「前へ」「次へ」ボタン（1ページのみの場合はなし）と「新しく予約」ボタンを作成する
+func paginationComponents(locale string, q listQuery, page, totalPages int) []discordgo.MessageComponent {
+	if totalPages <= 1 {
+		return []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{newReservationButton(locale)},
+			},
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(locale, "list.previous"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: q.customID(page - 1),
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    fmt.Sprintf("%d / %d", page+1, totalPages),
+					Style:    discordgo.SecondaryButton,
+					CustomID: q.customID(page) + customIDSeparator + "current",
+					Disabled: true,
+				},
+				discordgo.Button{
+					Label:    i18n.T(locale, "list.next"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: q.customID(page + 1),
+					Disabled: page >= totalPages-1,
+				},
+				newReservationButton(locale),
+			},
+		},
+	}
+}
+
+// handleListPage は一覧のページ送りボタンを処理し、元のメッセージを更新する
+func handleListPage(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, args []string, viewerID string) {
+	q, err := parseListQuery(args)
+	if err != nil {
+		respondError(s, i, tr(i, "list.invalid_page"))
+		return
+	}
+
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: buildListPage(lang(i), store, q, viewerID),
+	})
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+func TestListQueryCustomIDRoundTrip(t *testing.T) {
+	q := listQuery{
+		Kind:   listKindAll,
+		Status: statusFilterPast,
+		From:   "2025-11-01",
+		To:     "2025-11-30",
+		UserID: "123456789012345678",
+	}
+
+	customID := q.customID(3)
+	if len(customID) > 100 {
+		t.Fatalf("Custom ID exceeds Discord's 100 character limit: %d", len(customID))
+	}
+
+	action, args := decodeCustomID(customID)
+	if action != actionListPage {
+		t.Fatalf("Expected action %s, got %s", actionListPage, action)
+	}
+
+	restored, err := parseListQuery(args)
+	if err != nil {
+		t.Fatalf("parseListQuery failed: %v", err)
+	}
+
+	q.Page = 3
+	if restored != q {
+		t.Errorf("Expected %+v, got %+v", q, restored)
+	}
+
+	// 引数が足りない場合はエラー
+	if _, err := parseListQuery(args[:3]); err == nil {
+		t.Error("Expected error for truncated arguments")
+	}
+}
+
+func TestBuildListPageMemberHidesIDs(t *testing.T) {
+	store := storage.NewStorageIn(t.TempDir())
+	store.AddReservation(&models.Reservation{
+		ID:        "0123456789abcdef0123456789abcdef",
+		UserID:    "owner",
+		Date:      "2099-01-01",
+		StartTime: "10:00",
+		EndTime:   "11:00",
+		Status:    models.StatusPending,
+	})
+
+	q := listQuery{Kind: listKindMember, Status: statusFilterActive, UserID: "owner"}
+
+	// 本人以外には予約IDを表示しない
+	data := buildListPage("ja", store, q, "someone-else")
+	if len(data.Embeds) != 2 {
+		t.Fatalf("Expected header and 1 reservation embed, got %d", len(data.Embeds))
+	}
+	if strings.Contains(data.Embeds[1].Fields[0].Value, "0123456789abcdef") {
+		t.Error("Expected reservation ID to be hidden from non-owners")
+	}
+
+	// 本人には予約IDを表示する
+	data = buildListPage("ja", store, q, "owner")
+	if !strings.Contains(data.Embeds[1].Fields[0].Value, "0123456789abcdef") {
+		t.Error("Expected reservation ID to be shown to the owner")
+	}
+}
+
+func TestCommandType(t *testing.T) {
+	slash := discordgo.ApplicationCommandInteractionData{Name: "list"}
+	if got := commandType(slash); got != discordgo.ChatApplicationCommand {
+		t.Errorf("Expected chat input command, got %d", got)
+	}
+
+	user := discordgo.ApplicationCommandInteractionData{
+		Name:     ViewReservationsCommandName,
+		TargetID: "123",
+		Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+			Users: map[string]*discordgo.User{"123": {ID: "123"}},
+		},
+	}
+	if got := commandType(user); got != discordgo.UserApplicationCommand {
+		t.Errorf("Expected user command, got %d", got)
+	}
+}
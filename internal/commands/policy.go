@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+// reservationLimits はメンバー1人あたりの予約の上限を表す（0 は無制限）
+type reservationLimits struct {
+	MaxActive       int // 同時に持てる予約中の予約の件数（MAX_ACTIVE_RESERVATIONS）
+	MaxDailyMinutes int // 1日に予約できる合計時間（分、MAX_DAILY_RESERVATION_HOURS）
+}
+
+// currentReservationLimits は環境変数から予約の上限を読み込む
+// 未設定または不正な値の場合は無制限とする
+func currentReservationLimits() reservationLimits {
+	var limits reservationLimits
+	if n, err := strconv.Atoi(os.Getenv("MAX_ACTIVE_RESERVATIONS")); err == nil && n > 0 {
+		limits.MaxActive = n
+	}
+	if hours, err := strconv.ParseFloat(os.Getenv("MAX_DAILY_RESERVATION_HOURS"), 64); err == nil && hours > 0 {
+		limits.MaxDailyMinutes = int(hours * 60)
+	}
+	return limits
+}
+
+// check は userID が予約 r を持った場合に上限を超えないかを調べる
+// reservations のうち r と同じIDの予約は数えない（編集・譲渡の場合）
+func (l reservationLimits) check(reservations []*models.Reservation, userID string, r *models.Reservation) error {
+	active := 0
+	dailyMinutes := schedule.ToMinutes(r.EndTime) - schedule.ToMinutes(r.StartTime)
+	for _, other := range reservations {
+		if other.ID == r.ID || other.UserID != userID {
+			continue
+		}
+		if other.Status == models.StatusPending {
+			active++
+		}
+		if other.Date == r.Date && other.Status != models.StatusCancelled {
+			dailyMinutes += schedule.ToMinutes(other.EndTime) - schedule.ToMinutes(other.StartTime)
+		}
+	}
+
+	if l.MaxActive > 0 && active+1 > l.MaxActive {
+		return newError("limits.max_active", l.MaxActive, active)
+	}
+	if l.MaxDailyMinutes > 0 && dailyMinutes > l.MaxDailyMinutes {
+		return newError("limits.max_daily", time.Duration(l.MaxDailyMinutes)*time.Minute, formatDate(r.Date), time.Duration(dailyMinutes)*time.Minute)
+	}
+	return nil
+}
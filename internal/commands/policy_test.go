@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+func TestCurrentReservationLimits(t *testing.T) {
+	t.Setenv("MAX_ACTIVE_RESERVATIONS", "3")
+	t.Setenv("MAX_DAILY_RESERVATION_HOURS", "2.5")
+	if limits := currentReservationLimits(); limits.MaxActive != 3 || limits.MaxDailyMinutes != 150 {
+		t.Errorf("Unexpected limits: %+v", limits)
+	}
+
+	// 不正な値は無制限として扱う
+	t.Setenv("MAX_ACTIVE_RESERVATIONS", "many")
+	t.Setenv("MAX_DAILY_RESERVATION_HOURS", "-1")
+	if limits := currentReservationLimits(); limits != (reservationLimits{}) {
+		t.Errorf("Expected no limits, got %+v", limits)
+	}
+}
+
+func TestReservationLimitsCheck(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", UserID: "u1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending},
+		{ID: "r2", UserID: "u1", Date: "2025-11-21", StartTime: "10:00", EndTime: "12:00", Status: models.StatusPending},
+		{ID: "r3", UserID: "u1", Date: "2025-11-20", StartTime: "13:00", EndTime: "14:00", Status: models.StatusCancelled},
+		{ID: "r4", UserID: "u2", Date: "2025-11-20", StartTime: "15:00", EndTime: "16:00", Status: models.StatusPending},
+	}
+	newReservation := &models.Reservation{ID: "new", Date: "2025-11-20", StartTime: "16:00", EndTime: "17:30"}
+
+	if err := (reservationLimits{}).check(reservations, "u1", newReservation); err != nil {
+		t.Errorf("Expected no error without limits, got %v", err)
+	}
+
+	// u1 は予約中が2件あるため、3件目は上限2件を超える
+	if err := (reservationLimits{MaxActive: 2}).check(reservations, "u1", newReservation); err == nil || !strings.Contains(err.Error(), "2 件まで") {
+		t.Errorf("Expected active limit error, got %v", err)
+	}
+	if err := (reservationLimits{MaxActive: 2}).check(reservations, "u2", newReservation); err != nil {
+		t.Errorf("Expected no error for u2, got %v", err)
+	}
+
+	// 同じ日の予約（キャンセル済みを除く）と合わせて 2時間30分 になる
+	if err := (reservationLimits{MaxDailyMinutes: 120}).check(reservations, "u1", newReservation); err == nil || !strings.Contains(err.Error(), "2時間30分") {
+		t.Errorf("Expected daily limit error, got %v", err)
+	}
+	if err := (reservationLimits{MaxDailyMinutes: 150}).check(reservations, "u1", newReservation); err != nil {
+		t.Errorf("Expected no error at the daily limit, got %v", err)
+	}
+
+	// 編集・譲渡する予約自身は数えない
+	if err := (reservationLimits{MaxActive: 2, MaxDailyMinutes: 60}).check(reservations, "u1", reservations[0]); err != nil {
+		t.Errorf("Expected the reservation itself to be excluded, got %v", err)
+	}
+}
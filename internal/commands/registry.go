@@ -0,0 +1,300 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// Permission はコマンドを実行できるメンバーを表す
+type Permission int
+
+const (
+	PermissionEveryone Permission = iota // すべてのメンバー
+	PermissionAdmin                      // 管理者のみ（isAdmin）
+)
+
+// CommandContext はコマンドの処理に渡す情報
+type CommandContext struct {
+	Session          *discordgo.Session
+	Interaction      *discordgo.InteractionCreate
+	Store            *storage.Storage
+	Logger           *logging.Logger
+	AllowedChannelID string
+	IsDM             bool
+	Command          *Command
+	Registry         *Registry
+}
+
+// HandlerFunc はコマンドを処理する関数
+type HandlerFunc func(c *CommandContext)
+
+// Middleware はコマンドの処理の前後に共通の処理を挟む
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Command はレジストリに登録するコマンド
+// コマンドを追加する場合は cmd_xxx.go に xxxCommand() を作成し、builtinCommands に追加する
+type Command struct {
+	Definition *discordgo.ApplicationCommand // コマンド定義（説明と選択肢の名前はメッセージカタログから設定される）
+	Handler    HandlerFunc
+	Permission Permission
+	GuildOnly  bool   // DMでは使用できない（コマンド定義の DMPermission にも反映される）
+	Help       string // /help に表示する説明のメッセージキー（空の場合は表示しない）
+	Modal      bool   // モーダルを開くコマンド（モーダルは遅延応答の後に開けないため、自動で遅延応答しない）
+}
+
+// Name はコマンド名を返す
+func (c *Command) Name() string {
+	return c.Definition.Name
+}
+
+// Registry はコマンドの定義と処理をまとめて管理する
+// コマンドの登録（Definitions）、インタラクションの振り分け（HandleInteraction）、/help の生成（HelpMessages）に使う
+type Registry struct {
+	commands   []*Command
+	byName     map[string]*Command
+	middleware []Middleware
+}
+
+// NewRegistry は空のレジストリを作成する
+func NewRegistry(middleware ...Middleware) *Registry {
+	return &Registry{
+		byName:     make(map[string]*Command),
+		middleware: middleware,
+	}
+}
+
+// DefaultRegistry は組み込みのコマンドと標準のミドルウェアを登録したレジストリを作成する
+// ミドルウェアは先頭のものが外側になる（パニックからの復旧 → 処理時間の計測 → チャンネル制限 → 実行回数の制限 → 権限 → ログ）
+func DefaultRegistry() *Registry {
+	r := NewRegistry(
+		recoverMiddleware,
+		timingMiddleware,
+		channelMiddleware,
+		rateLimitMiddleware(newRateLimiter(rateLimitCount, rateLimitWindow)),
+		permissionMiddleware,
+		loggingMiddleware,
+	)
+	r.Register(builtinCommands()...)
+	return r
+}
+
+// builtinCommands は組み込みのコマンドを /help に表示する順に返す
+func builtinCommands() []*Command {
+	return []*Command{
+		reserveCommand(),
+		reserveNowCommand(),
+		reserveFormCommand(),
+		editCommand(),
+		extendCommand(),
+		transferCommand(),
+		cancelCommand(),
+		completeCommand(),
+		listCommand(),
+		myReservationsCommand(),
+		viewReservationsCommand(),
+		historyCommand(),
+		statsCommand(),
+		availabilityCommand(),
+		scheduleCommand(),
+		feedbackCommand(),
+		helpCommand(),
+		languageCommand(),
+		rankingCommand(),
+		adminCommand(),
+	}
+}
+
+// Register はコマンドを登録する。同じ名前のコマンドを登録した場合はパニックする
+func (r *Registry) Register(commands ...*Command) {
+	for _, cmd := range commands {
+		if _, exists := r.byName[cmd.Name()]; exists {
+			panic(fmt.Sprintf("command %q is already registered", cmd.Name()))
+		}
+		if cmd.GuildOnly {
+			cmd.Definition.DMPermission = &dmPermissionDisabled
+		}
+		localizeCommand(cmd.Definition)
+		r.commands = append(r.commands, cmd)
+		r.byName[cmd.Name()] = cmd
+	}
+}
+
+// Use はミドルウェアを追加する（既存のミドルウェアの内側に追加される）
+func (r *Registry) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Lookup は名前からコマンドを取得する
+func (r *Registry) Lookup(name string) (*Command, bool) {
+	cmd, ok := r.byName[name]
+	return cmd, ok
+}
+
+// Commands は登録されているコマンドを登録順に返す
+func (r *Registry) Commands() []*Command {
+	return append([]*Command(nil), r.commands...)
+}
+
+// Definitions はDiscordに登録するコマンド定義を返す
+func (r *Registry) Definitions() []*discordgo.ApplicationCommand {
+	definitions := make([]*discordgo.ApplicationCommand, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		definitions = append(definitions, cmd.Definition)
+	}
+	return definitions
+}
+
+// HandleInteraction はスラッシュコマンドと右クリックメニューのコマンドをミドルウェアを通して処理する
+// 応答は respond を通して送る（時間がかかった場合は自動で遅延応答し、後から元の応答を編集する）
+func (r *Registry) HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string) {
+	applyUserLocale(i, store)
+
+	cmd, ok := r.Lookup(i.ApplicationCommandData().Name)
+	if !ok {
+		return
+	}
+	defer trackResponse(s, i, logger, !cmd.Modal)()
+
+	r.Handle(&CommandContext{
+		Session:          s,
+		Interaction:      i,
+		Store:            store,
+		Logger:           logger,
+		AllowedChannelID: allowedChannelID,
+		IsDM:             i.GuildID == "",
+		Command:          cmd,
+		Registry:         r,
+	})
+}
+
+// Handle はミドルウェアを通してコマンドを実行する
+func (r *Registry) Handle(c *CommandContext) {
+	handler := c.Command.Handler
+	for idx := len(r.middleware) - 1; idx >= 0; idx-- {
+		handler = r.middleware[idx](handler)
+	}
+	handler(c)
+}
+
+// helpMessageLimit はDiscordのメッセージ1件の文字数の上限
+const helpMessageLimit = 2000
+
+// HelpMessages は /help に表示するメッセージを登録されているコマンドから作成する
+// 管理者専用・サーバー専用のコマンドは、実行者が使用できる場合だけ表示する
+// 1件のメッセージの上限を超える場合は、コマンドの区切りで複数のメッセージに分ける
+func (r *Registry) HelpMessages(locale string, admin, isDM bool) []string {
+	sections := []string{i18n.T(locale, "help.header")}
+	for _, cmd := range r.commands {
+		if cmd.Help == "" || (cmd.Permission == PermissionAdmin && !admin) || (cmd.GuildOnly && isDM) {
+			continue
+		}
+		section := "**" + commandLabel(locale, cmd) + "**"
+		if cmd.Permission == PermissionAdmin {
+			section += i18n.T(locale, "help.admin_only")
+		}
+		sections = append(sections, section+"\n"+i18n.T(locale, cmd.Help)+"\n\n")
+	}
+	sections = append(sections, i18n.T(locale, "help.footer"))
+
+	var messages []string
+	var current strings.Builder
+	for _, section := range sections {
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+utf8.RuneCountInString(section) > helpMessageLimit {
+			messages = append(messages, current.String())
+			current.Reset()
+		}
+		current.WriteString(section)
+	}
+	return append(messages, current.String())
+}
+
+// commandLabel はヘルプに表示するコマンド名を返す（スラッシュコマンドは「/名前」、右クリックメニューは表示名）
+func commandLabel(locale string, cmd *Command) string {
+	if cmd.Definition.Type == discordgo.UserApplicationCommand || cmd.Definition.Type == discordgo.MessageApplicationCommand {
+		discordLocale := discordgo.EnglishUS
+		if locale == i18n.Japanese {
+			discordLocale = discordgo.Japanese
+		}
+		if cmd.Definition.NameLocalizations != nil {
+			if name := (*cmd.Definition.NameLocalizations)[discordLocale]; name != "" {
+				return name
+			}
+		}
+		return cmd.Name()
+	}
+	return "/" + cmd.Name()
+}
+
+// dmPermissionDisabled はDMで使用できないコマンドの定義に指定する
+var dmPermissionDisabled = false
+
+// localizeCommand はコマンド定義の説明と選択肢の名前を、メッセージカタログの各言語のメッセージで設定する
+// 既定の説明は英語（日本語以外の言語設定のユーザーには英語で表示するため）とし、各言語の翻訳を *Localizations に設定する
+// キーはコマンドが「command.コマンド名」、オプションは「command.コマンド名.サブコマンド名.オプション名」から
+// 順に短くしたもの、最後に「option.オプション名」を探す。選択肢はオプションのキーに「.値」を付けたもの
+func localizeCommand(cmd *discordgo.ApplicationCommand) {
+	if cmd.Type != 0 && cmd.Type != discordgo.ChatApplicationCommand {
+		return
+	}
+	key := "command." + cmd.Name
+	cmd.Description = i18n.T(i18n.English, key)
+	cmd.DescriptionLocalizations = localizationsOf(key)
+	localizeOptions(cmd.Options, []string{cmd.Name})
+}
+
+// localizeOptions はオプション（サブコマンドを含む）の説明と選択肢の名前を設定する
+func localizeOptions(options []*discordgo.ApplicationCommandOption, path []string) {
+	for _, opt := range options {
+		optionPath := append(append([]string{}, path...), opt.Name)
+		keys := optionKeys(optionPath)
+
+		key := firstKey(keys)
+		opt.Description = i18n.T(i18n.English, key)
+		opt.DescriptionLocalizations = *localizationsOf(key)
+
+		for _, choice := range opt.Choices {
+			choiceKeys := make([]string, len(keys))
+			for idx, k := range keys {
+				choiceKeys[idx] = fmt.Sprintf("%s.%v", k, choice.Value)
+			}
+			choiceKey := firstKey(choiceKeys)
+			choice.Name = i18n.T(i18n.English, choiceKey)
+			choice.NameLocalizations = *localizationsOf(choiceKey)
+		}
+
+		localizeOptions(opt.Options, optionPath)
+	}
+}
+
+// optionKeys はオプションの説明を探すキーを優先順に返す
+// 例: admin edit date → command.admin.edit.date, command.admin.date, option.date
+func optionKeys(path []string) []string {
+	name := path[len(path)-1]
+	var keys []string
+	for n := len(path) - 1; n >= 1; n-- {
+		keys = append(keys, "command."+strings.Join(path[:n], ".")+"."+name)
+	}
+	return append(keys, "option."+name)
+}
+
+// firstKey はメッセージカタログにある最初のキーを返す（どれもない場合は最初のキー）
+func firstKey(keys []string) string {
+	for _, key := range keys {
+		if i18n.Has(key) {
+			return key
+		}
+	}
+	return keys[0]
+}
+
+// localizationsOf はコマンド定義に設定する言語ごとのメッセージを返す
+func localizationsOf(key string) *map[discordgo.Locale]string {
+	localizations := i18n.Localizations(key)
+	return &localizations
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+)
+
+func TestRegistryMiddlewareOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(c *CommandContext) {
+				calls = append(calls, name+" before")
+				next(c)
+				calls = append(calls, name+" after")
+			}
+		}
+	}
+
+	r := NewRegistry(trace("outer"))
+	r.Use(trace("inner"))
+	r.Register(&Command{
+		Definition: &discordgo.ApplicationCommand{Name: "ping"},
+		Handler:    func(c *CommandContext) { calls = append(calls, "handler") },
+	})
+
+	cmd, ok := r.Lookup("ping")
+	if !ok {
+		t.Fatal("Expected ping to be registered")
+	}
+	r.Handle(&CommandContext{Command: cmd, Registry: r})
+
+	want := []string{"outer before", "inner before", "handler", "inner after", "outer after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Unexpected call order: %v", calls)
+	}
+}
+
+func TestRegistryRejectsDuplicateNames(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic when registering a duplicate command")
+		}
+	}()
+	r := NewRegistry()
+	r.Register(&Command{Definition: &discordgo.ApplicationCommand{Name: "ping"}})
+	r.Register(&Command{Definition: &discordgo.ApplicationCommand{Name: "ping"}})
+}
+
+// TestDefaultRegistryDefinitions はコマンド定義の説明と選択肢の名前がすべてメッセージカタログから設定されていることを確認する
+// キーがカタログにない場合、i18n.T はキーをそのまま返す。Discordに登録する r.Definitions() の各言語の翻訳も確認する
+func TestDefaultRegistryDefinitions(t *testing.T) {
+	isKey := func(s string) bool {
+		return strings.HasPrefix(s, "command.") || strings.HasPrefix(s, "option.")
+	}
+	checkLocalizations := func(path string, localizations map[discordgo.Locale]string) {
+		if localizations[discordgo.Japanese] == "" {
+			t.Errorf("/%s: missing Japanese localization", path)
+		}
+		for locale, text := range localizations {
+			if text == "" || isKey(text) || utf8.RuneCountInString(text) > 100 {
+				t.Errorf("/%s: invalid %s localization %q", path, locale, text)
+			}
+		}
+	}
+
+	var checkOptions func(path string, options []*discordgo.ApplicationCommandOption)
+	checkOptions = func(path string, options []*discordgo.ApplicationCommandOption) {
+		for _, opt := range options {
+			optionPath := path + " " + opt.Name
+			if opt.Description == "" || isKey(opt.Description) || utf8.RuneCountInString(opt.Description) > 100 {
+				t.Errorf("/%s: invalid description %q", optionPath, opt.Description)
+			}
+			checkLocalizations(optionPath, opt.DescriptionLocalizations)
+			for _, choice := range opt.Choices {
+				if choice.Name == "" || isKey(choice.Name) {
+					t.Errorf("/%s: invalid name %q for choice %v", optionPath, choice.Name, choice.Value)
+				}
+				checkLocalizations(fmt.Sprintf("%s=%v", optionPath, choice.Value), choice.NameLocalizations)
+			}
+			checkOptions(optionPath, opt.Options)
+		}
+	}
+
+	r := DefaultRegistry()
+	for _, cmd := range r.Commands() {
+		def := cmd.Definition
+		if cmd.Handler == nil {
+			t.Errorf("%s: missing handler", def.Name)
+		}
+		if cmd.GuildOnly && (def.DMPermission == nil || *def.DMPermission) {
+			t.Errorf("%s: guild-only command should disable DM permission", def.Name)
+		}
+		if def.Type != 0 && def.Type != discordgo.ChatApplicationCommand {
+			if def.NameLocalizations == nil {
+				t.Errorf("%s: missing name localizations", def.Name)
+			}
+			continue
+		}
+		if def.Description == "" || isKey(def.Description) || utf8.RuneCountInString(def.Description) > 100 {
+			t.Errorf("/%s: invalid description %q", def.Name, def.Description)
+		}
+		if def.DescriptionLocalizations == nil {
+			t.Errorf("/%s: missing description localizations", def.Name)
+		} else {
+			checkLocalizations(def.Name, *def.DescriptionLocalizations)
+		}
+		checkOptions(def.Name, def.Options)
+	}
+
+	definitions := r.Definitions()
+	if len(definitions) != len(r.Commands()) {
+		t.Errorf("Expected one definition per command")
+	}
+	for _, def := range definitions {
+		if cmd, ok := r.Lookup(def.Name); !ok || cmd.Definition != def {
+			t.Errorf("%s: registered definition does not match the command", def.Name)
+		}
+	}
+}
+
+func TestHelpMessages(t *testing.T) {
+	r := DefaultRegistry()
+	for _, locale := range i18n.Locales() {
+		messages := r.HelpMessages(locale, true, false)
+		for idx, message := range messages {
+			if n := utf8.RuneCountInString(message); n > helpMessageLimit {
+				t.Errorf("Help message %d in %q is %d characters (limit %d)", idx, locale, n, helpMessageLimit)
+			}
+		}
+
+		full := strings.Join(messages, "")
+		for _, cmd := range r.Commands() {
+			if cmd.Help != "" && !strings.Contains(full, "**"+commandLabel(locale, cmd)+"**") {
+				t.Errorf("Help message in %q does not mention %s", locale, cmd.Name())
+			}
+		}
+		if strings.Contains(full, "help.") {
+			t.Errorf("Help message in %q contains a missing key", locale)
+		}
+
+		// 管理者以外には管理者用のコマンドを表示しない。DMではサーバー専用のコマンドを表示しない
+		if member := strings.Join(r.HelpMessages(locale, false, false), ""); strings.Contains(member, "**/admin**") {
+			t.Errorf("Help message in %q shows /admin to members", locale)
+		}
+		if dm := strings.Join(r.HelpMessages(locale, false, true), ""); strings.Contains(dm, commandLabel(locale, viewReservationsCommand())) {
+			t.Errorf("Help message in %q shows guild-only commands in DMs", locale)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(2, 10*time.Second)
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, time.UTC)
+
+	if !limiter.allow("u1", now) || !limiter.allow("u1", now.Add(time.Second)) {
+		t.Fatal("Expected the first two commands to be allowed")
+	}
+	if limiter.allow("u1", now.Add(2*time.Second)) {
+		t.Error("Expected the third command within the window to be rejected")
+	}
+	if !limiter.allow("u2", now.Add(2*time.Second)) {
+		t.Error("Expected other members not to be limited")
+	}
+	// 最初の実行から window が過ぎれば再び実行できる
+	if !limiter.allow("u1", now.Add(10*time.Second)) {
+		t.Error("Expected a command to be allowed after the window")
+	}
+}
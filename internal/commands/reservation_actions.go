@@ -0,0 +1,302 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// 予約メッセージに付けるボタンのアクション名
+// カスタムIDは「アクション名:予約ID」の形式（例: res_cancel:0123456789abcdef0123456789abcdef）
+const (
+	actionReservationCancel   = "res_cancel"
+	actionReservationComplete = "res_complete"
+	actionReservationEdit     = "res_edit"
+	actionReservationWatch    = "res_watch"
+	actionReservationCheckIn  = "res_checkin"
+	actionEditForm            = "edit_form"
+)
+
+// reservationActionComponents は予約メッセージに付けるボタンを作成する
+// 取り消し・完了・編集は予約者のみ、空き通知は予約者以外が押すことを想定している
+// 押したユーザーの権限はボタンの処理側でスラッシュコマンドと同じようにチェックする
+// 公開メッセージに付ける場合は locale に i18n.DefaultLocale() を渡す
+func reservationActionComponents(locale string, r *models.Reservation, includeWatch bool) []discordgo.MessageComponent {
+	buttons := []discordgo.MessageComponent{
+		discordgo.Button{
+			Label:    i18n.T(locale, "actions.cancel"),
+			Style:    discordgo.DangerButton,
+			CustomID: encodeCustomID(actionReservationCancel, r.ID),
+		},
+		discordgo.Button{
+			Label:    i18n.T(locale, "actions.complete"),
+			Style:    discordgo.PrimaryButton,
+			CustomID: encodeCustomID(actionReservationComplete, r.ID),
+		},
+		discordgo.Button{
+			Label:    i18n.T(locale, "actions.edit"),
+			Style:    discordgo.SecondaryButton,
+			CustomID: encodeCustomID(actionReservationEdit, r.ID),
+		},
+	}
+	if includeWatch {
+		buttons = append(buttons, discordgo.Button{
+			Label:    i18n.T(locale, "actions.watch"),
+			Style:    discordgo.SuccessButton,
+			CustomID: encodeCustomID(actionReservationWatch, r.ID),
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
+
+// handleReservationAction は予約メッセージのボタンを処理する
+func handleReservationAction(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, allowedChannelID string, isDM bool, action string, args []string) {
+	if len(args) != 1 || args[0] == "" {
+		respondError(s, i, tr(i, "reservation.invalid_args"))
+		return
+	}
+	reservationID := args[0]
+	userID, username := getUserInfo(i, isDM)
+
+	// スラッシュコマンドと同じ形式で実行ログを残す
+	command := map[string]string{
+		actionReservationCancel:   "cancel",
+		actionReservationComplete: "complete",
+		actionReservationEdit:     "edit",
+		actionReservationWatch:    "watch",
+		actionReservationCheckIn:  "checkin",
+	}[action]
+	logger.LogCommand(command, userID, username, i.ChannelID, true, "", map[string]interface{}{
+		"reservation_id": reservationID,
+		"via":            "button",
+	})
+
+	switch action {
+	case actionReservationCancel:
+		cancelReservation(s, i, store, logger, allowedChannelID, isDM, reservationID, "")
+	case actionReservationComplete:
+		completeReservation(s, i, store, logger, allowedChannelID, isDM, reservationID, "")
+	case actionReservationEdit:
+		openEditModal(s, i, store, reservationID, userID)
+	case actionReservationWatch:
+		watchReservation(s, i, store, logger, reservationID, userID)
+	case actionReservationCheckIn:
+		checkInReservation(s, i, store, logger, reservationID, userID)
+	}
+}
+
+// checkInWindow は予約の開始のどれだけ前からチェックインできるか
+const checkInWindow = time.Hour
+
+// checkInReservation はリマインダーの「チェックイン」ボタンから、予約者が部室に来たことを記録する
+// 確認と保存は1つのロックの中で行い、チェックイン済みの予約や終了した予約は変更しない
+func checkInReservation(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, reservationID, userID string) {
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found"))
+		return
+	}
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Now().In(jst)
+
+	var rejection error
+	updated, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		start, end, err := reservationPeriod(r, jst)
+		switch {
+		case r.UserID != userID:
+			rejection = newError("checkin.not_owner")
+		case r.Status != models.StatusPending:
+			rejection = newError("checkin.not_pending")
+		case r.CheckedInAt != nil:
+			rejection = newError("checkin.already")
+		case err != nil || !now.Before(end):
+			rejection = newError("checkin.ended")
+		case now.Before(start.Add(-checkInWindow)):
+			rejection = newError("checkin.too_early", int(checkInWindow/time.Minute))
+		}
+		if rejection != nil {
+			return rejection
+		}
+
+		checkedInAt := now
+		r.CheckedInAt = &checkedInAt
+		r.AddHistory("check_in", userID, "", "")
+		r.UpdatedAt = time.Now()
+		return nil
+	})
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	}
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.save_failed"))
+		logger.LogError("ERROR", "checkInReservation", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	respondEmbed(s, i, tr(i, "checkin.done"),
+		tr(i, "checkin.done_description", formatDate(updated.Date), updated.StartTime, updated.EndTime),
+		0x57F287, true)
+	disableSourceMessageButtons(s, i)
+}
+
+// openEditModal は予約の現在の値を入力済みにした編集フォームを開く
+func openEditModal(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, reservationID, userID string) {
+	reservation, err := store.GetReservation(reservationID)
+	if err != nil {
+		respondError(s, i, tr(i, "reservation.not_found"))
+		return
+	}
+	if reservation.UserID != userID {
+		respondError(s, i, tr(i, "edit.not_owner"))
+		return
+	}
+	if reservation.Status != models.StatusPending {
+		respondError(s, i, tr(i, "edit.not_pending"))
+		return
+	}
+
+	showEditModal(s, i, reservation.ID, reservationFormValues(reservation))
+}
+
+// editRequestFromModal は編集フォームの入力値のうち、現在の値から変わった項目だけを編集内容にする
+func editRequestFromModal(r *models.Reservation, values map[string]string) editRequest {
+	var req editRequest
+	if date := values["date"]; normalizeDate(date) != formatDate(r.Date) {
+		req.Date = &date
+	}
+	if startTime := values["start_time"]; normalizeTime(startTime) != r.StartTime {
+		req.StartTime = &startTime
+	}
+	if endTime := values["end_time"]; normalizeTime(endTime) != r.EndTime {
+		req.EndTime = &endTime
+	}
+	if comment := values["comment"]; comment != r.Comment {
+		req.Comment = &comment
+	}
+	return req
+}
+
+// watchReservation は予約が空いたときにDMで通知を受け取るよう登録する
+// 確認と保存は1つのロックの中で行う
+func watchReservation(s *discordgo.Session, i *discordgo.InteractionCreate, store *storage.Storage, logger *logging.Logger, reservationID, userID string) {
+	if _, err := store.GetReservation(reservationID); err != nil {
+		respondError(s, i, tr(i, "reservation.not_found"))
+		return
+	}
+
+	var rejection error
+	alreadyWatching := false
+	reservation, err := store.ModifyReservation(reservationID, func(r *models.Reservation, others []*models.Reservation) error {
+		switch {
+		case r.UserID == userID:
+			rejection = newError("watch.own_reservation")
+		case r.Status != models.StatusPending:
+			rejection = newError("watch.not_pending")
+		case !r.AddWatcher(userID):
+			alreadyWatching = true
+			rejection = newError("watch.already")
+		}
+		return rejection
+	})
+	if alreadyWatching {
+		respondEphemeral(s, i, tr(i, "watch.already"))
+		return
+	}
+	if rejection != nil {
+		respondError(s, i, localize(lang(i), rejection))
+		return
+	}
+	if err != nil {
+		respondError(s, i, tr(i, "watch.failed"))
+		logger.LogError("ERROR", "watchReservation", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": reservationID,
+		})
+		return
+	}
+
+	respondEmbed(s, i, tr(i, "watch.done"),
+		tr(i, "watch.done_description", formatDate(reservation.Date), reservation.StartTime, reservation.EndTime),
+		0x57F287, true)
+}
+
+// notifyWatchers は空き通知を登録しているユーザーに、指定の時間帯が空いたことをDMで知らせる
+// r は変更後の予約。通知は1回限りのため、送信後に通知したユーザーの登録を解除する（送信中に登録したユーザーは残す）
+func notifyWatchers(s *discordgo.Session, store *storage.Storage, logger *logging.Logger, r *models.Reservation, date, startTime, endTime string) {
+	if len(r.Watchers) == 0 {
+		return
+	}
+
+	for _, watcherID := range r.Watchers {
+		locale := userLocale(store, watcherID)
+		fields := []*discordgo.MessageEmbedField{
+			{
+				Name:   i18n.T(locale, "label.date"),
+				Value:  formatDate(date),
+				Inline: true,
+			},
+			{
+				Name:   i18n.T(locale, "label.time"),
+				Value:  fmt.Sprintf("%s - %s", startTime, endTime),
+				Inline: true,
+			},
+		}
+		err := sendDirectEmbed(s, watcherID, i18n.T(locale, "watch.notify"), i18n.T(locale, "watch.notify_description"), fields, 0x57F287, footer(locale, "watch"), nil)
+		if err != nil {
+			logger.LogError("WARN", "notifyWatchers", "Failed to send DM to watcher", err, map[string]interface{}{
+				"reservation_id": r.ID,
+				"user_id":        watcherID,
+			})
+		}
+	}
+
+	notified := r.Watchers
+	_, err := store.ModifyReservation(r.ID, func(current *models.Reservation, others []*models.Reservation) error {
+		remaining := make([]string, 0, len(current.Watchers))
+		for _, watcherID := range current.Watchers {
+			if !slices.Contains(notified, watcherID) {
+				remaining = append(remaining, watcherID)
+			}
+		}
+		current.Watchers = remaining
+		if len(remaining) == 0 {
+			current.Watchers = nil
+		}
+		return nil
+	})
+	if err != nil {
+		logger.LogError("ERROR", "notifyWatchers", "Failed to save reservations", err, map[string]interface{}{
+			"reservation_id": r.ID,
+		})
+	}
+}
+
+// disableSourceMessageButtons はボタンから操作した場合に、元の公開メッセージのボタンを外す
+// 取り消し・完了した予約のボタンが押され続けないようにするため。エフェメラルメッセージは編集できないため対象外
+func disableSourceMessageButtons(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionMessageComponent || i.Message == nil {
+		return
+	}
+	if i.Message.Flags&discordgo.MessageFlagsEphemeral != 0 {
+		return
+	}
+
+	// 埋め込みは省略すると消えてしまうため、元のものをそのまま送る
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         i.Message.ID,
+		Channel:    i.Message.ChannelID,
+		Components: []discordgo.MessageComponent{},
+		Embeds:     i.Message.Embeds,
+	})
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+func TestReservationActionComponents(t *testing.T) {
+	r := &models.Reservation{ID: "0123456789abcdef0123456789abcdef"}
+
+	row := reservationActionComponents("ja", r, true)[0].(discordgo.ActionsRow)
+	if len(row.Components) != 4 {
+		t.Fatalf("Expected 4 buttons, got %d", len(row.Components))
+	}
+
+	for _, component := range row.Components {
+		button := component.(discordgo.Button)
+		if len(button.CustomID) > 100 {
+			t.Errorf("Custom ID exceeds Discord's 100 character limit: %s", button.CustomID)
+		}
+		_, args := decodeCustomID(button.CustomID)
+		if len(args) != 1 || args[0] != r.ID {
+			t.Errorf("Expected reservation ID %s in %s", r.ID, button.CustomID)
+		}
+	}
+
+	// 予約者向けの確認メッセージには空き通知ボタンを付けない
+	row = reservationActionComponents("ja", r, false)[0].(discordgo.ActionsRow)
+	if len(row.Components) != 3 {
+		t.Errorf("Expected 3 buttons without watch, got %d", len(row.Components))
+	}
+}
+
+func TestEditRequestFromModal(t *testing.T) {
+	r := &models.Reservation{
+		Date:      "2025-11-01",
+		StartTime: "09:00",
+		EndTime:   "10:00",
+		Comment:   "ミーティング",
+	}
+
+	// 表記揺れのみの場合は変更なし
+	req := editRequestFromModal(r, map[string]string{
+		"date":       "2025/11/1",
+		"start_time": "9:00",
+		"end_time":   "10:00",
+		"comment":    "ミーティング",
+	})
+	if req.Date != nil || req.StartTime != nil || req.EndTime != nil || req.Comment != nil {
+		t.Errorf("Expected no changes, got %+v", req)
+	}
+
+	req = editRequestFromModal(r, map[string]string{
+		"date":       "2025/11/01",
+		"start_time": "09:00",
+		"end_time":   "11:00",
+		"comment":    "",
+	})
+	if req.EndTime == nil || *req.EndTime != "11:00" {
+		t.Errorf("Expected end time change, got %+v", req.EndTime)
+	}
+	if req.Comment == nil || *req.Comment != "" {
+		t.Errorf("Expected comment to be cleared, got %+v", req.Comment)
+	}
+	if req.Date != nil || req.StartTime != nil {
+		t.Errorf("Expected date and start time unchanged, got %+v", req)
+	}
+}
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+)
+
+// autoDeferAfter は応答がない場合に自動で遅延応答（「考え中…」）を送るまでの時間（応答期限は3秒）
+const autoDeferAfter = 2 * time.Second
+
+// responseState はインタラクションへの応答の状態を表す
+type responseState int
+
+const (
+	responsePending  responseState = iota // まだ応答していない
+	responseDeferred                      // 遅延応答済み（次の応答で元の応答を編集する）
+	responseSent                          // 応答済み（以降の応答はフォローアップメッセージで送る）
+)
+
+// responder は1つのインタラクションへの応答の状態を管理する
+// 処理に時間がかかった場合は自動で遅延応答を送り、後から元の応答を編集する。送信に失敗した場合はインタラクションIDとともに記録する
+type responder struct {
+	mu          sync.Mutex
+	session     *discordgo.Session
+	interaction *discordgo.Interaction
+	logger      *logging.Logger
+	source      string // ログに記録するコマンド名またはカスタムID
+	state       responseState
+	deferType   discordgo.InteractionResponseType // 遅延応答の種類
+	ephemeral   bool                              // 遅延応答を自分だけに表示するか
+	timer       *time.Timer
+	done        bool
+}
+
+// responders は処理中のインタラクションの responder（キーはインタラクションID）
+var responders sync.Map
+
+// trackResponse はインタラクションへの応答の追跡を始め、処理の終了時に呼ぶ関数を返す
+// autoDefer が true の場合、autoDeferAfter までに応答しなければ遅延応答を送る（モーダルを開く処理では false にする）
+// ボタンなどのコンポーネントはメッセージの更新として、それ以外は自分だけに表示するメッセージとして遅延応答する
+func trackResponse(s *discordgo.Session, i *discordgo.InteractionCreate, logger *logging.Logger, autoDefer bool) func() {
+	r := &responder{
+		session:     s,
+		interaction: i.Interaction,
+		logger:      logger,
+		source:      interactionSource(i),
+		deferType:   discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		ephemeral:   true,
+	}
+	if i.Type == discordgo.InteractionMessageComponent {
+		r.deferType = discordgo.InteractionResponseDeferredMessageUpdate
+	}
+	if autoDefer {
+		r.timer = time.AfterFunc(autoDeferAfter, r.autoDefer)
+	}
+	responders.Store(i.ID, r)
+
+	return func() {
+		responders.Delete(i.ID)
+		r.finish()
+	}
+}
+
+// interactionSource はログに記録するインタラクションの種類（コマンド名またはカスタムID）を返す
+func interactionSource(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		return i.ApplicationCommandData().Name
+	case discordgo.InteractionMessageComponent:
+		return i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return i.ModalSubmitData().CustomID
+	}
+	return ""
+}
+
+// respond はインタラクションに応答する
+// 遅延応答済みの場合は元の応答を編集し、応答済みの場合はフォローアップメッセージとして送る
+func respond(s *discordgo.Session, i *discordgo.InteractionCreate, resp *discordgo.InteractionResponse) error {
+	if v, ok := responders.Load(i.ID); ok {
+		return v.(*responder).respond(resp)
+	}
+	return s.InteractionRespond(i.Interaction, resp)
+}
+
+// deferResponse は時間のかかる処理の前に遅延応答を送る（応答済みの場合は何もしない）
+// ephemeral は後から送る応答を自分だけに表示するかどうか
+func deferResponse(s *discordgo.Session, i *discordgo.InteractionCreate, ephemeral bool) error {
+	if v, ok := responders.Load(i.ID); ok {
+		r := v.(*responder)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.state != responsePending {
+			return nil
+		}
+		r.deferType = discordgo.InteractionResponseDeferredChannelMessageWithSource
+		r.ephemeral = ephemeral
+		return r.deferLocked()
+	}
+
+	resp := &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredChannelMessageWithSource}
+	if ephemeral {
+		resp.Data = &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral}
+	}
+	return s.InteractionRespond(i.Interaction, resp)
+}
+
+func (r *responder) respond(resp *discordgo.InteractionResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopTimer()
+
+	var err error
+	switch r.state {
+	case responsePending:
+		err = r.session.InteractionRespond(r.interaction, resp)
+	case responseDeferred:
+		err = r.deliverDeferred(resp)
+	default:
+		err = r.followup(resp)
+	}
+	if err != nil {
+		r.logFailure("ERROR", "Failed to deliver interaction response", err, resp.Type)
+		return err
+	}
+	r.state = responseSent
+	return nil
+}
+
+// deliverDeferred は遅延応答の後に、元の応答の編集またはフォローアップメッセージで応答を届ける
+func (r *responder) deliverDeferred(resp *discordgo.InteractionResponse) error {
+	data := responseData(resp)
+	ephemeral := data.Flags&discordgo.MessageFlagsEphemeral != 0
+
+	switch {
+	case resp.Type == discordgo.InteractionResponseUpdateMessage && r.deferType == discordgo.InteractionResponseDeferredMessageUpdate,
+		resp.Type == discordgo.InteractionResponseChannelMessageWithSource && r.deferType == discordgo.InteractionResponseDeferredChannelMessageWithSource && ephemeral == r.ephemeral:
+		_, err := r.session.InteractionResponseEdit(r.interaction, webhookEdit(data))
+		return err
+	case resp.Type == discordgo.InteractionResponseChannelMessageWithSource, resp.Type == discordgo.InteractionResponseUpdateMessage:
+		// 表示範囲が遅延応答と異なる場合は「考え中…」を消して、フォローアップメッセージで送る
+		if r.deferType == discordgo.InteractionResponseDeferredChannelMessageWithSource {
+			if err := r.session.InteractionResponseDelete(r.interaction); err != nil {
+				r.logFailure("ERROR", "Failed to delete deferred response", err, resp.Type)
+			}
+		}
+		return r.followup(resp)
+	default:
+		// モーダルなどは遅延応答の後には送れない
+		return fmt.Errorf("response type %d cannot be sent after a deferred response", resp.Type)
+	}
+}
+
+// followup は応答をフォローアップメッセージとして送る
+func (r *responder) followup(resp *discordgo.InteractionResponse) error {
+	data := responseData(resp)
+	_, err := r.session.FollowupMessageCreate(r.interaction, true, &discordgo.WebhookParams{
+		Content:         data.Content,
+		Embeds:          data.Embeds,
+		Components:      data.Components,
+		Files:           data.Files,
+		AllowedMentions: data.AllowedMentions,
+		Flags:           data.Flags,
+	})
+	return err
+}
+
+// autoDefer は autoDeferAfter までに応答がなかった場合に遅延応答を送る
+func (r *responder) autoDefer() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done || r.state != responsePending {
+		return
+	}
+	r.deferLocked()
+}
+
+// deferLocked は遅延応答を送る（r.mu をロックして呼ぶこと）
+func (r *responder) deferLocked() error {
+	resp := &discordgo.InteractionResponse{Type: r.deferType}
+	if r.deferType == discordgo.InteractionResponseDeferredChannelMessageWithSource && r.ephemeral {
+		resp.Data = &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral}
+	}
+	if err := r.session.InteractionRespond(r.interaction, resp); err != nil {
+		r.logFailure("ERROR", "Failed to defer interaction response", err, resp.Type)
+		return err
+	}
+	r.state = responseDeferred
+	return nil
+}
+
+// finish は処理の終了時に呼ばれ、遅延応答のまま応答しなかった場合を記録する
+func (r *responder) finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopTimer()
+	r.done = true
+	if r.state == responseDeferred && r.deferType == discordgo.InteractionResponseDeferredChannelMessageWithSource {
+		r.logFailure("WARN", "Deferred response was never completed", nil, r.deferType)
+	}
+}
+
+func (r *responder) stopTimer() {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+}
+
+// logFailure は応答の送信の失敗などをインタラクションIDとともに記録する
+func (r *responder) logFailure(level, message string, err error, responseType discordgo.InteractionResponseType) {
+	if r.logger == nil {
+		return
+	}
+	r.logger.LogError(level, "respond", message, err, map[string]interface{}{
+		"interaction_id": r.interaction.ID,
+		"source":         r.source,
+		"response_type":  int(responseType),
+	})
+}
+
+// responseData は応答の内容を返す（内容のない応答の場合は空の内容）
+func responseData(resp *discordgo.InteractionResponse) *discordgo.InteractionResponseData {
+	if resp.Data == nil {
+		return &discordgo.InteractionResponseData{}
+	}
+	return resp.Data
+}
+
+// webhookEdit は応答の内容を元の応答の編集内容にする（指定のない項目は空にする）
+func webhookEdit(data *discordgo.InteractionResponseData) *discordgo.WebhookEdit {
+	content := data.Content
+	embeds := data.Embeds
+	if embeds == nil {
+		embeds = []*discordgo.MessageEmbed{}
+	}
+	components := data.Components
+	if components == nil {
+		components = []discordgo.MessageComponent{}
+	}
+	return &discordgo.WebhookEdit{
+		Content:         &content,
+		Embeds:          &embeds,
+		Components:      &components,
+		Files:           data.Files,
+		AllowedMentions: data.AllowedMentions,
+	}
+}
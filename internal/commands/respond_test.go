@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// recordingTransport はDiscord APIへのリクエストを記録し、空の成功レスポンスを返す
+type recordingTransport struct {
+	mu           sync.Mutex
+	requests     []string
+	contentTypes []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req.Method+" "+req.URL.Path)
+	t.contentTypes = append(t.contentTypes, req.Header.Get("Content-Type"))
+	t.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func (t *recordingTransport) paths() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.requests...)
+}
+
+// contentType は idx 番目のリクエストの Content-Type を返す（ファイルを添付した場合は multipart/form-data）
+func (t *recordingTransport) contentType(idx int) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.contentTypes[idx]
+}
+
+func newRecordingSession(t *testing.T) (*discordgo.Session, *recordingTransport) {
+	t.Helper()
+	s, err := discordgo.New("Bot token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &recordingTransport{}
+	s.Client = &http.Client{Transport: transport}
+	return s, transport
+}
+
+func testInteraction(interactionType discordgo.InteractionType) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:    "i1",
+		AppID: "app",
+		Token: "token",
+		Type:  interactionType,
+		Data:  discordgo.ApplicationCommandInteractionData{Name: "reserve"},
+	}}
+}
+
+func ephemeralMessage(content string) *discordgo.InteractionResponse {
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: content, Flags: discordgo.MessageFlagsEphemeral},
+	}
+}
+
+func TestRespondWithoutDefer(t *testing.T) {
+	s, transport := newRecordingSession(t)
+	i := testInteraction(discordgo.InteractionApplicationCommand)
+	done := trackResponse(s, i, nil, false)
+	defer done()
+
+	// 最初の応答は通常の応答、2件目以降はフォローアップメッセージ
+	respond(s, i, ephemeralMessage("first"))
+	respond(s, i, ephemeralMessage("second"))
+
+	want := []string{
+		"POST /api/v9/interactions/i1/token/callback",
+		"POST /api/v9/webhooks/app/token",
+	}
+	if got := transport.paths(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRespondAfterAutoDefer(t *testing.T) {
+	s, transport := newRecordingSession(t)
+	i := testInteraction(discordgo.InteractionApplicationCommand)
+	done := trackResponse(s, i, nil, false)
+	defer done()
+
+	// 応答期限が近づいたら遅延応答し、後から元の応答を編集する
+	v, _ := responders.Load(i.ID)
+	v.(*responder).autoDefer()
+	respond(s, i, ephemeralMessage("result"))
+
+	want := []string{
+		"POST /api/v9/interactions/i1/token/callback",
+		"PATCH /api/v9/webhooks/app/token/messages/@original",
+	}
+	if got := transport.paths(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRespondAfterDeferWithDifferentVisibility(t *testing.T) {
+	s, transport := newRecordingSession(t)
+	i := testInteraction(discordgo.InteractionApplicationCommand)
+	done := trackResponse(s, i, nil, false)
+	defer done()
+
+	// 自分だけに表示する遅延応答の後に公開の応答を送る場合は、遅延応答を消してフォローアップで送る
+	deferResponse(s, i, true)
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: "public"},
+	})
+
+	want := []string{
+		"POST /api/v9/interactions/i1/token/callback",
+		"DELETE /api/v9/webhooks/app/token/messages/@original",
+		"POST /api/v9/webhooks/app/token",
+	}
+	if got := transport.paths(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRespondComponentAfterAutoDefer(t *testing.T) {
+	s, transport := newRecordingSession(t)
+	i := testInteraction(discordgo.InteractionMessageComponent)
+	i.Data = discordgo.MessageComponentInteractionData{CustomID: "list_page:all:2"}
+	done := trackResponse(s, i, nil, false)
+	defer done()
+
+	// ボタンはメッセージの更新として遅延応答し、更新内容で元のメッセージを編集する
+	v, _ := responders.Load(i.ID)
+	v.(*responder).autoDefer()
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{Content: "page 2"},
+	})
+
+	want := []string{
+		"POST /api/v9/interactions/i1/token/callback",
+		"PATCH /api/v9/webhooks/app/token/messages/@original",
+	}
+	if got := transport.paths(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+	if v.(*responder).deferType != discordgo.InteractionResponseDeferredMessageUpdate {
+		t.Errorf("Expected a deferred message update, got %v", v.(*responder).deferType)
+	}
+}
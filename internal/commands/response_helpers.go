@@ -6,17 +6,19 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/models"
 )
 
 // respondError はエラーメッセージを送信する
 func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, message string) {
 	embed := &discordgo.MessageEmbed{
-		Title:       "🔴 エラー",
+		Title:       tr(i, "common.error"),
 		Description: message,
 		Color:       0xED4245, // Discord Red
 		Timestamp:   time.Now().Format(time.RFC3339),
 	}
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Embeds: []*discordgo.MessageEmbed{embed},
@@ -27,7 +29,7 @@ func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, message
 
 // respondEphemeral はエフェメラルメッセージを送信する
 func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, message string) {
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Content: message,
@@ -48,7 +50,7 @@ func respondEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, title st
 	if ephemeral {
 		flags = discordgo.MessageFlagsEphemeral
 	}
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Embeds: []*discordgo.MessageEmbed{embed},
@@ -73,7 +75,7 @@ func respondEmbedWithFooter(s *discordgo.Session, i *discordgo.InteractionCreate
 	if ephemeral {
 		flags = discordgo.MessageFlagsEphemeral
 	}
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	respond(s, i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Embeds: []*discordgo.MessageEmbed{embed},
@@ -82,6 +84,41 @@ func respondEmbedWithFooter(s *discordgo.Session, i *discordgo.InteractionCreate
 	})
 }
 
+// respondEmbedWithComponents は埋め込みメッセージをフッターとボタンなどのコンポーネント付きで送信する
+func respondEmbedWithComponents(s *discordgo.Session, i *discordgo.InteractionCreate, title string, description string, fields []*discordgo.MessageEmbedField, color int, footerText string, components []discordgo.MessageComponent, ephemeral bool) {
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Fields:      fields,
+		Color:       color,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: footerText,
+		},
+	}
+	var flags discordgo.MessageFlags
+	if ephemeral {
+		flags = discordgo.MessageFlagsEphemeral
+	}
+	respond(s, i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+			Flags:      flags,
+		},
+	})
+}
+
+// sendDirectEmbed はユーザーにDMで埋め込みメッセージを送信する
+func sendDirectEmbed(s *discordgo.Session, userID string, title string, description string, fields []*discordgo.MessageEmbedField, color int, footerText string, components []discordgo.MessageComponent) error {
+	channel, err := s.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+	return sendChannelEmbedWithComponents(s, channel.ID, title, description, fields, color, footerText, components)
+}
+
 // getDisplayName はメンバーの表示名を取得する
 func getDisplayName(member *discordgo.Member) string {
 	if member.Nick != "" {
@@ -159,8 +196,27 @@ func formatDate(date string) string {
 	return fmt.Sprintf("%s/%s/%s", year, month, day)
 }
 
+// formatDuration は時間の長さを「1時間30分」（英語では「1h 30m」）のような形式にフォーマットする
+func formatDuration(locale string, d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case hours > 0 && minutes > 0:
+		return i18n.T(locale, "duration.hours_minutes", hours, minutes)
+	case hours > 0:
+		return i18n.T(locale, "duration.hours", hours)
+	default:
+		return i18n.T(locale, "duration.minutes", minutes)
+	}
+}
+
 // sendChannelEmbed はチャンネルに埋め込みメッセージを送信する
 func sendChannelEmbed(s *discordgo.Session, channelID string, title string, description string, fields []*discordgo.MessageEmbedField, color int, footerText string) error {
+	return sendChannelEmbedWithComponents(s, channelID, title, description, fields, color, footerText, nil)
+}
+
+// sendChannelEmbedWithComponents はチャンネルにボタンなどのコンポーネント付きで埋め込みメッセージを送信する
+func sendChannelEmbedWithComponents(s *discordgo.Session, channelID string, title string, description string, fields []*discordgo.MessageEmbedField, color int, footerText string, components []discordgo.MessageComponent) error {
 	embed := &discordgo.MessageEmbed{
 		Title:       title,
 		Description: description,
@@ -171,7 +227,10 @@ func sendChannelEmbed(s *discordgo.Session, channelID string, title string, desc
 			Text: footerText,
 		},
 	}
-	_, err := s.ChannelMessageSendEmbed(channelID, embed)
+	_, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	})
 	return err
 }
 
@@ -200,3 +259,40 @@ func createHeaderEmbed(title string, description string, color int, footerText s
 		},
 	}
 }
+
+// reservationDetailFields は予約の日付・時間・コメント（ある場合のみ）の埋め込みフィールドを作成する
+func reservationDetailFields(locale string, r *models.Reservation) []*discordgo.MessageEmbedField {
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(locale, "label.date"),
+			Value:  formatDate(r.Date),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "label.time"),
+			Value:  fmt.Sprintf("%s - %s", r.StartTime, r.EndTime),
+			Inline: true,
+		},
+	}
+	if r.Comment != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   i18n.T(locale, "label.comment"),
+			Value:  r.Comment,
+			Inline: false,
+		})
+	}
+	return fields
+}
+
+// respondFieldErrors は入力項目ごとの検証エラーを1つの埋め込みメッセージで送信する（自分だけに表示される）
+func respondFieldErrors(s *discordgo.Session, i *discordgo.InteractionCreate, title string, errs fieldErrors, footerText string, components []discordgo.MessageComponent) {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(errs))
+	for _, e := range errs {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "❌ " + tr(i, "field."+e.Field),
+			Value:  localize(lang(i), e.Err),
+			Inline: false,
+		})
+	}
+	respondEmbedWithComponents(s, i, title, tr(i, "common.check_input"), fields, 0xED4245, footerText, components, true)
+}
@@ -0,0 +1,400 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+const (
+	defaultReminderMinutes  = 15               // REMINDER_MINUTES が未設定の場合のリマインダーの送信時刻（開始の何分前か）
+	defaultEndPromptMinutes = 10               // END_PROMPT_MINUTES が未設定の場合の延長・終了の確認の送信時刻（終了の何分前か）
+	endAnnounceGrace        = 15 * time.Minute // 終了時刻からこの時間を過ぎた予約は、完了にしても空いたことを知らせない（停止中に終わった予約など）
+	schedulerIdleWait       = time.Hour        // 予定された通知がない場合に予約を確認し直す間隔
+)
+
+// sessionConfig は予約の開始・終了に合わせた通知の設定
+type sessionConfig struct {
+	ReminderBefore  time.Duration // 開始のどれだけ前にリマインダーを送るか（0 の場合は送らない、REMINDER_MINUTES）
+	ReminderPing    bool          // リマインダーをチャンネルでもメンションして知らせるか（REMINDER_CHANNEL_PING）
+	EndPromptBefore time.Duration // 終了のどれだけ前に延長・終了の確認を送るか（0 の場合は送らない、END_PROMPT_MINUTES）
+}
+
+// currentSessionConfig は環境変数から通知の設定を読み込む
+// REMINDER_MINUTES・END_PROMPT_MINUTES が未設定または不正な値の場合は既定値、0 の場合は送らない
+func currentSessionConfig() sessionConfig {
+	return sessionConfig{
+		ReminderBefore:  minutesFromEnv("REMINDER_MINUTES", defaultReminderMinutes),
+		ReminderPing:    os.Getenv("REMINDER_CHANNEL_PING") == "true",
+		EndPromptBefore: minutesFromEnv("END_PROMPT_MINUTES", defaultEndPromptMinutes),
+	}
+}
+
+// minutesFromEnv は環境変数の分数を読み込む（未設定または不正な値の場合は defaultMinutes）
+func minutesFromEnv(key string, defaultMinutes int) time.Duration {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n >= 0 {
+		return time.Duration(n) * time.Minute
+	}
+	return time.Duration(defaultMinutes) * time.Minute
+}
+
+// sessionEventKind は予約に合わせて行う通知の種類
+type sessionEventKind int
+
+const (
+	eventReminder   sessionEventKind = iota // 開始前のリマインダー
+	eventEndPrompt                          // 終了前の延長・終了の確認
+	eventSessionEnd                         // 終了時刻に完了にして、部室が空いたことを知らせる
+)
+
+// sessionEvent は予約に合わせて行う通知1件
+type sessionEvent struct {
+	kind        sessionEventKind
+	at          time.Time // 通知する時刻
+	reservation *models.Reservation
+}
+
+// errEventHandled は通知が既に行われたか、不要になったことを表す
+var errEventHandled = errors.New("event already handled")
+
+// SessionScheduler は予約の開始前のリマインダーと終了前の延長・終了の確認を送り、終了時刻に予約を完了にする
+// 送信済みかどうかは予約に保存し、次の通知の時刻は保存された予約から毎回計算し直すため、
+// 予約の変更や取り消しはそのまま反映され、Botの停止中に送れなかった通知は起動後に送る
+type SessionScheduler struct {
+	session   *discordgo.Session
+	store     *storage.Storage
+	logger    *logging.Logger
+	channelID string
+	config    sessionConfig
+	notify    chan struct{}
+}
+
+// NewSessionScheduler は通知の設定を環境変数から読み込んで作成する
+// channelID はリマインダーをチャンネルでも知らせる場合と、予約の終了後に部室が空いたことを知らせる送信先
+func NewSessionScheduler(s *discordgo.Session, store *storage.Storage, logger *logging.Logger, channelID string) *SessionScheduler {
+	return &SessionScheduler{
+		session:   s,
+		store:     store,
+		logger:    logger,
+		channelID: channelID,
+		config:    currentSessionConfig(),
+		notify:    make(chan struct{}, 1),
+	}
+}
+
+// Notify は予約が変更されたことを知らせ、次の通知の時刻を計算し直させる（storage.OnChange に登録する）
+func (sc *SessionScheduler) Notify() {
+	select {
+	case sc.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run は時刻になった通知を送り、次の通知の時刻か予約が変更されるまで待つことを繰り返す（戻らない）
+func (sc *SessionScheduler) Run() {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	for {
+		now := time.Now().In(jst)
+		wait := schedulerIdleWait
+		for _, event := range sessionEvents(sc.store.GetAllReservations(), sc.config, now) {
+			if event.at.After(now) {
+				wait = event.at.Sub(now)
+				break
+			}
+			sc.handle(event, now)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-sc.notify:
+			timer.Stop()
+		}
+	}
+}
+
+// sessionEvents は予約から、まだ行っていない通知を時刻順に返す（now は日本時間の現在時刻）
+// 時刻を過ぎた通知も、予約が終わるまでは返す（停止中に送れなかった通知を起動後に送るため）
+// 終了時刻を過ぎた予約中の予約は、完了にするために必ず返す
+func sessionEvents(reservations []*models.Reservation, config sessionConfig, now time.Time) []sessionEvent {
+	var events []sessionEvent
+	for _, r := range reservations {
+		if r.Status != models.StatusPending {
+			continue
+		}
+		start, end, err := reservationPeriod(r, now.Location())
+		if err != nil {
+			continue
+		}
+		events = append(events, sessionEvent{kind: eventSessionEnd, at: end, reservation: r})
+		if !now.Before(end) {
+			continue
+		}
+
+		// リマインダーは、リマインダーの時刻より後に作られた予約とチェックイン済みの予約には送らない
+		if config.ReminderBefore > 0 && r.ReminderSentFor != r.StartKey() && r.CheckedInAt == nil {
+			at := start.Add(-config.ReminderBefore)
+			if !r.CreatedAt.After(at) {
+				events = append(events, sessionEvent{kind: eventReminder, at: at, reservation: r})
+			}
+		}
+
+		// 延長・終了の確認は、確認の時刻より後に作られた予約には送らない（開始前に送らないよう、開始時刻より後に限る）
+		if config.EndPromptBefore > 0 && r.EndPromptSentFor != r.EndKey() {
+			at := end.Add(-config.EndPromptBefore)
+			if at.After(start) && !r.CreatedAt.After(at) {
+				events = append(events, sessionEvent{kind: eventEndPrompt, at: at, reservation: r})
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[a].at.Before(events[b].at)
+	})
+	return events
+}
+
+// reservationPeriod は予約の開始・終了日時を loc のタイムゾーンで返す
+func reservationPeriod(r *models.Reservation, loc *time.Location) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02 15:04", r.Date+" "+r.StartTime, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := time.ParseInLocation("2006-01-02 15:04", r.Date+" "+r.EndTime, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// handle は通知を送る。二重に送らないよう、送信前に送信済みとして予約に保存する
+func (sc *SessionScheduler) handle(event sessionEvent, now time.Time) {
+	switch event.kind {
+	case eventReminder:
+		sc.sendReminder(event.reservation, now)
+	case eventEndPrompt:
+		sc.sendEndPrompt(event.reservation, now)
+	case eventSessionEnd:
+		sc.endSession(event.reservation, now)
+	}
+}
+
+// sendEndPrompt は終了前に、延長・終了ボタン付きの確認を予約者にDMで送る
+func (sc *SessionScheduler) sendEndPrompt(r *models.Reservation, now time.Time) {
+	endKey := r.EndKey()
+	updated, err := sc.store.ModifyReservation(r.ID, func(res *models.Reservation, others []*models.Reservation) error {
+		if res.Status != models.StatusPending || res.EndKey() != endKey || res.EndPromptSentFor == endKey {
+			return errEventHandled
+		}
+		res.EndPromptSentFor = endKey
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errEventHandled) {
+			sc.logger.LogError("ERROR", "SessionScheduler", "Failed to save end prompt state", err, map[string]interface{}{
+				"reservation_id": r.ID,
+			})
+		}
+		return
+	}
+
+	locale := userLocale(sc.store, updated.UserID)
+	_, end, _ := reservationPeriod(updated, now.Location())
+	minutes := int((end.Sub(now) + time.Minute - 1) / time.Minute)
+	description := i18n.T(locale, "end_prompt.description", minutes, updated.EndTime) + "\n" + i18n.T(locale, "end_prompt.hint")
+	err = sendDirectEmbed(sc.session, updated.UserID, i18n.T(locale, "end_prompt.title"), description,
+		reminderFields(locale, updated), 0xFEE75C, footer(locale, "extend"), sessionEndComponents(locale, updated, sc.store.GetAllReservations(), now))
+	if err != nil {
+		sc.logger.LogError("WARN", "SessionScheduler", "Failed to send end prompt DM", err, map[string]interface{}{
+			"reservation_id": updated.ID,
+			"user_id":        updated.UserID,
+		})
+	}
+}
+
+// endSession は終了時刻になった予約を完了にし、部室が空いた場合はチャンネルに知らせる
+// 停止中に終わった予約（終了から endAnnounceGrace 以上経過）や、続けて次の予約がある場合・閉室時刻に終わる場合は知らせない
+func (sc *SessionScheduler) endSession(r *models.Reservation, now time.Time) {
+	endKey := r.EndKey()
+	updated, err := sc.store.ModifyReservation(r.ID, func(res *models.Reservation, others []*models.Reservation) error {
+		if res.Status != models.StatusPending || res.EndKey() != endKey {
+			return errEventHandled
+		}
+		res.Status = models.StatusCompleted
+		res.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errEventHandled) {
+			sc.logger.LogError("ERROR", "SessionScheduler", "Failed to complete reservation", err, map[string]interface{}{
+				"reservation_id": r.ID,
+			})
+		}
+		return
+	}
+	if UpdateStatusCallback != nil {
+		UpdateStatusCallback()
+	}
+
+	_, end, _ := reservationPeriod(updated, now.Location())
+	if sc.channelID == "" || now.Sub(end) > endAnnounceGrace {
+		return
+	}
+	pub := i18n.DefaultLocale()
+	description, free := roomFreeDescription(pub, sc.store.GetAllReservations(), updated)
+	if !free {
+		return
+	}
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(pub, "label.user"),
+			Value:  fmt.Sprintf("<@%s>", updated.UserID),
+			Inline: false,
+		},
+		{
+			Name:   i18n.T(pub, "label.time"),
+			Value:  fmt.Sprintf("%s - %s", updated.StartTime, updated.EndTime),
+			Inline: true,
+		},
+	}
+	if err := sendChannelEmbed(sc.session, sc.channelID, i18n.T(pub, "session_end.announce"), description, fields, 0x57F287, footer(pub, "session")); err != nil {
+		sc.logger.LogError("WARN", "SessionScheduler", "Failed to announce the end of a reservation", err, map[string]interface{}{
+			"reservation_id": updated.ID,
+			"channel_id":     sc.channelID,
+		})
+	}
+}
+
+// roomFreeDescription は予約 r の終了後に部室が空くかどうかと、次の予約を知らせる本文を返す
+// 終了時刻に次の予約が始まる場合と、閉室時刻に終わる場合は空かない（free が false）
+func roomFreeDescription(locale string, reservations []*models.Reservation, r *models.Reservation) (description string, free bool) {
+	if r.EndTime >= schedule.ClosingTime {
+		return "", false
+	}
+	for _, next := range schedule.ActiveReservationsOn(reservations, r.Date) {
+		if next.ID == r.ID || next.StartTime < r.EndTime {
+			continue
+		}
+		if next.StartTime == r.EndTime {
+			return "", false
+		}
+		return i18n.T(locale, "session_end.free_until", next.StartTime), true
+	}
+	return i18n.T(locale, "session_end.free_rest_of_day"), true
+}
+
+// sendReminder は開始前のリマインダーを予約者にDMで送り、設定されていればチャンネルでもメンションする
+func (sc *SessionScheduler) sendReminder(r *models.Reservation, now time.Time) {
+	startKey := r.StartKey()
+	updated, err := sc.store.ModifyReservation(r.ID, func(res *models.Reservation, others []*models.Reservation) error {
+		if res.Status != models.StatusPending || res.StartKey() != startKey || res.ReminderSentFor == startKey {
+			return errEventHandled
+		}
+		res.ReminderSentFor = startKey
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, errEventHandled) {
+			sc.logger.LogError("ERROR", "SessionScheduler", "Failed to save reminder state", err, map[string]interface{}{
+				"reservation_id": r.ID,
+			})
+		}
+		return
+	}
+
+	locale := userLocale(sc.store, updated.UserID)
+	err = sendDirectEmbed(sc.session, updated.UserID, i18n.T(locale, "reminder.title"), reminderDescription(locale, updated, now),
+		reminderFields(locale, updated), 0x5865F2, footer(locale, "reminder"), reminderComponents(locale, updated))
+	if err != nil {
+		sc.logger.LogError("WARN", "SessionScheduler", "Failed to send reminder DM", err, map[string]interface{}{
+			"reservation_id": updated.ID,
+			"user_id":        updated.UserID,
+		})
+	}
+
+	if !sc.config.ReminderPing || sc.channelID == "" {
+		return
+	}
+	pub := i18n.DefaultLocale()
+	_, err = sc.session.ChannelMessageSendComplex(sc.channelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("<@%s>", updated.UserID),
+		Embeds: []*discordgo.MessageEmbed{{
+			Title:       i18n.T(pub, "reminder.title"),
+			Description: reminderDescription(pub, updated, now),
+			Fields:      reminderFields(pub, updated),
+			Color:       0x5865F2,
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Footer:      &discordgo.MessageEmbedFooter{Text: footer(pub, "reminder")},
+		}},
+		Components:      reminderComponents(pub, updated),
+		AllowedMentions: &discordgo.MessageAllowedMentions{Users: []string{updated.UserID}},
+	})
+	if err != nil {
+		sc.logger.LogError("WARN", "SessionScheduler", "Failed to send reminder to channel", err, map[string]interface{}{
+			"reservation_id": updated.ID,
+			"channel_id":     sc.channelID,
+		})
+	}
+}
+
+// reminderDescription はリマインダーの本文を返す（開始後に送る場合は開始済みであることを伝える）
+func reminderDescription(locale string, r *models.Reservation, now time.Time) string {
+	start, _, err := reservationPeriod(r, now.Location())
+	if err == nil && now.Before(start) {
+		minutes := int((start.Sub(now) + time.Minute - 1) / time.Minute)
+		return i18n.T(locale, "reminder.starts_in", minutes) + "\n" + i18n.T(locale, "reminder.hint")
+	}
+	return i18n.T(locale, "reminder.started", r.StartTime) + "\n" + i18n.T(locale, "reminder.hint")
+}
+
+func reminderFields(locale string, r *models.Reservation) []*discordgo.MessageEmbedField {
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(locale, "label.date"),
+			Value:  formatDate(r.Date),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "label.time"),
+			Value:  fmt.Sprintf("%s - %s", r.StartTime, r.EndTime),
+			Inline: true,
+		},
+	}
+	if r.Comment != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "label.comment"),
+			Value: r.Comment,
+		})
+	}
+	return fields
+}
+
+// reminderComponents はリマインダーに付ける「チェックイン」「取り消す」ボタンを作成する
+func reminderComponents(locale string, r *models.Reservation) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(locale, "actions.check_in"),
+					Style:    discordgo.SuccessButton,
+					CustomID: encodeCustomID(actionReservationCheckIn, r.ID),
+				},
+				discordgo.Button{
+					Label:    i18n.T(locale, "actions.cancel"),
+					Style:    discordgo.DangerButton,
+					CustomID: encodeCustomID(actionReservationCancel, r.ID),
+				},
+			},
+		},
+	}
+}
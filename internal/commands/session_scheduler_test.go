@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+func TestCurrentSessionConfig(t *testing.T) {
+	t.Setenv("REMINDER_MINUTES", "30")
+	t.Setenv("REMINDER_CHANNEL_PING", "true")
+	t.Setenv("END_PROMPT_MINUTES", "5")
+	if config := currentSessionConfig(); config.ReminderBefore != 30*time.Minute || !config.ReminderPing || config.EndPromptBefore != 5*time.Minute {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+
+	// 0 はリマインダーを送らない、不正な値は既定値
+	t.Setenv("REMINDER_MINUTES", "0")
+	if config := currentSessionConfig(); config.ReminderBefore != 0 {
+		t.Errorf("Expected reminders to be disabled, got %+v", config)
+	}
+	t.Setenv("REMINDER_MINUTES", "soon")
+	t.Setenv("REMINDER_CHANNEL_PING", "")
+	if config := currentSessionConfig(); config.ReminderBefore != defaultReminderMinutes*time.Minute || config.ReminderPing {
+		t.Errorf("Expected default config, got %+v", config)
+	}
+}
+
+func TestSessionEventsReminders(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 13, 0, 0, 0, jst)
+	created := now.AddDate(0, 0, -1)
+	checkedIn := now
+	reservations := []*models.Reservation{
+		{ID: "later", Date: "2025-11-20", StartTime: "16:00", EndTime: "17:00", Status: models.StatusPending, CreatedAt: created},
+		{ID: "soon", Date: "2025-11-20", StartTime: "13:10", EndTime: "14:00", Status: models.StatusPending, CreatedAt: created},
+		// 停止中に送れなかったリマインダーは、予約が終わるまでは送る
+		{ID: "missed", Date: "2025-11-20", StartTime: "12:30", EndTime: "13:30", Status: models.StatusPending, CreatedAt: created},
+		{ID: "ended", Date: "2025-11-20", StartTime: "11:00", EndTime: "12:00", Status: models.StatusPending, CreatedAt: created},
+		{ID: "sent", Date: "2025-11-20", StartTime: "15:00", EndTime: "16:00", Status: models.StatusPending, CreatedAt: created, ReminderSentFor: "2025-11-20 15:00"},
+		// 送信後に開始時刻が変わった予約には送り直す
+		{ID: "moved", Date: "2025-11-20", StartTime: "18:00", EndTime: "19:00", Status: models.StatusPending, CreatedAt: created, ReminderSentFor: "2025-11-20 15:00"},
+		{ID: "cancelled", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusCancelled, CreatedAt: created},
+		{ID: "checked-in", Date: "2025-11-20", StartTime: "13:05", EndTime: "14:00", Status: models.StatusPending, CreatedAt: created, CheckedInAt: &checkedIn},
+		// リマインダーの時刻より後に作られた予約には送らない
+		{ID: "just-booked", Date: "2025-11-20", StartTime: "13:05", EndTime: "14:00", Status: models.StatusPending, CreatedAt: now},
+	}
+
+	events := eventsOfKind(sessionEvents(reservations, sessionConfig{ReminderBefore: 15 * time.Minute}, now), eventReminder)
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.reservation.ID)
+	}
+	if got := strings.Join(ids, ","); got != "missed,soon,later,moved" {
+		t.Errorf("Unexpected reminders: %s", got)
+	}
+	if want := time.Date(2025, 11, 20, 15, 45, 0, 0, jst); !events[2].at.Equal(want) {
+		t.Errorf("Expected reminder at %v, got %v", want, events[2].at)
+	}
+
+	if events := eventsOfKind(sessionEvents(reservations, sessionConfig{}, now), eventReminder); len(events) != 0 {
+		t.Errorf("Expected no reminders when disabled, got %d", len(events))
+	}
+}
+
+func TestSessionEventsEnd(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 13, 0, 0, 0, jst)
+	created := now.AddDate(0, 0, -1)
+	reservations := []*models.Reservation{
+		{ID: "ending", Date: "2025-11-20", StartTime: "12:00", EndTime: "13:05", Status: models.StatusPending, CreatedAt: created},
+		// 延長して終了時刻が変わった予約には確認を送り直す
+		{ID: "extended", Date: "2025-11-20", StartTime: "12:00", EndTime: "14:00", Status: models.StatusPending, CreatedAt: created, EndPromptSentFor: "2025-11-20 13:00"},
+		{ID: "prompted", Date: "2025-11-20", StartTime: "12:00", EndTime: "13:30", Status: models.StatusPending, CreatedAt: created, EndPromptSentFor: "2025-11-20 13:30"},
+		// 停止中に終わった予約は、確認は送らずに完了にする
+		{ID: "ended", Date: "2025-11-19", StartTime: "12:00", EndTime: "13:00", Status: models.StatusPending, CreatedAt: created},
+		{ID: "completed", Date: "2025-11-20", StartTime: "11:00", EndTime: "12:00", Status: models.StatusCompleted, CreatedAt: created},
+		// 確認の時刻が開始前になる短い予約には送らない
+		{ID: "short", Date: "2025-11-20", StartTime: "15:00", EndTime: "15:05", Status: models.StatusPending, CreatedAt: created},
+	}
+
+	events := sessionEvents(reservations, sessionConfig{EndPromptBefore: 10 * time.Minute}, now)
+	var prompts, ends []string
+	for _, event := range eventsOfKind(events, eventEndPrompt) {
+		prompts = append(prompts, event.reservation.ID)
+	}
+	for _, event := range eventsOfKind(events, eventSessionEnd) {
+		ends = append(ends, event.reservation.ID)
+	}
+	if got := strings.Join(prompts, ","); got != "ending,extended" {
+		t.Errorf("Unexpected end prompts: %s", got)
+	}
+	if got := strings.Join(ends, ","); got != "ended,ending,prompted,extended,short" {
+		t.Errorf("Unexpected session ends: %s", got)
+	}
+}
+
+func TestRoomFreeDescription(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", Date: "2025-11-20", StartTime: "13:00", EndTime: "14:00", Status: models.StatusPending},
+		{ID: "r2", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusPending},
+		{ID: "r3", Date: "2025-11-20", StartTime: "17:00", EndTime: "18:00", Status: models.StatusPending},
+	}
+
+	// 続けて次の予約がある場合は空かない
+	if _, free := roomFreeDescription(i18n.English, reservations, reservations[0]); free {
+		t.Error("Expected the room not to be free when the next reservation starts right away")
+	}
+	if description, free := roomFreeDescription(i18n.English, reservations, reservations[1]); !free || description != i18n.T(i18n.English, "session_end.free_until", "17:00") {
+		t.Errorf("Unexpected description: %q (free %v)", description, free)
+	}
+	if description, free := roomFreeDescription(i18n.English, reservations, reservations[2]); !free || description != i18n.T(i18n.English, "session_end.free_rest_of_day") {
+		t.Errorf("Unexpected description: %q (free %v)", description, free)
+	}
+}
+
+// eventsOfKind は指定した種類の通知だけを返す
+func eventsOfKind(events []sessionEvent, kind sessionEventKind) []sessionEvent {
+	var filtered []sessionEvent
+	for _, event := range events {
+		if event.kind == kind {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+func TestReminderDescription(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	r := &models.Reservation{Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00"}
+
+	before := reminderDescription(i18n.English, r, time.Date(2025, 11, 20, 13, 45, 30, 0, jst))
+	if !strings.HasPrefix(before, i18n.T(i18n.English, "reminder.starts_in", 15)) {
+		t.Errorf("Unexpected description before start: %q", before)
+	}
+	after := reminderDescription(i18n.English, r, time.Date(2025, 11, 20, 14, 10, 0, 0, jst))
+	if !strings.HasPrefix(after, i18n.T(i18n.English, "reminder.started", "14:00")) {
+		t.Errorf("Unexpected description after start: %q", after)
+	}
+}
+
+// concurrentEditFixture は予約の編集と通知の処理を同時に実行するテストの準備をする
+// 明日の開室時間内に予約を iterations 件（最大12件）保存し、予約者として操作するインタラクションを返す
+func concurrentEditFixture(t *testing.T, iterations int) (*SessionScheduler, *storage.Storage, *logging.Logger, []*models.Reservation, func(n int) *discordgo.InteractionCreate) {
+	t.Helper()
+	s, _ := newRecordingSession(t)
+	store := storage.NewStorageIn(t.TempDir())
+	logger := logging.NewLogger(t.TempDir())
+	sc := NewSessionScheduler(s, store, logger, "")
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	tomorrow := time.Now().In(jst).AddDate(0, 0, 1).Format("2006-01-02")
+	var reservations []*models.Reservation
+	for n := 0; n < iterations; n++ {
+		// 上限に掛からないよう、予約ごとに別のメンバーにする
+		r := &models.Reservation{
+			ID:        fmt.Sprintf("r%d", n),
+			UserID:    fmt.Sprintf("u%d", n),
+			Username:  fmt.Sprintf("user%d", n),
+			Date:      tomorrow,
+			StartTime: fmt.Sprintf("%02d:00", 9+n),
+			EndTime:   fmt.Sprintf("%02d:30", 9+n),
+			Status:    models.StatusPending,
+		}
+		if err := store.AddReservation(r); err != nil {
+			t.Fatal(err)
+		}
+		reservations = append(reservations, r)
+	}
+
+	interaction := func(n int) *discordgo.InteractionCreate {
+		i := testInteraction(discordgo.InteractionApplicationCommand)
+		i.ID = fmt.Sprintf("i%d", n)
+		i.Member = &discordgo.Member{User: &discordgo.User{ID: fmt.Sprintf("u%d", n), Username: fmt.Sprintf("user%d", n)}}
+		return i
+	}
+	return sc, store, logger, reservations, interaction
+}
+
+// 予約の編集とリマインダーの送信が同時に行われても、どちらの変更も失われない（go test -race で確認する）
+func TestSendReminderConcurrentWithEdit(t *testing.T) {
+	sc, store, logger, reservations, interaction := concurrentEditFixture(t, 12)
+
+	var wg sync.WaitGroup
+	for n, r := range reservations {
+		comment := fmt.Sprintf("edited %d", n)
+		wg.Add(2)
+		go func(n int, r *models.Reservation) {
+			defer wg.Done()
+			editReservation(sc.session, interaction(n), store, logger, "", false, r.ID, editRequest{Comment: &comment})
+		}(n, r)
+		go func(r *models.Reservation) {
+			defer wg.Done()
+			sc.sendReminder(r, time.Now())
+		}(r)
+	}
+	wg.Wait()
+
+	for n, r := range reservations {
+		got, err := store.GetReservation(r.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("edited %d", n); got.Comment != want {
+			t.Errorf("%s: expected comment %q to survive the reminder, got %q", r.ID, want, got.Comment)
+		}
+		if got.ReminderSentFor != r.StartKey() {
+			t.Errorf("%s: expected reminder state %q to survive the edit, got %q", r.ID, r.StartKey(), got.ReminderSentFor)
+		}
+	}
+}
+
+// 予約の編集と終了時刻の完了処理が同時に行われても、完了が編集で元に戻らない（go test -race で確認する）
+func TestEndSessionConcurrentWithEdit(t *testing.T) {
+	sc, store, logger, reservations, interaction := concurrentEditFixture(t, 12)
+
+	var wg sync.WaitGroup
+	for n, r := range reservations {
+		comment := fmt.Sprintf("edited %d", n)
+		wg.Add(2)
+		go func(n int, r *models.Reservation) {
+			defer wg.Done()
+			editReservation(sc.session, interaction(n), store, logger, "", false, r.ID, editRequest{Comment: &comment})
+		}(n, r)
+		go func(r *models.Reservation) {
+			defer wg.Done()
+			sc.endSession(r, time.Now())
+		}(r)
+	}
+	wg.Wait()
+
+	for n, r := range reservations {
+		got, err := store.GetReservation(r.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != models.StatusCompleted {
+			t.Errorf("%s: expected completion to survive the edit, got status %q", r.ID, got.Status)
+		}
+		// 完了より先に編集された場合だけコメントが変わる
+		if want := fmt.Sprintf("edited %d", n); got.Comment != want && got.Comment != "" {
+			t.Errorf("%s: unexpected comment %q", r.ID, got.Comment)
+		}
+	}
+}
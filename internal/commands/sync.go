@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CommandDiff はレジストリのコマンド定義とDiscordに登録済みのコマンドの差分
+type CommandDiff struct {
+	Create []*discordgo.ApplicationCommand // 登録されていないコマンド
+	Update []*discordgo.ApplicationCommand // 定義が変わったコマンド（ID は登録済みのコマンドのもの）
+	Delete []*discordgo.ApplicationCommand // レジストリにないコマンド（登録済みのコマンド）
+}
+
+// Empty は差分がないかどうかを返す
+func (d CommandDiff) Empty() bool {
+	return len(d.Create) == 0 && len(d.Update) == 0 && len(d.Delete) == 0
+}
+
+// DiffCommands はレジストリのコマンド定義 desired と、Discordから取得した登録済みのコマンド existing の差分を返す
+// コマンドは種類と名前で対応付ける。guild はサーバー専用のコマンドかどうか（サーバー専用のコマンドは DMPermission を比較しない）
+func DiffCommands(desired, existing []*discordgo.ApplicationCommand, guild bool) CommandDiff {
+	registered := make(map[string]*discordgo.ApplicationCommand, len(existing))
+	for _, cmd := range existing {
+		registered[commandKey(cmd)] = cmd
+	}
+
+	var diff CommandDiff
+	seen := make(map[string]bool, len(desired))
+	for _, cmd := range desired {
+		key := commandKey(cmd)
+		seen[key] = true
+
+		current, ok := registered[key]
+		switch {
+		case !ok:
+			diff.Create = append(diff.Create, cmd)
+		case !reflect.DeepEqual(shapeOf(cmd, guild), shapeOf(current, guild)):
+			updated := *cmd
+			updated.ID = current.ID
+			diff.Update = append(diff.Update, &updated)
+		}
+	}
+
+	for _, cmd := range existing {
+		if !seen[commandKey(cmd)] {
+			diff.Delete = append(diff.Delete, cmd)
+		}
+	}
+	return diff
+}
+
+// commandKey はコマンドを対応付けるキー（種類と名前）を返す
+func commandKey(cmd *discordgo.ApplicationCommand) string {
+	return fmt.Sprintf("%d:%s", commandTypeOf(cmd), cmd.Name)
+}
+
+// commandTypeOf はコマンドの種類を返す（未指定の場合はスラッシュコマンド）
+func commandTypeOf(cmd *discordgo.ApplicationCommand) discordgo.ApplicationCommandType {
+	if cmd.Type == 0 {
+		return discordgo.ChatApplicationCommand
+	}
+	return cmd.Type
+}
+
+// commandShape はコマンド定義のうち、比較する項目を既定値を補って表したもの
+// Discordから取得したコマンドは省略された項目や数値の型が定義と異なるため、比較前にこの形にそろえる
+type commandShape struct {
+	Type                     discordgo.ApplicationCommandType
+	Name                     string
+	NameLocalizations        map[discordgo.Locale]string
+	Description              string
+	DescriptionLocalizations map[discordgo.Locale]string
+	DefaultMemberPermissions string
+	DMPermission             bool
+	NSFW                     bool
+	Options                  []optionShape
+}
+
+type optionShape struct {
+	Type                     discordgo.ApplicationCommandOptionType
+	Name                     string
+	NameLocalizations        map[discordgo.Locale]string
+	Description              string
+	DescriptionLocalizations map[discordgo.Locale]string
+	ChannelTypes             []discordgo.ChannelType
+	Required                 bool
+	Autocomplete             bool
+	Choices                  []choiceShape
+	MinValue                 string
+	MaxValue                 float64
+	MinLength                string
+	MaxLength                int
+	Options                  []optionShape
+}
+
+type choiceShape struct {
+	Name              string
+	NameLocalizations map[discordgo.Locale]string
+	Value             string
+}
+
+// shapeOf はコマンド定義を比較用の形にする
+func shapeOf(cmd *discordgo.ApplicationCommand, guild bool) commandShape {
+	shape := commandShape{
+		Type:         commandTypeOf(cmd),
+		Name:         cmd.Name,
+		Description:  cmd.Description,
+		DMPermission: cmd.DMPermission == nil || *cmd.DMPermission,
+		NSFW:         cmd.NSFW != nil && *cmd.NSFW,
+		Options:      optionShapes(cmd.Options),
+	}
+	if cmd.NameLocalizations != nil {
+		shape.NameLocalizations = nonEmpty(*cmd.NameLocalizations)
+	}
+	if cmd.DescriptionLocalizations != nil {
+		shape.DescriptionLocalizations = nonEmpty(*cmd.DescriptionLocalizations)
+	}
+	if cmd.DefaultMemberPermissions != nil {
+		shape.DefaultMemberPermissions = fmt.Sprint(*cmd.DefaultMemberPermissions)
+	}
+	if guild {
+		// サーバー専用のコマンドはDMに表示されないため、Discordは DMPermission を返さない
+		shape.DMPermission = true
+	}
+	return shape
+}
+
+func optionShapes(options []*discordgo.ApplicationCommandOption) []optionShape {
+	if len(options) == 0 {
+		return nil
+	}
+	shapes := make([]optionShape, len(options))
+	for idx, opt := range options {
+		shape := optionShape{
+			Type:                     opt.Type,
+			Name:                     opt.Name,
+			NameLocalizations:        nonEmpty(opt.NameLocalizations),
+			Description:              opt.Description,
+			DescriptionLocalizations: nonEmpty(opt.DescriptionLocalizations),
+			Required:                 opt.Required,
+			Autocomplete:             opt.Autocomplete,
+			MaxValue:                 opt.MaxValue,
+			MaxLength:                opt.MaxLength,
+			Options:                  optionShapes(opt.Options),
+		}
+		if len(opt.ChannelTypes) > 0 {
+			shape.ChannelTypes = opt.ChannelTypes
+		}
+		if opt.MinValue != nil {
+			shape.MinValue = fmt.Sprint(*opt.MinValue)
+		}
+		if opt.MinLength != nil {
+			shape.MinLength = fmt.Sprint(*opt.MinLength)
+		}
+		for _, choice := range opt.Choices {
+			// Discordから取得した数値の選択肢は float64 になるため、文字列にして比較する
+			shape.Choices = append(shape.Choices, choiceShape{
+				Name:              choice.Name,
+				NameLocalizations: nonEmpty(choice.NameLocalizations),
+				Value:             fmt.Sprint(choice.Value),
+			})
+		}
+		shapes[idx] = shape
+	}
+	return shapes
+}
+
+// nonEmpty は空のマップを nil にする（省略された翻訳と空の翻訳を同じものとして比較するため）
+func nonEmpty(m map[discordgo.Locale]string) map[discordgo.Locale]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// registered はDiscordから取得したコマンドを再現する（JSONを経由して、数値の型や省略された項目をそろえる）
+func registered(t *testing.T, definitions []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	t.Helper()
+	data, err := json.Marshal(definitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commands []*discordgo.ApplicationCommand
+	if err := json.Unmarshal(data, &commands); err != nil {
+		t.Fatal(err)
+	}
+	for idx, cmd := range commands {
+		cmd.ID = "id-" + cmd.Name
+		cmd.Version = "1"
+		if cmd.Type == 0 {
+			cmd.Type = discordgo.ChatApplicationCommand
+		}
+		// サーバー専用のコマンドは dm_permission を返さない
+		commands[idx].DMPermission = nil
+	}
+	return commands
+}
+
+func TestDiffCommandsUnchanged(t *testing.T) {
+	definitions := DefaultRegistry().Definitions()
+	if diff := DiffCommands(definitions, registered(t, definitions), true); !diff.Empty() {
+		t.Errorf("Expected no changes, got %d created, %d updated, %d deleted", len(diff.Create), len(diff.Update), len(diff.Delete))
+	}
+}
+
+func TestDiffCommandsChanges(t *testing.T) {
+	existing := registered(t, []*discordgo.ApplicationCommand{
+		{Name: "help", Description: "Show help"},
+		{Name: "old", Description: "Removed command"},
+		{Name: "reserve", Description: "Book the room"},
+	})
+	desired := []*discordgo.ApplicationCommand{
+		{Name: "help", Description: "Show help"},
+		{Name: "reserve", Description: "Book the room", Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionString, Name: "date", Description: "Date", Required: true},
+		}},
+		{Name: "new", Description: "New command"},
+	}
+
+	diff := DiffCommands(desired, existing, true)
+	if len(diff.Create) != 1 || diff.Create[0].Name != "new" {
+		t.Errorf("Expected /new to be created, got %v", diff.Create)
+	}
+	if len(diff.Update) != 1 || diff.Update[0].Name != "reserve" || diff.Update[0].ID != "id-reserve" {
+		t.Errorf("Expected /reserve to be updated with its registered ID, got %v", diff.Update)
+	}
+	if len(diff.Delete) != 1 || diff.Delete[0].Name != "old" {
+		t.Errorf("Expected /old to be deleted, got %v", diff.Delete)
+	}
+	if desired[1].ID != "" {
+		t.Error("DiffCommands should not modify the definitions")
+	}
+}
+
+func TestDiffCommandsMatchesByType(t *testing.T) {
+	// 同じ名前でも種類が違うコマンドは別のコマンド
+	existing := registered(t, []*discordgo.ApplicationCommand{{Name: "view", Description: "Slash command"}})
+	desired := []*discordgo.ApplicationCommand{{Name: "view", Type: discordgo.UserApplicationCommand}}
+
+	diff := DiffCommands(desired, existing, true)
+	if len(diff.Create) != 1 || len(diff.Delete) != 1 || len(diff.Update) != 0 {
+		t.Errorf("Expected one create and one delete, got %d created, %d updated, %d deleted", len(diff.Create), len(diff.Update), len(diff.Delete))
+	}
+}
+
+func TestDiffCommandsDMPermission(t *testing.T) {
+	disabled := false
+	desired := []*discordgo.ApplicationCommand{{Name: "admin", Description: "Admin", DMPermission: &disabled}}
+	existing := registered(t, []*discordgo.ApplicationCommand{{Name: "admin", Description: "Admin"}})
+
+	// サーバー専用のコマンドでは比較しない
+	if diff := DiffCommands(desired, existing, true); !diff.Empty() {
+		t.Error("Expected DM permission to be ignored for guild commands")
+	}
+	// グローバルのコマンドでは変更として扱う
+	if diff := DiffCommands(desired, existing, false); len(diff.Update) != 1 {
+		t.Error("Expected DM permission change to be detected for global commands")
+	}
+}
@@ -0,0 +1,295 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/naturaltime"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+var (
+	errInvalidDate     = newError("validation.invalid_date")
+	errInvalidTime     = newError("validation.invalid_time")
+	errInvalidDuration = newError("validation.invalid_duration")
+	errInvalidMonth    = newError("validation.invalid_month")
+)
+
+// parseDateInput は日付入力を解釈・検証し、保存用のYYYY-MM-DD形式と日付を返す
+// 「明日」「来週の火曜」のような表現は現在の日本時間を基準に解釈する
+func parseDateInput(dateStr string) (string, time.Time, error) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	date, parsed, _, err := parseDateTimeInput(dateStr, time.Now().In(jst))
+	return date, parsed, err
+}
+
+// parseDateTimeInput は now を基準に日付入力を解釈し、YYYY-MM-DD形式と日付（UTCの0時）を返す
+// 「明日14時」のように時刻を含む場合は、その時刻を HH:MM 形式で clock に返す
+func parseDateTimeInput(input string, now time.Time) (date string, parsed time.Time, clock string, err error) {
+	t, clock, err := naturaltime.ParseDateTime(input, now)
+	if err != nil {
+		return "", time.Time{}, "", errInvalidDate
+	}
+	parsed = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return parsed.Format("2006-01-02"), parsed, clock, nil
+}
+
+// parseTimeInput は時刻入力を解釈・検証し、HH:MM形式で返す（「14時半」「3pm」なども受け付ける）
+func parseTimeInput(timeStr string) (string, error) {
+	clock, err := naturaltime.ParseTime(timeStr)
+	if err != nil {
+		return "", errInvalidTime
+	}
+	return clock, nil
+}
+
+// parseDurationInput は時間の長さの入力を解釈する
+// 数字のみの場合は分として扱い、それ以外は 30m・1h30m・1時間半 などの形式で解釈する
+func parseDurationInput(input string) (time.Duration, error) {
+	duration, err := naturaltime.ParseDuration(input)
+	if err != nil {
+		return 0, errInvalidDuration
+	}
+	return duration, nil
+}
+
+// addDuration は HH:MM 形式の時刻に時間の長さを足した時刻を返す（日付をまたぐ場合は false）
+func addDuration(clock string, d time.Duration) (string, bool) {
+	minutes := schedule.ToMinutes(clock) + int(d/time.Minute)
+	if minutes >= 24*60 {
+		return "", false
+	}
+	return schedule.FromMinutes(minutes), true
+}
+
+// closedDayError は休室日（schedule.ClosedOn）の予約を拒否するエラーを返す。休室日でない場合は nil
+func closedDayError(date string) error {
+	reason, closed := schedule.ClosedOn(date)
+	switch {
+	case !closed:
+		return nil
+	case reason != "":
+		return newError("validation.closed_day_reason", formatDate(date), reason)
+	default:
+		return newError("validation.closed_day", formatDate(date))
+	}
+}
+
+// scheduleError は休室日（closedDayError）と開室時間（openingHoursErrors）外の時間帯を拒否するエラーを返す。問題がない場合は nil
+func scheduleError(date, startTime, endTime string) error {
+	if err := closedDayError(date); err != nil {
+		return err
+	}
+	if errs := openingHoursErrors(startTime, endTime); len(errs) > 0 {
+		return errs[0].Err
+	}
+	return nil
+}
+
+// openingHoursErrors は開室時間（schedule.OpeningTime 〜 schedule.ClosingTime）外の開始時刻・終了時刻を入力項目ごとのエラーとして返す
+func openingHoursErrors(startTime, endTime string) fieldErrors {
+	var errs fieldErrors
+	if startTime < schedule.OpeningTime {
+		errs = append(errs, fieldError{"start_time", newError("validation.outside_hours", schedule.OpeningTime, schedule.ClosingTime)})
+	}
+	if endTime > schedule.ClosingTime {
+		errs = append(errs, fieldError{"end_time", newError("validation.outside_hours", schedule.OpeningTime, schedule.ClosingTime)})
+	}
+	return errs
+}
+
+// fieldError は入力項目ごとの検証エラーを表す
+type fieldError struct {
+	Field string // 入力項目（date / start_time / end_time / duration）
+	Err   error  // 表示するメッセージ（newError で作成し、表示時に利用者の言語に変換する）
+}
+
+// fieldErrors は検証エラーの一覧を表す
+type fieldErrors []fieldError
+
+// Error はログ出力用にすべてのエラーを1行にまとめる
+func (errs fieldErrors) Error() string {
+	messages := make([]string, len(errs))
+	for idx, e := range errs {
+		messages[idx] = e.Field + ": " + e.Err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validateReservationRequest は予約作成の入力値を検証し、保存用に正規化した値を返す
+// 終了時間の代わりに利用時間を指定でき、どちらも空の場合は開始時刻+1時間とする。エラーは入力項目ごとにまとめて返す（/reserve と予約フォームで共通）
+func validateReservationRequest(req reservationRequest, now time.Time) (reservationRequest, fieldErrors) {
+	var errs fieldErrors
+	normalized := reservationRequest{Comment: req.Comment}
+
+	date, parsedDate, clock, err := parseDateTimeInput(req.Date, now)
+	if err != nil {
+		errs = append(errs, fieldError{"date", errInvalidDate})
+	} else if err := closedDayError(date); err != nil {
+		errs = append(errs, fieldError{"date", err})
+	}
+	normalized.Date = date
+
+	// 開始時間が空の場合は日付に含まれる時刻（「明日14時」など）を使う
+	var startTime string
+	switch {
+	case req.StartTime != "":
+		startTime, err = parseTimeInput(req.StartTime)
+		if err != nil {
+			errs = append(errs, fieldError{"start_time", newError("validation.invalid_start_time")})
+		}
+	case clock != "":
+		startTime = clock
+	case err == nil:
+		errs = append(errs, fieldError{"start_time", newError("validation.start_time_required")})
+	}
+	normalized.StartTime = startTime
+
+	switch {
+	case req.EndTime != "" && req.Duration != "":
+		errs = append(errs, fieldError{"duration", newError("validation.end_time_or_duration")})
+	case req.EndTime != "":
+		endTime, err := parseTimeInput(req.EndTime)
+		if err != nil {
+			errs = append(errs, fieldError{"end_time", newError("validation.invalid_end_time")})
+		}
+		normalized.EndTime = endTime
+	case req.Duration != "":
+		duration, err := parseDurationInput(req.Duration)
+		if err != nil {
+			errs = append(errs, fieldError{"duration", errInvalidDuration})
+		} else if startTime != "" {
+			endTime, ok := addDuration(startTime, duration)
+			if !ok {
+				errs = append(errs, fieldError{"duration", newError("validation.crosses_midnight")})
+			}
+			normalized.EndTime = endTime
+		}
+	case startTime != "":
+		// 終了時間が指定されていない場合は開始時刻+1時間
+		start, _ := time.Parse("15:04", startTime)
+		normalized.EndTime = start.Add(1 * time.Hour).Format("15:04")
+	}
+
+	// 終了時刻が開始時刻より前または同じ時刻でないか、開室時間内かをチェック
+	if normalized.StartTime != "" && normalized.EndTime != "" {
+		if normalized.EndTime <= normalized.StartTime {
+			errs = append(errs, fieldError{"end_time", newError("validation.end_before_start", normalized.StartTime)})
+		} else {
+			errs = append(errs, openingHoursErrors(normalized.StartTime, normalized.EndTime)...)
+		}
+	}
+
+	// 過去日時のチェック（日付と開始時刻がどちらも正しい場合のみ）
+	if date != "" && startTime != "" {
+		start, _ := time.Parse("15:04", startTime)
+		reservationDateTime := time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), start.Hour(), start.Minute(), 0, 0, now.Location())
+		if reservationDateTime.Before(now) {
+			field := "start_time"
+			if date < now.Format("2006-01-02") {
+				field = "date"
+			}
+			errs = append(errs, fieldError{field, newError("validation.past_datetime", now.Format("2006/01/02 15:04"))})
+		}
+	}
+
+	return normalized, errs
+}
+
+// validateEditRequest は予約編集の入力値を検証し、変更後の値を返す
+// 指定されていない項目は現在の値を引き継ぐ。変更する項目がない場合は hasChanges が false になる（/edit と編集フォームで共通）
+func validateEditRequest(r *models.Reservation, req editRequest, now time.Time) (updated reservationRequest, hasChanges bool, errs fieldErrors) {
+	updated = reservationRequest{
+		Date:      r.Date,
+		StartTime: r.StartTime,
+		EndTime:   r.EndTime,
+		Comment:   r.Comment,
+	}
+
+	// 日付の変更
+	if req.Date != nil {
+		hasChanges = true
+		date, parsedDate, clock, err := parseDateTimeInput(*req.Date, now)
+		if err != nil {
+			errs = append(errs, fieldError{"date", errInvalidDate})
+		} else {
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+			if parsedDate.Before(today) {
+				errs = append(errs, fieldError{"date", newError("validation.past_date")})
+			}
+			if err := closedDayError(date); err != nil {
+				errs = append(errs, fieldError{"date", err})
+			}
+			updated.Date = date
+
+			// 「明日14時」のように時刻を含む場合は、予約の長さを保ったまま開始時間も変更する
+			if clock != "" && req.StartTime == nil && req.EndTime == nil && req.Duration == nil {
+				length := schedule.ToMinutes(r.EndTime) - schedule.ToMinutes(r.StartTime)
+				endTime, ok := addDuration(clock, time.Duration(length)*time.Minute)
+				if !ok {
+					errs = append(errs, fieldError{"end_time", newError("validation.shift_crosses_midnight")})
+				}
+				updated.StartTime = clock
+				updated.EndTime = endTime
+			}
+		}
+	}
+
+	// 開始時間の変更
+	if req.StartTime != nil {
+		hasChanges = true
+		startTime, err := parseTimeInput(*req.StartTime)
+		if err != nil {
+			errs = append(errs, fieldError{"start_time", newError("validation.invalid_start_time")})
+		} else {
+			updated.StartTime = startTime
+		}
+	}
+
+	// 終了時間の変更
+	if req.EndTime != nil {
+		hasChanges = true
+		endTime, err := parseTimeInput(*req.EndTime)
+		if err != nil {
+			errs = append(errs, fieldError{"end_time", newError("validation.invalid_end_time")})
+		} else {
+			updated.EndTime = endTime
+		}
+	}
+
+	// 利用時間の変更（変更後の開始時間からの長さで終了時間を決める）
+	if req.Duration != nil {
+		hasChanges = true
+		duration, err := parseDurationInput(*req.Duration)
+		switch {
+		case req.EndTime != nil:
+			errs = append(errs, fieldError{"duration", newError("validation.end_time_or_duration")})
+		case err != nil:
+			errs = append(errs, fieldError{"duration", errInvalidDuration})
+		default:
+			endTime, ok := addDuration(updated.StartTime, duration)
+			if !ok {
+				errs = append(errs, fieldError{"duration", newError("validation.crosses_midnight")})
+			}
+			updated.EndTime = endTime
+		}
+	}
+
+	// コメントの変更
+	if req.Comment != nil {
+		hasChanges = true
+		updated.Comment = *req.Comment
+	}
+
+	// 時刻の整合性チェック（開室時間は時刻を変更する場合のみ。管理者が開室時間外に変更した予約もコメントなどは編集できる）
+	if len(errs) == 0 {
+		if updated.EndTime <= updated.StartTime {
+			errs = append(errs, fieldError{"end_time", newError("validation.end_before_start", updated.StartTime)})
+		} else if updated.StartTime != r.StartTime || updated.EndTime != r.EndTime {
+			errs = append(errs, openingHoursErrors(updated.StartTime, updated.EndTime)...)
+		}
+	}
+
+	return updated, hasChanges, errs
+}
@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+func TestValidateReservationRequest(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, jst)
+
+	// 正規化され、終了時間の省略時は開始時刻+1時間になる
+	input, errs := validateReservationRequest(reservationRequest{Date: "2025/11/21", StartTime: "9:30"}, now)
+	if len(errs) != 0 {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+	if input.Date != "2025-11-21" || input.StartTime != "09:30" || input.EndTime != "10:30" {
+		t.Errorf("Unexpected normalized input: %+v", input)
+	}
+
+	// 自然な表現は now を基準に解釈し、日付に含まれる時刻は開始時間として使う
+	input, errs = validateReservationRequest(reservationRequest{Date: "tomorrow 3pm", EndTime: "16時半"}, now)
+	if len(errs) != 0 {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+	if input.Date != "2025-11-21" || input.StartTime != "15:00" || input.EndTime != "16:30" {
+		t.Errorf("Unexpected natural-language input: %+v", input)
+	}
+
+	// 利用時間を指定すると開始時刻からの長さで終了時間が決まる
+	input, errs = validateReservationRequest(reservationRequest{Date: "2025/11/21", StartTime: "14:00", Duration: "1h30m"}, now)
+	if len(errs) != 0 || input.EndTime != "15:30" {
+		t.Errorf("Expected end time 15:30, got %+v %v", input, errs)
+	}
+
+	tests := []struct {
+		name   string
+		req    reservationRequest
+		fields []string
+	}{
+		{"invalid formats", reservationRequest{Date: "someday", StartTime: "25:00", EndTime: "xx"}, []string{"date", "start_time", "end_time"}},
+		{"end before start", reservationRequest{Date: "2025-11-21", StartTime: "15:00", EndTime: "14:00"}, []string{"end_time"}},
+		{"past time today", reservationRequest{Date: "2025-11-20", StartTime: "11:00", EndTime: "13:00"}, []string{"start_time"}},
+		{"past date", reservationRequest{Date: "2025-11-19", StartTime: "13:00", EndTime: "14:00"}, []string{"date"}},
+		{"missing start time", reservationRequest{Date: "明日"}, []string{"start_time"}},
+		{"end time and duration", reservationRequest{Date: "2025-11-21", StartTime: "15:00", EndTime: "16:00", Duration: "1h"}, []string{"duration"}},
+		{"duration past midnight", reservationRequest{Date: "2025-11-21", StartTime: "23:00", Duration: "2時間"}, []string{"duration"}},
+	}
+	for _, tt := range tests {
+		_, errs := validateReservationRequest(tt.req, now)
+		if len(errs) != len(tt.fields) {
+			t.Errorf("%s: expected errors for %v, got %v", tt.name, tt.fields, errs)
+			continue
+		}
+		for idx, field := range tt.fields {
+			if errs[idx].Field != field {
+				t.Errorf("%s: expected error on %s, got %s", tt.name, field, errs[idx].Field)
+			}
+		}
+	}
+}
+
+func TestValidateReservationRequestClosedDay(t *testing.T) {
+	if err := schedule.SetClosedDays("2025-11-24=勤労感謝の日"); err != nil {
+		t.Fatal(err)
+	}
+	defer schedule.SetClosedDays("")
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, jst)
+
+	_, errs := validateReservationRequest(reservationRequest{Date: "2025/11/24", StartTime: "10:00"}, now)
+	if len(errs) != 1 || errs[0].Field != "date" {
+		t.Fatalf("Expected a date error, got %v", errs)
+	}
+	if message := localize("ja", errs[0].Err); message != "2025/11/24 は休室日（勤労感謝の日）のため予約できません" {
+		t.Errorf("Unexpected message: %s", message)
+	}
+
+	// 編集で休室日に移すこともできない
+	r := &models.Reservation{Date: "2025-11-21", StartTime: "10:00", EndTime: "11:00"}
+	date := "2025-11-24"
+	if _, _, errs := validateEditRequest(r, editRequest{Date: &date}, now); len(errs) != 1 || errs[0].Field != "date" {
+		t.Errorf("Expected a date error, got %v", errs)
+	}
+}
+
+func TestValidateReservationRequestOpeningHours(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 7, 0, 0, 0, jst)
+
+	// 開室時間（09:00-21:00）外の時刻は項目ごとのエラーになる。閉室時刻ちょうどに終わる予約はできる
+	_, errs := validateReservationRequest(reservationRequest{Date: "2025/11/21", StartTime: "08:30", EndTime: "21:30"}, now)
+	if len(errs) != 2 || errs[0].Field != "start_time" || errs[1].Field != "end_time" {
+		t.Fatalf("Expected start and end time errors, got %v", errs)
+	}
+	if message := localize("ja", errs[0].Err); message != "開室時間（09:00 - 21:00）外の時間帯は指定できません" {
+		t.Errorf("Unexpected message: %s", message)
+	}
+	if _, errs := validateReservationRequest(reservationRequest{Date: "2025/11/21", StartTime: "20:00", EndTime: "21:00"}, now); len(errs) != 0 {
+		t.Errorf("Expected no errors up to closing time, got %v", errs)
+	}
+
+	// 編集は時刻を変更する場合のみチェックする（開室時間外の予約もコメントは変更できる）
+	r := &models.Reservation{Date: "2025-11-21", StartTime: "20:00", EndTime: "22:00"}
+	endTime := "22:30"
+	if _, _, errs := validateEditRequest(r, editRequest{EndTime: &endTime}, now); len(errs) != 1 || errs[0].Field != "end_time" {
+		t.Errorf("Expected an end time error, got %v", errs)
+	}
+	comment := "延長許可済み"
+	if _, _, errs := validateEditRequest(r, editRequest{Comment: &comment}, now); len(errs) != 0 {
+		t.Errorf("Expected comment edit to be allowed, got %v", errs)
+	}
+}
+
+func TestScheduleError(t *testing.T) {
+	if err := schedule.SetClosedDays("2025-11-24=勤労感謝の日"); err != nil {
+		t.Fatal(err)
+	}
+	defer schedule.SetClosedDays("")
+
+	tests := []struct {
+		date, startTime, endTime string
+		want                     string
+	}{
+		{"2025-11-21", "09:00", "21:00", ""},
+		{"2025-11-24", "10:00", "11:00", "2025/11/24 は休室日（勤労感謝の日）のため予約できません"},
+		{"2025-11-21", "08:30", "10:00", "開室時間（09:00 - 21:00）外の時間帯は指定できません"},
+		{"2025-11-21", "20:00", "21:30", "開室時間（09:00 - 21:00）外の時間帯は指定できません"},
+	}
+	for _, tt := range tests {
+		err := scheduleError(tt.date, tt.startTime, tt.endTime)
+		got := ""
+		if err != nil {
+			got = localize("ja", err)
+		}
+		if got != tt.want {
+			t.Errorf("scheduleError(%s, %s, %s) = %q, want %q", tt.date, tt.startTime, tt.endTime, got, tt.want)
+		}
+	}
+}
+
+func TestValidateEditRequest(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, jst)
+	r := &models.Reservation{Date: "2025-11-21", StartTime: "10:00", EndTime: "11:00", Comment: "練習"}
+
+	if _, hasChanges, _ := validateEditRequest(r, editRequest{}, now); hasChanges {
+		t.Error("Expected no changes for empty request")
+	}
+
+	endTime := "12:30"
+	updated, hasChanges, errs := validateEditRequest(r, editRequest{EndTime: &endTime}, now)
+	if !hasChanges || len(errs) != 0 {
+		t.Fatalf("Expected valid change, got %v", errs)
+	}
+	if updated.Date != r.Date || updated.StartTime != r.StartTime || updated.EndTime != "12:30" || updated.Comment != r.Comment {
+		t.Errorf("Unexpected updated values: %+v", updated)
+	}
+
+	// 終了時間を開始時間より前にはできない
+	startTime := "11:30"
+	if _, _, errs := validateEditRequest(r, editRequest{StartTime: &startTime}, now); len(errs) != 1 || errs[0].Field != "end_time" {
+		t.Errorf("Expected end_time error, got %v", errs)
+	}
+
+	// 時刻を含む日付に変更すると、予約の長さを保ったまま開始時間も変わる
+	date := "来週の火曜 14時"
+	updated, _, errs = validateEditRequest(r, editRequest{Date: &date}, now)
+	if len(errs) != 0 || updated.Date != "2025-11-25" || updated.StartTime != "14:00" || updated.EndTime != "15:00" {
+		t.Errorf("Unexpected natural-language edit: %+v %v", updated, errs)
+	}
+
+	// 利用時間は変更後の開始時間から数える
+	startTime = "13:00"
+	duration := "2時間"
+	updated, _, errs = validateEditRequest(r, editRequest{StartTime: &startTime, Duration: &duration}, now)
+	if len(errs) != 0 || updated.StartTime != "13:00" || updated.EndTime != "15:00" {
+		t.Errorf("Unexpected duration edit: %+v %v", updated, errs)
+	}
+	if _, _, errs := validateEditRequest(r, editRequest{EndTime: &endTime, Duration: &duration}, now); len(errs) != 1 || errs[0].Field != "duration" {
+		t.Errorf("Expected duration error, got %v", errs)
+	}
+
+	// 過去の日付には変更できない
+	date = "2025/11/19"
+	if _, _, errs := validateEditRequest(r, editRequest{Date: &date}, now); len(errs) != 1 || errs[0].Field != "date" {
+		t.Errorf("Expected date error, got %v", errs)
+	}
+}
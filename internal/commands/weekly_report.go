@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/stats"
+	"github.com/dice/hxs_reservation_system/internal/storage"
+)
+
+// weeklyReportStateKey は最後に週間レポートを投稿した週の開始日（YYYY-MM-DD）を保存する状態のキー
+const weeklyReportStateKey = "weekly_report_last_week"
+
+// PostWeeklyReport は channelID のチャンネルに先週（月曜日〜日曜日）の週間レポートを投稿する（表示言語は i18n.DefaultLocale()）
+// now は日本時間の現在時刻。予約の集計（アーカイブ済みを含む）に logger の月別のコマンド利用回数（weeklyCommandUsage）を合わせ、日ごとの集計をCSVで添付する
+// 投稿した週は保存するため、再起動しても同じ週を2回投稿することはなく、投稿済みの場合は false を返す
+func PostWeeklyReport(s *discordgo.Session, store *storage.Storage, logger *logging.Logger, channelID string, now time.Time) (bool, error) {
+	from, to := previousWeek(now)
+	filter := storage.ReservationFilter{
+		FromDate: from.Format("2006-01-02"),
+		ToDate:   to.Format("2006-01-02"),
+	}
+	if store.GetState(weeklyReportStateKey) == filter.FromDate {
+		return false, nil
+	}
+
+	reservations, err := store.FindHistory(filter)
+	if err != nil {
+		return false, err
+	}
+	report := stats.Compute(reservations, filter.FromDate, filter.ToDate)
+	ranked := rankedUsers(report, func(userID string) bool {
+		return store.GetUserSettings(userID).ShowInRanks
+	}, statsRankingLimit)
+	usage := weeklyCommandUsage(logger.GetStats().MonthlyStats, from, to, now)
+
+	data, err := WeeklyReportCSV(reservations, from, to)
+	if err != nil {
+		return false, err
+	}
+
+	locale := i18n.DefaultLocale()
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{WeeklyReportEmbed(locale, report, ranked, usage)},
+		Files: []*discordgo.File{
+			{
+				Name:        "weekly_report_" + filter.FromDate + ".csv",
+				ContentType: "text/csv",
+				Reader:      bytes.NewReader(data),
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, store.SetState(weeklyReportStateKey, filter.FromDate)
+}
+
+// previousWeek は now の前の週の月曜日と日曜日を返す
+func previousWeek(now time.Time) (from, to time.Time) {
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	monday := time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, now.Location())
+	return monday.AddDate(0, 0, -7), monday.AddDate(0, 0, -1)
+}
+
+// commandUsage は週間レポートに載せる1か月分のコマンド利用回数を表す
+type commandUsage struct {
+	Month   time.Time           // 集計した月
+	Through int                 // 月の途中までの集計の場合はその日（月全体の集計の場合は 0）
+	Stat    logging.MonthlyStat // コマンド利用回数
+}
+
+// weeklyCommandUsage は from 〜 to の週にかかる月（月をまたぐ場合は2か月分）のコマンド利用回数を返す
+// 利用回数は月別にしか記録していないため、週の分ではなく月全体の回数になる。now の月は now の日までの集計（月初から）とする
+func weeklyCommandUsage(monthly map[string]logging.MonthlyStat, from, to, now time.Time) []commandUsage {
+	months := []time.Time{from}
+	if to.Format("2006-01") != from.Format("2006-01") {
+		months = append(months, to)
+	}
+
+	usage := make([]commandUsage, 0, len(months))
+	for _, month := range months {
+		key := month.Format("2006-01")
+		u := commandUsage{
+			Month: time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location()),
+			Stat:  monthly[key],
+		}
+		if key == now.Format("2006-01") {
+			u.Through = now.Day()
+		}
+		usage = append(usage, u)
+	}
+	return usage
+}
+
+// rankedUsers は予約時間の多い順に、ランキングへの掲載を選んだメンバーだけを最大 n 人返す
+func rankedUsers(report stats.Report, showInRanks func(userID string) bool, n int) []stats.UserUsage {
+	var ranked []stats.UserUsage
+	for _, usage := range report.TopUsers(len(report.Users)) {
+		if len(ranked) >= n {
+			break
+		}
+		if showInRanks(usage.UserID) {
+			ranked = append(ranked, usage)
+		}
+	}
+	return ranked
+}
+
+// WeeklyReportEmbed は週間レポートの埋め込みを作成する
+// ranked はランキングに表示するメンバー、usage は月ごとのコマンド利用回数（月の途中までの集計はその旨を見出しに表示する）
+func WeeklyReportEmbed(locale string, report stats.Report, ranked []stats.UserUsage, usage []commandUsage) *discordgo.MessageEmbed {
+	var bookers []string
+	for idx, usage := range ranked {
+		bookers = append(bookers, i18n.T(locale, "stats.top_booker", idx+1, usage.UserID,
+			formatHours(locale, time.Duration(usage.Minutes)*time.Minute), usage.Bookings))
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   i18n.T(locale, "stats.bookings"),
+			Value:  i18n.T(locale, "stats.bookings_value", report.Bookings, report.Completed),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "stats.cancelled"),
+			Value:  i18n.T(locale, "stats.cancelled_value", report.Cancelled, report.CancelRate()*100),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "weekly_report.no_shows"),
+			Value:  i18n.T(locale, "weekly_report.no_shows_value", report.NoShows, report.NoShowRate()*100) + "\n" + noShowNote(locale),
+			Inline: true,
+		},
+		{
+			Name: i18n.T(locale, "stats.utilization"),
+			Value: i18n.T(locale, "stats.utilization_value", report.UtilizationRate()*100,
+				formatHours(locale, time.Duration(report.BookedMinutes)*time.Minute), formatHours(locale, time.Duration(report.OpenMinutes)*time.Minute)),
+			Inline: false,
+		},
+		{
+			Name:   i18n.T(locale, "stats.busiest_weekdays"),
+			Value:  formatRanked(locale, report.BusiestWeekdays(3), func(key int) string { return i18n.T(locale, "stats.weekday", i18n.Weekday(locale, time.Weekday(key))) }),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "stats.busiest_hours"),
+			Value:  formatRanked(locale, report.BusiestHours(3), func(key int) string { return i18n.T(locale, "stats.hour", key) }),
+			Inline: true,
+		},
+		{
+			Name:   i18n.T(locale, "stats.top_bookers"),
+			Value:  joinOrNone(locale, bookers) + "\n" + i18n.T(locale, "weekly_report.ranking_hint"),
+			Inline: false,
+		},
+	}
+	for _, u := range usage {
+		name := i18n.T(locale, "weekly_report.command_usage", u.Month.Year(), int(u.Month.Month()))
+		if u.Through > 0 {
+			name = i18n.T(locale, "weekly_report.command_usage_to_date", u.Month.Year(), int(u.Month.Month()), u.Through)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   name,
+			Value:  formatCommandUsage(locale, u.Stat),
+			Inline: false,
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "weekly_report.title"),
+		Description: i18n.T(locale, "stats.period", formatDate(report.From), formatDate(report.To)),
+		Color:       0x5865F2,
+		Fields:      fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(locale, "weekly_report.footer"),
+		},
+	}
+}
+
+// noShowNote は無断欠席の数え方の説明を返す（週間レポートと朝のダイジェストで共通）
+// チェックインのボタンはリマインダーにしかないため、リマインダーを送った予約だけを数える（models.Reservation.IsNoShow）
+func noShowNote(locale string) string {
+	if currentSessionConfig().ReminderBefore == 0 {
+		return i18n.T(locale, "weekly_report.no_show_disabled")
+	}
+	return i18n.T(locale, "weekly_report.no_show_rule")
+}
+
+// weeklyReportCSVHeader は週間レポートのCSVの見出し
+var weeklyReportCSVHeader = []string{"date", "weekday", "bookings", "completed", "cancelled", "no_shows", "booked_minutes", "open_minutes", "utilization"}
+
+// WeeklyReportCSV は from 〜 to の日ごとの集計と合計をCSVにする（メンバーごとの内訳は含まない）
+func WeeklyReportCSV(reservations []*models.Reservation, from, to time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(weeklyReportCSVHeader); err != nil {
+		return nil, err
+	}
+
+	row := func(label, weekday string, report stats.Report) []string {
+		return []string{
+			label,
+			weekday,
+			strconv.Itoa(report.Bookings),
+			strconv.Itoa(report.Completed),
+			strconv.Itoa(report.Cancelled),
+			strconv.Itoa(report.NoShows),
+			strconv.Itoa(report.BookedMinutes),
+			strconv.Itoa(report.OpenMinutes),
+			fmt.Sprintf("%.3f", report.UtilizationRate()),
+		}
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		if err := w.Write(row(date, day.Weekday().String()[:3], stats.Compute(reservations, date, date))); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Write(row("total", "", stats.Compute(reservations, from.Format("2006-01-02"), to.Format("2006-01-02")))); err != nil {
+		return nil, err
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/i18n"
+	"github.com/dice/hxs_reservation_system/internal/logging"
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/stats"
+)
+
+func TestPreviousWeek(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	for _, now := range []time.Time{
+		time.Date(2025, 11, 24, 9, 0, 0, 0, jst),  // 月曜日
+		time.Date(2025, 11, 27, 15, 0, 0, 0, jst), // 木曜日
+		time.Date(2025, 11, 30, 23, 0, 0, 0, jst), // 日曜日
+	} {
+		from, to := previousWeek(now)
+		if from.Format("2006-01-02") != "2025-11-17" || to.Format("2006-01-02") != "2025-11-23" {
+			t.Errorf("%s: expected 2025-11-17 to 2025-11-23, got %s to %s", now.Format("2006-01-02"), from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestRankedUsers(t *testing.T) {
+	report := stats.Compute([]*models.Reservation{
+		{UserID: "u1", Date: "2025-11-17", StartTime: "10:00", EndTime: "14:00", Status: models.StatusCompleted},
+		{UserID: "u2", Date: "2025-11-18", StartTime: "10:00", EndTime: "13:00", Status: models.StatusCompleted},
+		{UserID: "u3", Date: "2025-11-19", StartTime: "10:00", EndTime: "12:00", Status: models.StatusCompleted},
+		{UserID: "u4", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCompleted},
+	}, "2025-11-17", "2025-11-23")
+
+	// 掲載を選んでいないメンバー（u1, u3）は表示しない
+	optedIn := map[string]bool{"u2": true, "u4": true}
+	ranked := rankedUsers(report, func(userID string) bool { return optedIn[userID] }, 5)
+	if len(ranked) != 2 || ranked[0].UserID != "u2" || ranked[1].UserID != "u4" {
+		t.Errorf("Expected only opted-in members in order, got %v", ranked)
+	}
+
+	if ranked := rankedUsers(report, func(string) bool { return true }, 1); len(ranked) != 1 || ranked[0].UserID != "u1" {
+		t.Errorf("Expected the top member only, got %v", ranked)
+	}
+}
+
+func TestWeeklyReportCSV(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	reservations := []*models.Reservation{
+		{UserID: "u1", Date: "2025-11-17", StartTime: "10:00", EndTime: "11:30", Status: models.StatusCompleted},
+		{UserID: "u2", Date: "2025-11-19", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCancelled},
+	}
+
+	data, err := WeeklyReportCSV(reservations, time.Date(2025, 11, 17, 0, 0, 0, 0, jst), time.Date(2025, 11, 23, 0, 0, 0, 0, jst))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 見出し + 7日分 + 合計
+	if len(records) != 9 {
+		t.Fatalf("Expected 9 rows, got %d", len(records))
+	}
+	if got := strings.Join(records[1], ","); got != "2025-11-17,Mon,1,1,0,0,90,720,0.125" {
+		t.Errorf("Unexpected row for Monday: %s", got)
+	}
+	if got := strings.Join(records[8], ","); got != "total,,1,1,1,0,90,5040,0.018" {
+		t.Errorf("Unexpected total row: %s", got)
+	}
+	if strings.Contains(string(data), "u1") {
+		t.Error("CSV should not include member IDs")
+	}
+}
+
+func TestWeeklyReportEmbed(t *testing.T) {
+	report := stats.Compute([]*models.Reservation{
+		{UserID: "u1", Date: "2025-11-17", StartTime: "10:00", EndTime: "12:00", Status: models.StatusCompleted, ReminderSentFor: "2025-11-17 10:00"},
+		{UserID: "u2", Date: "2025-11-18", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCancelled},
+	}, "2025-11-17", "2025-11-23")
+	usage := []commandUsage{
+		{Month: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), Stat: logging.MonthlyStat{TotalCommands: 3, CommandCounts: map[string]int{"list": 3}, UserCounts: map[string]int{"u2": 3}}},
+		{Month: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), Through: 24, Stat: logging.MonthlyStat{TotalCommands: 12, CommandCounts: map[string]int{"reserve": 12}, UserCounts: map[string]int{"u1": 12}}},
+	}
+
+	embed := WeeklyReportEmbed(i18n.Japanese, report, nil, usage)
+	values := map[string]string{}
+	for _, field := range embed.Fields {
+		values[field.Name] = field.Value
+	}
+
+	noShows := i18n.T(i18n.Japanese, "weekly_report.no_shows_value", 1, 100.0)
+	if got := values[i18n.T(i18n.Japanese, "weekly_report.no_shows")]; got != noShows+"\n"+i18n.T(i18n.Japanese, "weekly_report.no_show_rule") {
+		t.Errorf("Unexpected no-shows: %q", got)
+	}
+
+	// リマインダーが無効の場合は無断欠席を数えていないことを示す
+	t.Setenv("REMINDER_MINUTES", "0")
+	for _, field := range WeeklyReportEmbed(i18n.Japanese, report, nil, usage).Fields {
+		if field.Name == i18n.T(i18n.Japanese, "weekly_report.no_shows") && field.Value != noShows+"\n"+i18n.T(i18n.Japanese, "weekly_report.no_show_disabled") {
+			t.Errorf("Expected the disabled note, got %q", field.Value)
+		}
+	}
+	if got := values[i18n.T(i18n.Japanese, "stats.top_bookers")]; !strings.HasPrefix(got, i18n.T(i18n.Japanese, "stats.none")) {
+		t.Errorf("Expected no ranked members, got %q", got)
+	}
+	if got := values[i18n.T(i18n.Japanese, "weekly_report.command_usage", 2025, 10)]; !strings.Contains(got, "`/list` 3") {
+		t.Errorf("Expected full month command usage, got %q", got)
+	}
+	if got := values["⌨️ コマンド利用（2025年11月1日〜24日の途中集計）"]; !strings.Contains(got, "`/reserve` 12") {
+		t.Errorf("Expected month-to-date command usage, got %q", got)
+	}
+}
+
+func TestWeeklyCommandUsage(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	monthly := map[string]logging.MonthlyStat{
+		"2025-10": {TotalCommands: 3},
+		"2025-11": {TotalCommands: 12},
+	}
+
+	// 月の途中の週は今月の途中までの集計だけ
+	from, to := previousWeek(time.Date(2025, 11, 24, 9, 0, 0, 0, jst))
+	usage := weeklyCommandUsage(monthly, from, to, time.Date(2025, 11, 24, 9, 0, 0, 0, jst))
+	if len(usage) != 1 || usage[0].Month.Format("2006-01") != "2025-11" || usage[0].Through != 24 || usage[0].Stat.TotalCommands != 12 {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+
+	// 月をまたぐ週は先月（月全体）と今月（途中まで）の両方
+	from, to = previousWeek(time.Date(2025, 11, 3, 9, 0, 0, 0, jst))
+	usage = weeklyCommandUsage(monthly, from, to, time.Date(2025, 11, 3, 9, 0, 0, 0, jst))
+	if len(usage) != 2 || usage[0].Month.Format("2006-01") != "2025-10" || usage[0].Through != 0 || usage[1].Month.Format("2006-01") != "2025-11" || usage[1].Through != 3 {
+		t.Errorf("Unexpected usage across months: %+v", usage)
+	}
+
+	// 月末の日曜日で終わる週を翌月に投稿する場合は、先月全体の集計
+	from, to = previousWeek(time.Date(2025, 12, 1, 9, 0, 0, 0, jst))
+	usage = weeklyCommandUsage(monthly, from, to, time.Date(2025, 12, 1, 9, 0, 0, 0, jst))
+	if len(usage) != 1 || usage[0].Month.Format("2006-01") != "2025-11" || usage[0].Through != 0 {
+		t.Errorf("Unexpected usage for a finished month: %+v", usage)
+	}
+}
@@ -0,0 +1,520 @@
+package i18n
+
+// en は英語のメッセージカタログ
+var en = map[string]string{
+	// 共通
+	"footer":                  "Room Reservation System  |  %s",
+	"common.error":            "🔴 Error",
+	"common.check_input":      "Please check your input.",
+	"common.channel_only":     "This command can only be used in the allowed channel or DM.",
+	"common.unavailable":      "This action is not available right now.",
+	"common.guild_only":       "This command can only be used in the server.",
+	"common.rate_limited":     "You're running commands too quickly. Please wait a moment and try again.",
+	"common.unexpected_error": "An unexpected error occurred. Please try again later.",
+	"duration.hours_minutes":  "%dh %dm",
+	"duration.hours":          "%dh",
+	"duration.minutes":        "%dm",
+	"weekday.sun":             "Sun",
+	"weekday.mon":             "Mon",
+	"weekday.tue":             "Tue",
+	"weekday.wed":             "Wed",
+	"weekday.thu":             "Thu",
+	"weekday.fri":             "Fri",
+	"weekday.sat":             "Sat",
+
+	// 入力の検証
+	"validation.invalid_date":           "Invalid date (e.g. 2025/11/20, tomorrow, next tuesday)",
+	"validation.invalid_time":           "Invalid time (e.g. 14:00, 2:30pm, 3pm)",
+	"validation.invalid_duration":       "Invalid duration (e.g. 30m, 1h30m, 90)",
+	"validation.invalid_month":          "Invalid month (use YYYY-MM or YYYY/MM)",
+	"validation.invalid_start_time":     "Invalid start time (e.g. 14:00, 2:30pm, 3pm)",
+	"validation.invalid_end_time":       "Invalid end time (e.g. 15:00, 3:30pm, 3pm)",
+	"validation.start_time_required":    "Please enter a start time (you can also include it in the date, e.g. \"tomorrow 2pm\")",
+	"validation.end_time_or_duration":   "Specify either an end time or a duration, not both",
+	"validation.crosses_midnight":       "The reservation cannot run past midnight",
+	"validation.end_before_start":       "The end time must be after the start time (%s)",
+	"validation.past_datetime":          "You cannot book a time in the past (now: %s)",
+	"validation.closed_day":             "%s is a closed day and cannot be booked",
+	"validation.closed_day_reason":      "%s is a closed day (%s) and cannot be booked",
+	"validation.outside_hours":          "Times outside opening hours (%s - %s) are not allowed",
+	"validation.past_date":              "You cannot move a reservation to a past date",
+	"validation.shift_crosses_midnight": "The reservation would run past midnight; please specify an end time",
+	"field.date":                        "📅 Date",
+	"field.start_time":                  "🕐 Start time",
+	"field.end_time":                    "🕐 End time",
+	"field.duration":                    "⏱️ Duration",
+	"field.comment":                     "💬 Comment",
+
+	// 予約の上限
+	"limits.max_active": "You can hold at most %d active reservations (currently %d)",
+	"limits.max_daily":  "You can book at most %s per day (your reservations on %s would total %s)",
+
+	// 予約の共通メッセージ
+	"label.user":                     "👤 Booked by",
+	"label.date":                     "📅 Date",
+	"label.time":                     "🕐 Time",
+	"label.comment":                  "💬 Comment",
+	"label.reservation_id":           "🆔 Reservation ID",
+	"reservation.id_line":            "Reservation ID: `%s`",
+	"reservation.not_found":          "The specified reservation was not found.",
+	"reservation.not_found_check_id": "Reservation not found. Please check the reservation ID.",
+	"reservation.update_failed":      "Failed to update the reservation",
+	"reservation.save_failed":        "Failed to save the reservation",
+	"reservation.invalid_args":       "Invalid reservation data.",
+
+	// /cancel
+	"cancel.not_owner":   "You cannot cancel another member's reservation.",
+	"cancel.not_pending": "Completed or cancelled reservations cannot be cancelled.",
+	"cancel.done":        "🔴 Reservation cancelled",
+	"cancel.announce":    "🔴 A reservation was cancelled",
+
+	// /complete
+	"complete.not_owner":   "You cannot complete another member's reservation.",
+	"complete.not_pending": "Completed or cancelled reservations cannot be completed.",
+	"complete.done":        "🔵 Reservation completed",
+	"complete.announce":    "🔵 A reservation has ended",
+
+	// /edit
+	"edit.not_owner":   "You cannot edit another member's reservation.",
+	"edit.not_pending": "Completed or cancelled reservations cannot be edited.",
+	"edit.no_changes":  "Please specify at least one field to change.",
+	"edit.failed":      "🔴 Could not edit the reservation",
+	"edit.done":        "🟡 Reservation updated",
+	"edit.announce":    "🟡 A reservation was updated",
+	"edit.announce_by": "%s updated their reservation",
+
+	// 予約の共通メッセージ（続き）
+	"common.none":                      "(none)",
+	"reservation.overlap_check_failed": "Failed to check for overlapping reservations",
+	"reservation.time_taken":           "That time is already booked.",
+
+	// /reserve
+	"reserve.failed":      "🔴 Could not make the reservation",
+	"reserve.id_failed":   "Failed to generate a reservation ID",
+	"reserve.overlapping": "📅 Overlapping reservation",
+	"reserve.done":        "🟢 Reservation confirmed!",
+	"reserve.announce":    "🟢 A new reservation was added",
+
+	// /transfer
+	"transfer.accept":                      "Accept",
+	"transfer.decline":                     "Decline",
+	"transfer.not_owner":                   "You cannot transfer another member's reservation.",
+	"transfer.not_pending":                 "Completed or cancelled reservations cannot be transferred.",
+	"transfer.invalid_recipient":           "Invalid recipient.",
+	"transfer.self":                        "You cannot transfer a reservation to yourself.",
+	"transfer.recipient_limit":             "The recipient would exceed their reservation limit: %s",
+	"transfer.offer_save_failed":           "Failed to save the transfer offer",
+	"transfer.offer_title":                 "🔁 Reservation transfer offer",
+	"transfer.offer_description":           "<@%s> would like to transfer a room reservation to you.\nIf you accept, the reservation becomes yours.",
+	"transfer.dm_failed":                   "Could not send a DM to the recipient. Please check that they accept DMs.",
+	"transfer.offered":                     "📨 Transfer offered",
+	"transfer.offered_description":         "The reservation will be transferred once <@%s> accepts.\nUntil then it remains yours.",
+	"transfer.offer_gone":                  "This transfer offer was withdrawn or has already been answered.",
+	"transfer.reservation_closed":          "This reservation has already ended or been cancelled.",
+	"transfer.limit_on_accept":             "You cannot accept because it would exceed your reservation limit: %s",
+	"transfer.declined":                    "Transfer declined",
+	"transfer.declined_description":        "The reservation stays with the member who offered it.",
+	"transfer.declined_notice":             "🔁 Your transfer offer was declined",
+	"transfer.declined_notice_description": "<@%s> declined the transfer. The reservation is still yours.",
+	"transfer.accepted":                    "🟢 Reservation taken over",
+	"transfer.accepted_description":        "This reservation is now yours.",
+	"transfer.accepted_notice":             "🔁 Reservation transferred",
+	"transfer.accepted_notice_description": "<@%s> accepted the transfer.",
+	"transfer.owner_change":                "🔁 New owner",
+	"transfer.announce":                    "🔁 A reservation was transferred",
+
+	// /extend
+	"extend.not_pending":        "Completed or cancelled reservations cannot be changed.",
+	"extend.already_ended":      "This reservation has already ended.",
+	"extend.not_started":        "A reservation that has not started cannot be finished now. Use `/cancel` to cancel it.",
+	"extend.zero":               "Please specify how much to change the end time by.",
+	"extend.before_start":       "You cannot shorten it to before the start time (%s).",
+	"extend.before_now":         "You cannot shorten it to before the current time. Use `by:now` to finish now.",
+	"extend.after_closing":      "You cannot extend past closing time (%s).",
+	"extend.overlap":            "Cannot extend: <@%[2]s> has the room from %[1]s (%[1]s - %[3]s).",
+	"extend.button":             "Extend %s (until %s)",
+	"extend.finish_button":      "Finish",
+	"extend.invalid_by":         "Invalid value (e.g. 30m to extend, -30m to shorten, now to finish now)",
+	"extend.invalid_minutes":    "Invalid extension length.",
+	"extend.not_owner":          "You cannot change another member's reservation.",
+	"extend.done":               "🟡 Reservation extended",
+	"extend.shortened":          "🟡 Reservation shortened",
+	"extend.finished":           "🔵 Reservation finished",
+	"extend.announce":           "🟡 A reservation was extended",
+	"extend.announce_shortened": "🟡 A reservation was shortened",
+	"extend.finish_now":         "Finish now",
+	"extend.extend_by":          "Extend by %s",
+	"extend.shorten_by":         "Shorten by %s",
+	"extend.choice":             "%s (%s - %s)",
+
+	// /reserve-now
+	"reserve_now.busy":              "<@%[3]s> has the room from %[1]s to %[2]s",
+	"reserve_now.closed":            "The room is closed now (open %s - %s)",
+	"reserve_now.closed_day":        "The room is closed today",
+	"reserve_now.closed_day_reason": "The room is closed today (%s)",
+	"reserve_now.after_closing":     "This would run past closing time (%s). Please choose a shorter duration",
+	"reserve_now.too_short":         "The duration is too short. Choose one that lasts past the current time",
+	"reserve_now.button":            "Book now (%s)",
+	"reserve_now.invalid_duration":  "Invalid duration.",
+	"reserve_now.no_free_slot":      "No free slots left today",
+	"reserve_now.free_from":         "You can book %[2]s from %[1]s",
+	"reserve_now.next_free":         "🟢 Next free time",
+	"reserve_now.busy_description":  "The room is in use or already booked during that time.",
+
+	// フォーム
+	"form.unavailable":         "This form is no longer available.",
+	"form.invalid_args":        "Invalid form data.",
+	"form.retry":               "Try again",
+	"form.new_reservation":     "New reservation",
+	"form.reserve_title":       "Book the room",
+	"form.edit_title":          "Edit reservation",
+	"form.date":                "Date (e.g. 2025/11/20, tomorrow, next Tue)",
+	"form.start_time":          "Start time (e.g. 14:00, 2:30pm)",
+	"form.end_time":            "End time (e.g. 15:00, 4pm)",
+	"form.end_time_optional":   "End time (e.g. 15:00) - blank for 1 hour",
+	"form.comment":             "Comment (optional)",
+	"form.reserve_open_failed": "Could not open the reservation form.",
+	"form.edit_open_failed":    "Could not open the edit form.",
+
+	// 予約メッセージのボタン・空き通知
+	"actions.cancel":           "Cancel",
+	"actions.complete":         "Complete",
+	"actions.edit":             "Edit",
+	"actions.watch":            "Notify me if freed",
+	"actions.check_in":         "Check in",
+	"watch.own_reservation":    "You cannot watch your own reservation.",
+	"watch.not_pending":        "This reservation has already ended or been cancelled.",
+	"watch.already":            "You are already watching this reservation.",
+	"watch.failed":             "Failed to register the notification",
+	"watch.done":               "🔔 Notification registered",
+	"watch.done_description":   "We'll DM you if the %s %s - %s slot becomes free.",
+	"watch.notify":             "🔔 The room is free",
+	"watch.notify_description": "A time slot you were watching is now free. Use `/reserve` to book it.",
+
+	// Reminders, check-in and end-of-session prompts
+	"reminder.title":               "⏰ Your reservation starts soon",
+	"reminder.starts_in":           "Your reservation starts in %d minutes.",
+	"reminder.started":             "Your reservation started at %s.",
+	"reminder.hint":                "Press \"Check in\" when you arrive. If you can't make it, press \"Cancel\" so other members can use the room.",
+	"checkin.done":                 "✅ Checked in",
+	"checkin.done_description":     "You checked in to your reservation on %s, %s - %s.",
+	"checkin.not_owner":            "You can't check in to another member's reservation.",
+	"checkin.not_pending":          "You can't check in to a completed or cancelled reservation.",
+	"checkin.already":              "You have already checked in to this reservation.",
+	"checkin.ended":                "This reservation has already ended, so you can't check in.",
+	"checkin.too_early":            "You can check in from %d minutes before the start.",
+	"end_prompt.title":             "⌛ Your reservation ends soon",
+	"end_prompt.description":       "Your reservation ends in %d minutes (%s).",
+	"end_prompt.hint":              "Press an extend button to keep using the room, or \"Finish\" if you're done so other members can use it right away.",
+	"session_end.announce":         "🟢 The room is free",
+	"session_end.free_until":       "The next reservation starts at %s.",
+	"session_end.free_rest_of_day": "No more reservations today.",
+
+	// 予約一覧
+	"list.status.completed": "completed",
+	"list.status.cancelled": "cancelled",
+	"list.status.past":      "completed or cancelled",
+	"list.status.all":       "all",
+	"list.condition_status": "Status: %s",
+	"list.condition_period": "Period: %s - %s",
+	"list.condition_user":   "Member: <@%s>",
+	"list.title_all":        "⚫ All reservations",
+	"list.title_mine":       "⚪ Your reservations",
+	"list.title_member":     "👤 Member's reservations",
+	"list.empty":            "There are no reservations.",
+	"list.empty_mine":       "You have no reservations.",
+	"list.empty_filtered":   "No reservations match the filter.",
+	"list.empty_member":     "<@%s> has no upcoming reservations.",
+	"list.count":            "%d reservation(s)",
+	"list.count_member":     "<@%s> has %d upcoming reservation(s)",
+	"list.footer_page":      "Room Reservation System  |  %s  |  Page %d/%d",
+	"list.footer_item":      "Room Reservation System  |  %s  |  Reservation %d/%d",
+	"list.previous":         "◀ Prev",
+	"list.next":             "Next ▶",
+	"list.invalid_page":     "Invalid page. Please run the command again.",
+
+	// 管理者コマンド
+	"status.pending":              "Booked",
+	"status.completed":            "Completed",
+	"status.cancelled":            "Cancelled",
+	"admin.permission_denied":     "You don't have permission to run this command.",
+	"admin.subcommand_required":   "Please specify a subcommand.",
+	"admin.unknown_subcommand":    "Unknown subcommand.",
+	"admin.already_cancelled":     "This reservation is already cancelled.",
+	"admin.already_pending":       "This reservation is already active.",
+	"admin.cancel.done":           "🔴 Reservation cancelled (admin)",
+	"admin.cancel.announce":       "🔴 A reservation was cancelled by an admin",
+	"admin.edit.changes":          "📝 Changes",
+	"admin.edit.done":             "🟡 Reservation edited (admin)",
+	"admin.edit.announce":         "🟡 A reservation was edited by an admin",
+	"admin.edit.override_hint":    "Set `override: True` to move the reservation to a closed day or outside opening hours.",
+	"admin.reopen.failed":         "🔴 Could not reopen the reservation",
+	"admin.reopen.overlap":        "Another reservation exists in the same time slot.",
+	"admin.reopen.done":           "🟢 Reservation reopened (admin)",
+	"admin.reopen.announce":       "🟢 A reservation was reopened by an admin",
+	"admin.reassign.invalid_user": "Invalid user to assign the reservation to.",
+	"admin.reassign.same_owner":   "That user already owns this reservation.",
+	"admin.reassign.not_pending":  "Only pending reservations can be reassigned.",
+	"admin.reassign.changes":      "🔁 Owner change",
+	"admin.reassign.done":         "🟡 Reservation owner changed (admin)",
+	"admin.reassign.announce":     "🟡 A reservation's owner was changed by an admin",
+	"admin.list.title":            "🛡️ Reservations (admin)",
+	"admin.list.more":             "…and %d more (narrow the filter)",
+	"admin.list.footer":           "Room Reservation System  |  admin list  |  %d total",
+	"admin.audit.admin":           "🛡️ Admin",
+	"admin.audit.reason":          "📝 Reason",
+	"admin.audit.title":           "🛡️ Admin action: %s",
+
+	// オートコンプリート
+	"date.today":                 "Today %s",
+	"date.tomorrow":              "Tomorrow %s",
+	"date.day_after_tomorrow":    "In 2 days %s",
+	"date.weeks_later":           "In %d week(s) %s",
+	"date.free":                  "%sh free",
+	"date.full":                  "Fully booked",
+	"date.closed":                "Closed",
+	"date.closed_reason":         "Closed (%s)",
+	"date.no_more_today":         "No more slots today",
+	"date.free_keyword":          "free",
+	"duration.until":             "%s (until %s)",
+	"duration.until_overlapping": "%s (until %s, overlaps a reservation)",
+	"duration.until_free_end":    "Until the end of the free slot %s",
+	"time.taken":                 "%s (booked by %s, %s-%s)",
+	"time.until_next":            "%s (next reservation starts)",
+
+	// /availability
+	"availability.past_date":         "Availability cannot be shown for past dates.",
+	"availability.title":             "🟢 Availability  %s",
+	"availability.opening_hours":     "Opening hours: %s - %s",
+	"availability.none":              "There are no free time slots.",
+	"availability.closed":            "%s — no reservations can be made on this day.",
+	"availability.none_for_duration": "There are no free slots of %s or longer.",
+	"availability.for_duration":      "Free slots of %s or longer",
+	"availability.slot":              "🕐 **%s - %s** (%s)",
+	"availability.book_hint":         "Press a button to open the reservation form for that slot.",
+	"availability.book_button":       "Book %s-%s",
+	"availability.invalid_button":    "Invalid button data. Please run /availability again.",
+	"availability.today_button":      "Today's availability",
+
+	// Channel board
+	"board.title":             "📋 Room reservations",
+	"board.hint":              "Use the buttons below to book or check availability.",
+	"board.status_closed":     "⛔ Today: %s",
+	"board.status_in_use":     "🔴 In use — %s (until %s)",
+	"board.status_outside":    "🌙 Outside opening hours (open %s - %s)",
+	"board.status_free_until": "🟢 Free (next reservation at %s)",
+	"board.status_free":       "🟢 Free (no more reservations today)",
+	"board.now_marker":        "▶ ",
+	"board.today":             "📅 Today %s",
+	"board.upcoming":          "🗓️ Upcoming (next %d days)",
+	"board.no_reservations":   "No reservations",
+	"board.more":              "…and %d more",
+	"board.footer":            "Room Reservation System  |  Updates automatically when reservations change",
+	"digest.title":            "☀️ The room today — %s",
+	"digest.description":      "Open %s - %s",
+	"digest.reservations":     "📅 Today's reservations (%d)",
+	"digest.free_slots":       "🟢 Free times",
+	"digest.no_free_slots":    "No free times today",
+	"digest.closures":         "⛔ Upcoming closures (next %d days)",
+	"digest.yesterday":        "📊 Yesterday",
+	"digest.yesterday_counts": "No-shows: %d / Cancellations: %d",
+	"digest.footer":           "Room Reservation System  |  Daily digest",
+
+	// /schedule
+	"schedule.render_failed": "Failed to render the timeline image",
+	"schedule.today_button":  "Timeline",
+	"schedule.title_day":     "📊 Timeline  %s",
+	"schedule.title_week":    "📊 Weekly timeline  %s - %s",
+	"schedule.empty":         "No reservations in this period.",
+	"schedule.more":          "…and %d more",
+	"schedule.legend":        "🟩 Booked  🟦 Completed  🟥 Cancelled (outlined)",
+
+	// /stats
+	"stats.load_failed":       "Failed to load statistics",
+	"stats.bookings":          "📅 Bookings",
+	"stats.bookings_value":    "%d (%d completed)",
+	"stats.cancelled":         "🚫 Cancellations",
+	"stats.cancelled_value":   "%d (%.0f%%)",
+	"stats.utilization":       "🏠 Utilization",
+	"stats.utilization_value": "%.1f%% (%s / %s open)",
+	"stats.busiest_weekdays":  "📆 Busiest weekdays",
+	"stats.weekday":           "%s",
+	"stats.busiest_hours":     "🕐 Busiest hours",
+	"stats.hour":              "%d:00",
+	"stats.command_usage":     "⌨️ Command usage",
+	"stats.top_booker":        "%d. <@%s> %s (%d bookings)",
+	"stats.top_bookers":       "👤 Members with the most booked time",
+	"stats.top_command_users": "👤 Most active command users",
+	"stats.command_user":      "<@%s> %d times",
+	"stats.period":            "Period: %s - %s (including archived reservations)",
+	"stats.admin_only":        "Per-member breakdowns are shown to admins only.",
+	"stats.footer":            "Room Reservation System  |  stats  |  %d commands total",
+	"stats.title":             "📈 Usage statistics  %d/%02d",
+	"stats.no_records":        "No records",
+	"stats.command_total":     "%d commands by %d members",
+	"stats.none":              "None",
+
+	// Weekly report
+	"weekly_report.title":                 "📊 Weekly report",
+	"weekly_report.no_shows":              "🙈 No-shows",
+	"weekly_report.no_shows_value":        "%d (%.0f%% of completed)",
+	"weekly_report.no_show_rule":          "No-shows are reservations that got a check-in reminder but ended without a check-in, extension or finish",
+	"weekly_report.no_show_disabled":      "Reminders are disabled (`REMINDER_MINUTES=0`), so no check-in is requested and no-shows are not counted",
+	"weekly_report.ranking_hint":          "Only members who opted in with `/ranking` are listed",
+	"weekly_report.command_usage":         "⌨️ Command usage (%d-%02d)",
+	"weekly_report.command_usage_to_date": "⌨️ Command usage (%d-%02d, month to date through day %d)",
+	"weekly_report.footer":                "Room Reservation System  |  Weekly report every Monday  |  See the CSV for daily figures",
+
+	// /history
+	"history.admin_only":      "Only admins can view other members' history.",
+	"history.load_failed":     "Failed to load history",
+	"history.all_periods":     "Period: all",
+	"history.more":            "…and %d more (narrow with a date range)",
+	"history.total":           "📋 Reservations",
+	"history.count":           "%d",
+	"history.used_time":       "⏱️ Time used (completed)",
+	"history.used_time_value": "%s (%d)",
+	"history.pending":         "📅 Booked",
+	"history.title":           "📜 Reservation history",
+	"history.hours":           "%sh",
+
+	// /feedback
+	"feedback.empty":            "Please enter your feedback",
+	"feedback.not_configured":   "No feedback channel is configured. Please contact an admin.",
+	"feedback.received":         "💬 New feedback",
+	"feedback.footer":           "Room Reservation System  |  feedback  |  Received: %s",
+	"feedback.failed":           "Failed to send feedback. Please contact an admin.",
+	"feedback.done":             "✅ Feedback sent",
+	"feedback.done_description": "Thank you for your feedback.\nIt was delivered anonymously to the team.\n\nWe'll use it to improve the system.",
+
+	// /help（コマンドごとの説明は Command.Help のキーで指定し、Registry.HelpMessage で組み立てる。全体で Discordのメッセージの上限 2000 文字以内）
+	"help.header":            "# 📖Room Reservation System - Help\n## Commands:\n",
+	"help.admin_only":        " (admins only)",
+	"help.reserve":           "> Book the room\n> - `date`: e.g. 2025/10/15, tomorrow, next Tue\n> - `start_time`: e.g. 14:00, 2:30pm\n> - `end_time` / `duration`: e.g. 1h30m (default 1 hour)\n> - `comment`: optional",
+	"help.reserve_now":       "> Book from now (default 1 hour)",
+	"help.reserve_form":      "> Book with a form prefilled with the next free slot",
+	"help.edit":              "> Edit a reservation's date, time or comment",
+	"help.extend":            "> Change the end time (`by`: 30m, -30m, now)",
+	"help.transfer":          "> Hand a reservation to another member (they accept via DM)",
+	"help.cancel":            "> Cancel a reservation (`reservation_id`, optional `comment`)",
+	"help.complete":          "> Mark a reservation as done (`reservation_id`, optional `comment`)\n> - The buttons on reservation messages work too",
+	"help.list":              "> All reservations\n> - `user` / `status` / `from` / `to`: optional filters",
+	"help.my_reservations":   "> Your reservations (same filters)",
+	"help.view_reservations": "> Right-click a member → Apps to see their reservations",
+	"help.history":           "> Your past reservations and total time used",
+	"help.stats":             "> Monthly usage and room utilization (`month`: optional)",
+	"help.availability":      "> Free time on a `date` (optional `duration`, e.g. 1h30m)\n> - The slot buttons open a prefilled form",
+	"help.schedule":          "> Timeline image (`view`: day / week, `date`: optional)",
+	"help.feedback":          "> Send anonymous feedback",
+	"help.help":              "> Show this help",
+	"help.language":          "> Change the language (日本語 / English / auto)",
+	"help.ranking":           "> Choose whether your name appears in the weekly report's usage ranking (`show`, hidden by default)",
+	"help.admin":             "> Manage everyone's reservations (`cancel` / `edit` / `reopen` / `reassign` / `list`)\n> - Changes need a `reason` and are logged to the audit channel",
+	"help.footer": "## Privacy:\n" +
+		"- Lists, stats, /help and /feedback are only shown to you\n" +
+		"- Reservation IDs are sent only to the person who booked\n" +
+		"- Feedback is fully anonymous\n\n" +
+		"## Data:\n" +
+		"- Finished reservations are deleted after 30 days\n" +
+		"- Expired reservations are completed at 3:00 daily\n\n" +
+		"## Where to use:\n" +
+		"- https://discord.com/channels/1090816023965479035/1375843736864559195\n" +
+		"- Or in DMs once you have authorized the app\n\n" +
+		"## Authorization:\n" +
+		"Authorize [here](https://discord.com/oauth2/authorize?client_id=1425303718882185237)\n" +
+		"## Support:\n" +
+		"- Report problems via /feedback\n",
+
+	// コマンド定義（説明・選択肢）。キーは internal/commands の localizeCommand を参照
+	"command.reserve":                 "Book the club room",
+	"command.reserve.date":            "Date (e.g. 2025/10/15, tomorrow, next Tue, tomorrow 2pm)",
+	"command.reserve.start_time":      "Start time (e.g. 14:00, 2:30pm). Optional if the date includes a time",
+	"command.reserve.end_time":        "End time (e.g. 15:00, 4pm). Defaults to start + duration (1 hour)",
+	"command.reserve.duration":        "Duration (e.g. 30m, 1h30m, 90) instead of end_time",
+	"command.reserve-now":             "Book the room from now (start rounded down to 30 minutes)",
+	"command.reserve-now.duration":    "Duration (e.g. 30m, 1h30m). Defaults to 1 hour",
+	"command.reserve-form":            "Book the room with a form (prefilled with the next free slot)",
+	"command.cancel":                  "Cancel a reservation",
+	"command.complete":                "Mark a reservation as completed",
+	"command.edit":                    "Edit a reservation",
+	"command.edit.date":               "New date (e.g. 2025/10/15, tomorrow). Omit to keep",
+	"command.edit.start_time":         "New start time (e.g. 14:00, 2:30pm). Omit to keep",
+	"command.edit.end_time":           "New end time (e.g. 15:00, 4pm). Omit to keep",
+	"command.edit.duration":           "New duration (e.g. 30m, 1h30m) instead of end_time",
+	"command.edit.comment":            "New comment. Omit to keep",
+	"command.extend":                  "Extend or shorten a reservation (or finish it now)",
+	"command.extend.reservation_id":   "Reservation ID to change",
+	"command.extend.by":               "Change (e.g. 30m to extend, -30m to shorten, now to finish)",
+	"command.transfer":                "Hand your reservation to another member (after they accept)",
+	"command.transfer.reservation_id": "Reservation ID to transfer",
+	"command.transfer.to":             "Member to transfer to",
+	"command.list":                    "Show all reservations (only visible to you)",
+	"command.my-reservations":         "Show your reservations (only visible to you)",
+	"command.history":                 "Show your history and total time used, including past reservations",
+	"command.history.status":          "Filter by status (all if omitted)",
+	"command.history.user":            "Member whose history to show (admins only)",
+	"command.stats":                   "Show monthly command usage and room utilization (only visible to you)",
+	"command.stats.month":             "Month (YYYY-MM or YYYY/MM, defaults to this month)",
+	"command.availability":            "Show free time on a date (only visible to you)",
+	"command.availability.date":       "Date (YYYY-MM-DD or YYYY/MM/DD)",
+	"command.availability.duration":   "Length needed (e.g. 30m, 1h30m, 90). Shows only slots at least this long",
+	"command.schedule":                "Show a timeline image of reservations (only visible to you)",
+	"command.schedule.view":           "Range (day if omitted)",
+	"command.schedule.view.day":       "Day",
+	"command.schedule.view.week":      "Week",
+	"command.schedule.date":           "Date (YYYY-MM-DD or YYYY/MM/DD), defaults to today",
+	"command.help":                    "Show the help message (only visible to you)",
+	"command.feedback":                "Send anonymous feedback about the system",
+	"command.feedback.message":        "Your feedback",
+	"command.view_reservations":       "View reservations",
+	"command.admin":                   "Reservation management for admins",
+	"command.admin.reservation_id":    "Reservation ID (searches all members' reservations)",
+	"command.admin.reason":            "Reason (recorded in the audit channel)",
+	"command.admin.cancel":            "Cancel any member's reservation",
+	"command.admin.edit":              "Edit any member's reservation",
+	"command.admin.edit.date":         "New date (YYYY-MM-DD or YYYY/MM/DD). Omit to keep",
+	"command.admin.edit.start_time":   "New start time (HH:MM). Omit to keep",
+	"command.admin.edit.end_time":     "New end time (HH:MM). Omit to keep",
+	"command.admin.edit.comment":      "New comment. Omit to keep",
+	"command.admin.edit.override":     "Allow moving to a closed day or outside opening hours (default: False)",
+	"command.admin.reopen":            "Reopen a completed or cancelled reservation",
+	"command.admin.reassign":          "Assign a reservation to another member",
+	"command.admin.reassign.user":     "New owner",
+	"command.admin.list":              "Show all members' reservations with filters",
+	"command.admin.list.status":       "Filter by status (optional)",
+	"option.reservation_id":           "Reservation ID",
+	"option.comment":                  "Comment (optional)",
+	"option.user":                     "Filter by member (optional)",
+	"option.status":                   "Filter by status (active only if omitted)",
+	"option.status.active":            "Active",
+	"option.status.pending":           "Active",
+	"option.status.completed":         "Completed",
+	"option.status.cancelled":         "Cancelled",
+	"option.status.past":              "Past (completed or cancelled)",
+	"option.status.all":               "All",
+	"option.from":                     "On or after this date (YYYY-MM-DD or YYYY/MM/DD, optional)",
+	"option.to":                       "On or before this date (YYYY-MM-DD or YYYY/MM/DD, optional)",
+
+	// /language
+	"language.title":             "🌐 Display language",
+	"language.name":              "English",
+	"language.auto":              "Follow your Discord language",
+	"language.current":           "Current display language: %s",
+	"language.set":               "Display language set to %s.",
+	"language.reset":             "The display language now follows your Discord language setting.",
+	"language.invalid":           "Unsupported language.",
+	"language.save_failed":       "Failed to save the display language",
+	"command.language":           "Change the display language (only visible to you)",
+	"command.language.lang":      "Language (omit to show the current setting)",
+	"command.language.lang.ja":   "日本語",
+	"command.language.lang.en":   "English",
+	"command.language.lang.auto": "Auto (follow Discord)",
+
+	// /ranking
+	"ranking.title":        "🏆 Ranking",
+	"ranking.shown":        "Your name will appear in the weekly report's usage ranking.",
+	"ranking.hidden":       "Your name will not appear in the weekly report's usage ranking.",
+	"ranking.save_failed":  "Failed to save the setting",
+	"command.ranking":      "Choose whether your name appears in the weekly report's ranking (only visible to you)",
+	"command.ranking.show": "Show your name in the ranking (omit to show the current setting)",
+}
@@ -0,0 +1,96 @@
+// Package i18n はユーザーに表示するメッセージの翻訳（メッセージカタログ）を扱う
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// 対応している言語
+const (
+	Japanese = "ja"
+	English  = "en"
+)
+
+// catalogs は言語ごとのメッセージカタログ（キー → メッセージ）
+// メッセージは fmt の書式で、引数の順序が言語によって異なる場合は %[1]s のように番号で指定する
+var catalogs = map[string]map[string]string{
+	Japanese: ja,
+	English:  en,
+}
+
+// Locales は対応している言語を返す
+func Locales() []string {
+	return []string{Japanese, English}
+}
+
+// IsSupported は対応している言語かどうかを返す
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// DefaultLocale はチャンネルへの通知など、相手の言語が分からない場合に使う言語を返す
+// 環境変数 DEFAULT_LOCALE で変更でき、未設定または未対応の場合は日本語
+func DefaultLocale() string {
+	if locale := os.Getenv("DEFAULT_LOCALE"); IsSupported(locale) {
+		return locale
+	}
+	return Japanese
+}
+
+// FromDiscord はDiscordの言語設定から表示に使う言語を返す
+// 日本語以外の言語は英語として扱い、空の場合は DefaultLocale を返す
+func FromDiscord(locale discordgo.Locale) string {
+	switch {
+	case locale == "":
+		return DefaultLocale()
+	case locale == discordgo.Japanese:
+		return Japanese
+	default:
+		return English
+	}
+}
+
+// Has はメッセージカタログにキーがあるかどうかを返す
+func Has(key string) bool {
+	_, ok := catalogs[Japanese][key]
+	return ok
+}
+
+// T は指定した言語のメッセージを返す
+// キーが見つからない場合は日本語のメッセージ、それもない場合はキーをそのまま返す
+func T(locale, key string, args ...interface{}) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[Japanese][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Weekday は曜日の短い表記（「月」「Mon」など）を返す
+func Weekday(locale string, weekday time.Weekday) string {
+	return T(locale, weekdayKeys[weekday])
+}
+
+var weekdayKeys = [...]string{
+	"weekday.sun", "weekday.mon", "weekday.tue", "weekday.wed", "weekday.thu", "weekday.fri", "weekday.sat",
+}
+
+// Localizations はコマンド定義の NameLocalizations・DescriptionLocalizations に設定する言語ごとのメッセージを返す
+func Localizations(key string) map[discordgo.Locale]string {
+	return map[discordgo.Locale]string{
+		discordgo.Japanese:  T(Japanese, key),
+		discordgo.EnglishUS: T(English, key),
+		discordgo.EnglishGB: T(English, key),
+	}
+}
@@ -0,0 +1,181 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for _, locale := range Locales() {
+		for _, other := range Locales() {
+			for key := range catalogs[other] {
+				if _, ok := catalogs[locale][key]; !ok {
+					t.Errorf("Key %q exists in %q but is missing from %q", key, other, locale)
+				}
+			}
+		}
+	}
+}
+
+// formatVerb は fmt の書式指定（%% を除く）にマッチする
+var formatVerb = regexp.MustCompile(`%(\[(\d+)\])?[-+# 0]*\d*(\.\d+)?([a-zA-Z%])`)
+
+// formatArgs はメッセージが使う引数の数を返す
+func formatArgs(message string) int {
+	count, next := 0, 1
+	for _, m := range formatVerb.FindAllStringSubmatch(message, -1) {
+		if m[4] == "%" {
+			continue
+		}
+		if m[2] != "" {
+			next, _ = strconv.Atoi(m[2])
+		}
+		if next > count {
+			count = next
+		}
+		next++
+	}
+	return count
+}
+
+func TestCatalogsUseSameArguments(t *testing.T) {
+	for key, message := range catalogs[Japanese] {
+		want := formatArgs(message)
+		for _, locale := range Locales() {
+			if got := formatArgs(catalogs[locale][key]); got != want {
+				t.Errorf("%s: %q uses %d argument(s), but %q uses %d", key, locale, got, Japanese, want)
+			}
+		}
+	}
+}
+
+// keyArgument は呼び出しのうちメッセージカタログのキーを受け取る引数の位置（関数名 → 位置）
+var keyArgument = map[string]int{
+	"tr":              1,
+	"T":               1,
+	"newError":        0,
+	"Localizations":   0,
+	"localizationsOf": 0,
+	"Has":             0,
+}
+
+// TestKeysUsedInSourceExist はメッセージカタログのキーを文字列リテラルで渡している箇所を探し、
+// どの言語のカタログにもそのキーがあることを確認する
+func TestKeysUsedInSourceExist(t *testing.T) {
+	var files []string
+	for _, dir := range []string{"../commands", "../../cmd/bot"} {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+
+	used := make(map[string][]string)
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := parser.ParseFile(fset, path, src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addKey := func(expr ast.Expr) {
+			lit, ok := expr.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return
+			}
+			if key, err := strconv.Unquote(lit.Value); err == nil {
+				used[key] = append(used[key], fset.Position(lit.Pos()).String())
+			}
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			// commands.Command の Help フィールド
+			if kv, ok := n.(*ast.KeyValueExpr); ok {
+				if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == "Help" {
+					addKey(kv.Value)
+				}
+				return true
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var name string
+			switch fn := call.Fun.(type) {
+			case *ast.Ident:
+				name = fn.Name
+			case *ast.SelectorExpr:
+				name = fn.Sel.Name
+			}
+			if idx, ok := keyArgument[name]; ok && idx < len(call.Args) {
+				addKey(call.Args[idx])
+			}
+			return true
+		})
+	}
+
+	if len(used) == 0 {
+		t.Fatal("No message keys found in source; the scanned directories may have moved")
+	}
+
+	keys := make([]string, 0, len(used))
+	for key := range used {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, locale := range Locales() {
+			if _, ok := catalogs[locale][key]; !ok {
+				t.Errorf("Key %q used at %s is missing from %q", key, used[key][0], locale)
+			}
+		}
+	}
+}
+
+func TestFromDiscord(t *testing.T) {
+	t.Setenv("DEFAULT_LOCALE", "")
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"ja", Japanese},
+		{"en-US", English},
+		{"ko", English},
+		{"", Japanese},
+	}
+	for _, tt := range tests {
+		if got := FromDiscord(discordgo.Locale(tt.locale)); got != tt.want {
+			t.Errorf("FromDiscord(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+
+	t.Setenv("DEFAULT_LOCALE", English)
+	if got := FromDiscord(""); got != English {
+		t.Errorf("Expected DEFAULT_LOCALE to be used for empty locale, got %q", got)
+	}
+}
+
+func TestTFallsBack(t *testing.T) {
+	if got := T("fr", "common.error"); got != catalogs[Japanese]["common.error"] {
+		t.Errorf("Expected Japanese fallback for unsupported locale, got %q", got)
+	}
+	if got := T(English, "no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected key for missing message, got %q", got)
+	}
+}
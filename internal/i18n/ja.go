@@ -0,0 +1,520 @@
+package i18n
+
+// ja は日本語のメッセージカタログ
+var ja = map[string]string{
+	// 共通
+	"footer":                  "部室予約システム  |  %s",
+	"common.error":            "🔴 エラー",
+	"common.check_input":      "入力内容を確認してください。",
+	"common.channel_only":     "このコマンドは指定チャンネルかDMでのみ使用できます。",
+	"common.unavailable":      "この操作は現在利用できません。",
+	"common.guild_only":       "このコマンドはサーバー内でのみ使用できます。",
+	"common.rate_limited":     "コマンドの実行が続いています。少し待ってからもう一度お試しください。",
+	"common.unexpected_error": "予期しないエラーが発生しました。時間をおいてもう一度お試しください。",
+	"duration.hours_minutes":  "%d時間%d分",
+	"duration.hours":          "%d時間",
+	"duration.minutes":        "%d分",
+	"weekday.sun":             "日",
+	"weekday.mon":             "月",
+	"weekday.tue":             "火",
+	"weekday.wed":             "水",
+	"weekday.thu":             "木",
+	"weekday.fri":             "金",
+	"weekday.sat":             "土",
+
+	// 入力の検証
+	"validation.invalid_date":           "日付の形式が正しくありません（例: 2025/11/20、明日、来週の火曜）",
+	"validation.invalid_time":           "時刻の形式が正しくありません（例: 14:00、14時半、3pm）",
+	"validation.invalid_duration":       "時間の長さの形式が正しくありません（例: 30分、1時間半、90m）",
+	"validation.invalid_month":          "月の形式が正しくありません（YYYY-MM または YYYY/MM 形式で入力してください）",
+	"validation.invalid_start_time":     "開始時間の形式が正しくありません（例: 14:00、14時半、3pm）",
+	"validation.invalid_end_time":       "終了時間の形式が正しくありません（例: 15:00、15時半、3pm）",
+	"validation.start_time_required":    "開始時間を入力してください（日付に「明日14時」のように含めることもできます）",
+	"validation.end_time_or_duration":   "終了時間と利用時間はどちらか一方だけを指定してください",
+	"validation.crosses_midnight":       "終了時刻が日付をまたぐため予約できません",
+	"validation.end_before_start":       "終了時刻は開始時刻（%s）より後である必要があります",
+	"validation.past_datetime":          "過去の日時は予約できません（現在日時: %s）",
+	"validation.closed_day":             "%s は休室日のため予約できません",
+	"validation.closed_day_reason":      "%s は休室日（%s）のため予約できません",
+	"validation.outside_hours":          "開室時間（%s - %s）外の時間帯は指定できません",
+	"validation.past_date":              "過去の日付には変更できません",
+	"validation.shift_crosses_midnight": "予約が日付をまたぐため、終了時間を指定してください",
+	"field.date":                        "📅 予約日",
+	"field.start_time":                  "🕐 開始時間",
+	"field.end_time":                    "🕐 終了時間",
+	"field.duration":                    "⏱️ 利用時間",
+	"field.comment":                     "💬 コメント",
+
+	// 予約の上限
+	"limits.max_active": "予約中の予約は1人 %d 件までです（現在 %d 件）",
+	"limits.max_daily":  "1日に予約できるのは1人 %s までです（%s の予約が合計 %s になります）",
+
+	// 予約の共通メッセージ
+	"label.user":                     "👤 予約者",
+	"label.date":                     "📅 日付",
+	"label.time":                     "🕐 時間",
+	"label.comment":                  "💬 コメント",
+	"label.reservation_id":           "🆔 予約ID",
+	"reservation.id_line":            "予約ID: `%s`",
+	"reservation.not_found":          "指定された予約が見つかりません。",
+	"reservation.not_found_check_id": "予約が見つかりませんでした。予約IDを確認してください。",
+	"reservation.update_failed":      "予約の更新に失敗しました",
+	"reservation.save_failed":        "予約の保存に失敗しました",
+	"reservation.invalid_args":       "予約情報が正しくありません。",
+
+	// /cancel
+	"cancel.not_owner":   "他のユーザーの予約は取り消せません。",
+	"cancel.not_pending": "完了またはキャンセルされた予約は取り消せません。",
+	"cancel.done":        "🔴 予約を取り消しました",
+	"cancel.announce":    "🔴 予約が取り消されました",
+
+	// /complete
+	"complete.not_owner":   "他のユーザーの予約は完了にできません。",
+	"complete.not_pending": "完了またはキャンセルされた予約は完了にできません。",
+	"complete.done":        "🔵 予約を完了にしました",
+	"complete.announce":    "🔵 予約が終わりました",
+
+	// /edit
+	"edit.not_owner":   "他のユーザーの予約は編集できません。",
+	"edit.not_pending": "完了またはキャンセルされた予約は編集できません。",
+	"edit.no_changes":  "変更する項目を少なくとも1つ指定してください。",
+	"edit.failed":      "🔴 予約を編集できませんでした",
+	"edit.done":        "🟡 予約を編集しました",
+	"edit.announce":    "🟡 予約が編集されました",
+	"edit.announce_by": "%s さんが予約を編集しました",
+
+	// 予約の共通メッセージ（続き）
+	"common.none":                      "（なし）",
+	"reservation.overlap_check_failed": "予約の重複チェックに失敗しました",
+	"reservation.time_taken":           "指定された時間は既に予約されています。",
+
+	// /reserve
+	"reserve.failed":      "🔴 予約できませんでした",
+	"reserve.id_failed":   "予約IDの生成に失敗しました",
+	"reserve.overlapping": "📅 重複している予約",
+	"reserve.done":        "🟢 予約が完了しました！",
+	"reserve.announce":    "🟢 新しい予約が追加されました",
+
+	// /transfer
+	"transfer.accept":                      "承諾する",
+	"transfer.decline":                     "辞退する",
+	"transfer.not_owner":                   "他のユーザーの予約は譲渡できません。",
+	"transfer.not_pending":                 "完了またはキャンセルされた予約は譲渡できません。",
+	"transfer.invalid_recipient":           "譲渡先のユーザーが正しくありません。",
+	"transfer.self":                        "自分自身には譲渡できません。",
+	"transfer.recipient_limit":             "譲渡先の予約が上限を超えるため譲渡できません: %s",
+	"transfer.offer_save_failed":           "譲渡の申し出の保存に失敗しました",
+	"transfer.offer_title":                 "🔁 予約の譲渡の申し出",
+	"transfer.offer_description":           "<@%s> さんから部室の予約を譲りたいという申し出がありました。\n承諾すると、この予約はあなたの予約になります。",
+	"transfer.dm_failed":                   "譲渡先にDMを送れませんでした。相手がDMを受け付けているか確認してください。",
+	"transfer.offered":                     "📨 譲渡を申し出ました",
+	"transfer.offered_description":         "<@%s> さんが承諾すると、予約が譲渡されます。\n承諾されるまでは引き続きあなたの予約です。",
+	"transfer.offer_gone":                  "この譲渡の申し出は取り消されたか、既に対応済みです。",
+	"transfer.reservation_closed":          "この予約は既に終了または取り消されています。",
+	"transfer.limit_on_accept":             "予約の上限を超えるため承諾できません: %s",
+	"transfer.declined":                    "譲渡を辞退しました",
+	"transfer.declined_description":        "予約は引き続き申し出たメンバーのものです。",
+	"transfer.declined_notice":             "🔁 予約の譲渡が辞退されました",
+	"transfer.declined_notice_description": "<@%s> さんが予約の譲渡を辞退しました。予約は引き続きあなたのものです。",
+	"transfer.accepted":                    "🟢 予約を引き継ぎました",
+	"transfer.accepted_description":        "この予約はあなたの予約になりました。",
+	"transfer.accepted_notice":             "🔁 予約を譲渡しました",
+	"transfer.accepted_notice_description": "<@%s> さんが予約の譲渡を承諾しました。",
+	"transfer.owner_change":                "🔁 予約者の変更",
+	"transfer.announce":                    "🔁 予約が譲渡されました",
+
+	// /extend
+	"extend.not_pending":        "完了またはキャンセルされた予約は変更できません。",
+	"extend.already_ended":      "終了時刻を過ぎた予約は変更できません。",
+	"extend.not_started":        "まだ始まっていない予約は今すぐ終了できません。取り消す場合は `/cancel` を使ってください。",
+	"extend.zero":               "変更する長さを指定してください。",
+	"extend.before_start":       "開始時刻（%s）より前には短縮できません。",
+	"extend.before_now":         "現在時刻より前には短縮できません。今すぐ終わる場合は `by:now` を指定してください。",
+	"extend.after_closing":      "閉室時刻（%s）を超えて延長することはできません。",
+	"extend.overlap":            "%[1]s から <@%[2]s> さんの予約（%[1]s - %[3]s）があるため延長できません。",
+	"extend.button":             "%s延長（%sまで）",
+	"extend.finish_button":      "終了する",
+	"extend.invalid_by":         "変更する長さの形式が正しくありません（例: 30m で延長、-30m で短縮、now で今すぐ終了）",
+	"extend.invalid_minutes":    "延長する長さが正しくありません。",
+	"extend.not_owner":          "他のユーザーの予約は変更できません。",
+	"extend.done":               "🟡 予約を延長しました",
+	"extend.shortened":          "🟡 予約を短縮しました",
+	"extend.finished":           "🔵 予約を終了しました",
+	"extend.announce":           "🟡 予約が延長されました",
+	"extend.announce_shortened": "🟡 予約が短縮されました",
+	"extend.finish_now":         "今すぐ終了",
+	"extend.extend_by":          "%s延長",
+	"extend.shorten_by":         "%s短縮",
+	"extend.choice":             "%s（%s - %s）",
+
+	// /reserve-now
+	"reserve_now.busy":              "%s - %s は <@%s> さんが予約しています",
+	"reserve_now.closed":            "現在は開室時間外です（開室時間: %s - %s）",
+	"reserve_now.closed_day":        "今日は休室日のため予約できません",
+	"reserve_now.closed_day_reason": "今日は休室日（%s）のため予約できません",
+	"reserve_now.after_closing":     "閉室時刻（%s）を超えるため予約できません。利用時間を短くしてください",
+	"reserve_now.too_short":         "利用時間が短すぎます。現在時刻より後まで使える長さを指定してください",
+	"reserve_now.button":            "今すぐ予約（%s）",
+	"reserve_now.invalid_duration":  "利用時間が正しくありません。",
+	"reserve_now.no_free_slot":      "今日はこれ以降に空いている時間帯がありません",
+	"reserve_now.free_from":         "%s から %s 予約できます",
+	"reserve_now.next_free":         "🟢 次に空く時間",
+	"reserve_now.busy_description":  "部室は使用中か、指定した時間内に予約が入っています。",
+
+	// フォーム
+	"form.unavailable":         "このフォームは現在利用できません。",
+	"form.invalid_args":        "フォーム情報が正しくありません。",
+	"form.retry":               "入力し直す",
+	"form.new_reservation":     "新しく予約",
+	"form.reserve_title":       "部室の予約",
+	"form.edit_title":          "予約の編集",
+	"form.date":                "予約日（例: 2025/11/20、明日、来週の火曜）",
+	"form.start_time":          "開始時間（例: 14:00、14時半）",
+	"form.end_time":            "終了時間（例: 15:00、16時）",
+	"form.end_time_optional":   "終了時間（例: 15:00、16時）※空欄で開始時刻+1時間",
+	"form.comment":             "コメント（任意）",
+	"form.reserve_open_failed": "予約フォームを開けませんでした。",
+	"form.edit_open_failed":    "編集フォームを開けませんでした。",
+
+	// 予約メッセージのボタン・空き通知
+	"actions.cancel":           "取り消す",
+	"actions.complete":         "完了にする",
+	"actions.edit":             "編集する",
+	"actions.watch":            "空いたら通知",
+	"actions.check_in":         "チェックイン",
+	"watch.own_reservation":    "自分の予約には空き通知を登録できません。",
+	"watch.not_pending":        "この予約は既に終了または取り消されています。",
+	"watch.already":            "既に空き通知を登録しています。",
+	"watch.failed":             "空き通知の登録に失敗しました",
+	"watch.done":               "🔔 空き通知を登録しました",
+	"watch.done_description":   "%s %s - %s の予約が取り消されるなどして空いた場合に、DMでお知らせします。",
+	"watch.notify":             "🔔 部室が空きました",
+	"watch.notify_description": "空き通知を登録していた時間帯が空きました。`/reserve` で予約できます。",
+
+	// 開始前のリマインダー・チェックイン・終了前の確認
+	"reminder.title":               "⏰ まもなく予約の時間です",
+	"reminder.starts_in":           "あと %d 分で予約の時間です。",
+	"reminder.started":             "予約の時間（%s〜）になっています。",
+	"reminder.hint":                "部室に着いたら「チェックイン」を押してください。行けなくなった場合は「取り消す」を押すと、ほかのメンバーが使えるようになります。",
+	"checkin.done":                 "✅ チェックインしました",
+	"checkin.done_description":     "%s %s - %s の予約にチェックインしました。",
+	"checkin.not_owner":            "他のユーザーの予約にはチェックインできません。",
+	"checkin.not_pending":          "完了またはキャンセルされた予約にはチェックインできません。",
+	"checkin.already":              "この予約にはチェックイン済みです。",
+	"checkin.ended":                "予約の時間が終わっているため、チェックインできません。",
+	"checkin.too_early":            "チェックインは開始の %d 分前からできます。",
+	"end_prompt.title":             "⌛ まもなく予約の終了時刻です",
+	"end_prompt.description":       "あと %d 分で終了時刻（%s）です。",
+	"end_prompt.hint":              "続けて使う場合は延長ボタンを、使い終わった場合は「終了する」を押すと、ほかのメンバーがすぐに使えるようになります。",
+	"session_end.announce":         "🟢 部室が空きました",
+	"session_end.free_until":       "%s から次の予約があります。",
+	"session_end.free_rest_of_day": "今日はこの後の予約はありません。",
+
+	// 予約一覧
+	"list.status.completed": "完了",
+	"list.status.cancelled": "キャンセル済み",
+	"list.status.past":      "完了・キャンセル済み",
+	"list.status.all":       "すべて",
+	"list.condition_status": "状態: %s",
+	"list.condition_period": "期間: %s 〜 %s",
+	"list.condition_user":   "予約者: <@%s>",
+	"list.title_all":        "⚫ すべての予約一覧",
+	"list.title_mine":       "⚪ あなたの予約一覧",
+	"list.title_member":     "👤 メンバーの予約一覧",
+	"list.empty":            "現在、予約はありません。",
+	"list.empty_mine":       "あなたの予約はありません。",
+	"list.empty_filtered":   "条件に一致する予約はありません。",
+	"list.empty_member":     "<@%s> さんの今後の予約はありません。",
+	"list.count":            "現在 %d 件の予約があります",
+	"list.count_member":     "<@%s> さんの今後の予約が %d 件あります",
+	"list.footer_page":      "部室予約システム  |  %s  |  ページ %d/%d",
+	"list.footer_item":      "部室予約システム  |  %s  |  予約 %d/%d",
+	"list.previous":         "◀ 前へ",
+	"list.next":             "次へ ▶",
+	"list.invalid_page":     "ページ情報が正しくありません。もう一度コマンドを実行してください。",
+
+	// 管理者コマンド
+	"status.pending":              "予約中",
+	"status.completed":            "完了",
+	"status.cancelled":            "キャンセル済み",
+	"admin.permission_denied":     "このコマンドを実行する権限がありません。",
+	"admin.subcommand_required":   "サブコマンドを指定してください。",
+	"admin.unknown_subcommand":    "不明なサブコマンドです。",
+	"admin.already_cancelled":     "この予約は既に取り消されています。",
+	"admin.already_pending":       "この予約は既に予約中です。",
+	"admin.cancel.done":           "🔴 予約を取り消しました（管理者）",
+	"admin.cancel.announce":       "🔴 予約が管理者により取り消されました",
+	"admin.edit.changes":          "📝 変更内容",
+	"admin.edit.done":             "🟡 予約を編集しました（管理者）",
+	"admin.edit.announce":         "🟡 予約が管理者により編集されました",
+	"admin.edit.override_hint":    "休室日・開室時間外に変更する場合は `override: True` を指定してください。",
+	"admin.reopen.failed":         "🔴 予約を再開できませんでした",
+	"admin.reopen.overlap":        "同じ時間帯に別の予約があります。",
+	"admin.reopen.done":           "🟢 予約を再開しました（管理者）",
+	"admin.reopen.announce":       "🟢 予約が管理者により再開されました",
+	"admin.reassign.invalid_user": "予約を割り当てるユーザーが正しくありません。",
+	"admin.reassign.same_owner":   "指定されたユーザーは既にこの予約の所有者です。",
+	"admin.reassign.not_pending":  "予約中の予約のみ割り当て直せます。",
+	"admin.reassign.changes":      "🔁 所有者の変更",
+	"admin.reassign.done":         "🟡 予約の所有者を変更しました（管理者）",
+	"admin.reassign.announce":     "🟡 予約の所有者が管理者により変更されました",
+	"admin.list.title":            "🛡️ 予約一覧（管理者）",
+	"admin.list.more":             "…ほか %d 件（条件を絞り込んでください）",
+	"admin.list.footer":           "部室予約システム  |  admin list  |  %d件",
+	"admin.audit.admin":           "🛡️ 実行者",
+	"admin.audit.reason":          "📝 理由",
+	"admin.audit.title":           "🛡️ 管理者操作: %s",
+
+	// オートコンプリート
+	"date.today":                 "今日 %s",
+	"date.tomorrow":              "明日 %s",
+	"date.day_after_tomorrow":    "明後日 %s",
+	"date.weeks_later":           "%d週間後 %s",
+	"date.free":                  "空きあり %sh",
+	"date.full":                  "満室",
+	"date.closed":                "休室",
+	"date.closed_reason":         "休室（%s）",
+	"date.no_more_today":         "本日の受付終了",
+	"date.free_keyword":          "空き",
+	"duration.until":             "%s（%s まで）",
+	"duration.until_overlapping": "%s（%s まで・予約と重なります）",
+	"duration.until_free_end":    "空き時間の最後まで %s",
+	"time.taken":                 "%s（%s さんが予約中 %s-%s）",
+	"time.until_next":            "%s（次の予約の開始）",
+
+	// /availability
+	"availability.past_date":         "過去の日付の空き状況は表示できません。",
+	"availability.title":             "🟢 空き状況  %s",
+	"availability.opening_hours":     "開室時間: %s - %s",
+	"availability.none":              "空いている時間帯はありません。",
+	"availability.closed":            "%s — この日は予約できません。",
+	"availability.none_for_duration": "%s以上空いている時間帯はありません。",
+	"availability.for_duration":      "%s以上空いている時間帯",
+	"availability.slot":              "🕐 **%s - %s**（%s）",
+	"availability.book_hint":         "ボタンを押すと、その時間帯で予約フォームを開きます。",
+	"availability.book_button":       "%s-%s を予約",
+	"availability.invalid_button":    "ボタンの情報が正しくありません。もう一度 /availability を実行してください。",
+	"availability.today_button":      "今日の空き状況",
+
+	// チャンネルの掲示板
+	"board.title":             "📋 部室の予約状況",
+	"board.hint":              "下のボタンから予約や空き状況の確認ができます。",
+	"board.status_closed":     "⛔ 今日は%sです",
+	"board.status_in_use":     "🔴 使用中 — %s さん（〜%s）",
+	"board.status_outside":    "🌙 開室時間外です（開室時間: %s - %s）",
+	"board.status_free_until": "🟢 空いています（%s から次の予約）",
+	"board.status_free":       "🟢 空いています（今日はこの後の予約はありません）",
+	"board.now_marker":        "▶ ",
+	"board.today":             "📅 今日 %s",
+	"board.upcoming":          "🗓️ 今後の予約（%d日間）",
+	"board.no_reservations":   "予約はありません",
+	"board.more":              "…ほか %d 件",
+	"board.footer":            "部室予約システム  |  予約が変わると自動で更新されます",
+	"digest.title":            "☀️ 今日の部室 %s",
+	"digest.description":      "開室時間: %s - %s",
+	"digest.reservations":     "📅 今日の予約（%d件）",
+	"digest.free_slots":       "🟢 空いている時間帯",
+	"digest.no_free_slots":    "今日は空いている時間帯はありません",
+	"digest.closures":         "⛔ 休室日のお知らせ（%d日間）",
+	"digest.yesterday":        "📊 昨日の記録",
+	"digest.yesterday_counts": "無断欠席 %d 件 / キャンセル %d 件",
+	"digest.footer":           "部室予約システム  |  毎朝のお知らせ",
+
+	// /schedule
+	"schedule.render_failed": "タイムライン画像の作成に失敗しました",
+	"schedule.today_button":  "タイムライン",
+	"schedule.title_day":     "📊 タイムライン  %s",
+	"schedule.title_week":    "📊 週間タイムライン  %s 〜 %s",
+	"schedule.empty":         "この期間の予約はありません。",
+	"schedule.more":          "…ほか %d 件",
+	"schedule.legend":        "🟩 予約中  🟦 完了  🟥 キャンセル済み（枠線）",
+
+	// /stats
+	"stats.load_failed":       "統計の読み込みに失敗しました",
+	"stats.bookings":          "📅 予約件数",
+	"stats.bookings_value":    "%d 件（完了 %d 件）",
+	"stats.cancelled":         "🚫 キャンセル",
+	"stats.cancelled_value":   "%d 件（%.0f%%）",
+	"stats.utilization":       "🏠 利用率",
+	"stats.utilization_value": "%.1f%%（%s / 開室 %s）",
+	"stats.busiest_weekdays":  "📆 混雑する曜日",
+	"stats.weekday":           "%s曜",
+	"stats.busiest_hours":     "🕐 混雑する時間帯",
+	"stats.hour":              "%d時台",
+	"stats.command_usage":     "⌨️ コマンド利用",
+	"stats.top_booker":        "%d. <@%s> %s（%d 件）",
+	"stats.top_bookers":       "👤 予約時間の多いメンバー",
+	"stats.top_command_users": "👤 コマンド利用の多いメンバー",
+	"stats.command_user":      "<@%s> %d 回",
+	"stats.period":            "集計期間: %s 〜 %s（アーカイブ済みの予約を含む）",
+	"stats.admin_only":        "メンバーごとの内訳は管理者のみ表示されます。",
+	"stats.footer":            "部室予約システム  |  stats  |  累計コマンド数 %d",
+	"stats.title":             "📈 利用統計  %d年%d月",
+	"stats.no_records":        "記録はありません",
+	"stats.command_total":     "合計 %d 回・利用者 %d 人",
+	"stats.none":              "なし",
+
+	// 週間レポート
+	"weekly_report.title":                 "📊 週間レポート",
+	"weekly_report.no_shows":              "🙈 無断欠席",
+	"weekly_report.no_shows_value":        "%d 件（完了の %.0f%%）",
+	"weekly_report.no_show_rule":          "無断欠席は、リマインダーでチェックインを求めた予約のうち、チェックインも延長・終了もされずに完了したものです",
+	"weekly_report.no_show_disabled":      "リマインダーが無効（`REMINDER_MINUTES=0`）のため、チェックインを求めておらず無断欠席は数えていません",
+	"weekly_report.ranking_hint":          "`/ranking` で掲載を選んだメンバーのみ表示しています",
+	"weekly_report.command_usage":         "⌨️ コマンド利用（%d年%d月）",
+	"weekly_report.command_usage_to_date": "⌨️ コマンド利用（%d年%d月1日〜%d日の途中集計）",
+	"weekly_report.footer":                "部室予約システム  |  毎週月曜日のレポート  |  日ごとの集計はCSVを参照",
+
+	// /history
+	"history.admin_only":      "他のユーザーの履歴は管理者のみ表示できます。",
+	"history.load_failed":     "履歴の読み込みに失敗しました",
+	"history.all_periods":     "期間: すべて",
+	"history.more":            "…ほか %d 件（期間を指定して絞り込めます）",
+	"history.total":           "📋 件数",
+	"history.count":           "%d 件",
+	"history.used_time":       "⏱️ 利用時間（完了）",
+	"history.used_time_value": "%s（%d 件）",
+	"history.pending":         "📅 予約中",
+	"history.title":           "📜 予約履歴",
+	"history.hours":           "%s時間",
+
+	// /feedback
+	"feedback.empty":            "フィードバック内容を入力してください",
+	"feedback.not_configured":   "フィードバックチャンネルが設定されていません。管理者に連絡してください。",
+	"feedback.received":         "💬 新しいフィードバック",
+	"feedback.footer":           "部室予約システム  |  feedback  |  受信日時: %s",
+	"feedback.failed":           "フィードバックの送信に失敗しました。管理者に連絡してください。",
+	"feedback.done":             "✅ フィードバックを送信しました",
+	"feedback.done_description": "ご意見ありがとうございます。\nあなたのフィードバックは匿名で運営チームに届けられました。\n\n今後のシステム改善に活用させていただきます。",
+
+	// /help（コマンドごとの説明は Command.Help のキーで指定し、Registry.HelpMessage で組み立てる。全体で Discordのメッセージの上限 2000 文字以内）
+	"help.header":            "# 📖部室予約システム - ヘルプ\n## 利用可能なコマンド:\n",
+	"help.admin_only":        "（管理者のみ）",
+	"help.reserve":           "> 部室の予約を作成します\n> - `date`: 予約日（例: 2025/10/15、明日、来週の火曜、明日14時）\n> - `start_time`: 開始時間（例: 14:00、14時半、3pm）\n> - `end_time` / `duration`: 終了時間か利用時間（例: 1h30m）※省略時は1時間\n> - `comment`: コメント（任意）",
+	"help.reserve_now":       "> 今から予約します（`duration` 省略時は1時間）",
+	"help.reserve_form":      "> 直近の空き時間を入力済みのフォームから予約します",
+	"help.edit":              "> 予約を編集します（`reservation_id` と、変更する `date` / `start_time` / `end_time` / `duration` / `comment`）",
+	"help.extend":            "> 予約の終了時刻を延長・短縮します（`by`: 30m で延長、-30m で短縮、now で終了）",
+	"help.transfer":          "> 予約を別のメンバーに譲渡します（相手がDMで承諾すると予約者が変わります）",
+	"help.cancel":            "> 予約を取り消します（`reservation_id`、`comment` は任意）",
+	"help.complete":          "> 予約を完了にします（`reservation_id`、`comment` は任意）\n> - 予約メッセージの「取り消す」「完了にする」「編集する」ボタンでも操作できます",
+	"help.list":              "> すべての予約を表示します\n> - `user` / `status` / `from` / `to`: 予約者・状態・期間で絞り込み（任意）",
+	"help.my_reservations":   "> 自分の予約を表示します（`status` / `from` / `to` で絞り込み）",
+	"help.view_reservations": "> メンバーを右クリック →「アプリ」から、そのメンバーの予約を表示します",
+	"help.history":           "> 過去を含む予約履歴と利用時間の合計を表示します",
+	"help.stats":             "> 月ごとのコマンド利用状況と部室の利用率を表示します（`month`: 省略時は今月）",
+	"help.availability":      "> 指定日の空き時間を表示します（`date`、`duration`: 必要な時間）\n> - 空き時間帯のボタンから予約フォームを開けます",
+	"help.schedule":          "> 予約のタイムライン画像を表示します（`view`: 日 / 週、`date`: 省略時は今日）",
+	"help.feedback":          "> システムへのご意見・ご要望を匿名で送信します",
+	"help.help":              "> このヘルプを表示します",
+	"help.language":          "> 表示言語を切り替えます（日本語 / English / Discordの設定に従う）",
+	"help.ranking":           "> 週間レポートの利用時間のランキングに名前を載せるかどうかを設定します（`show`、既定は載せない）",
+	"help.admin":             "> すべてのユーザーの予約を管理します（`cancel` / `edit` / `reopen` / `reassign` / `list`）\n> - 変更操作には `reason`（理由）が必須で、監査チャンネルに記録されます",
+	"help.footer": "## プライバシー:\n" +
+		"- 表示系のコマンド（/list、/history、/stats など）と /help、/feedback は自分だけに表示されます\n" +
+		"- 予約作成時、予約IDは予約者だけに通知されます\n" +
+		"- フィードバックは完全に匿名で送信されます\n\n" +
+		"## データ管理:\n" +
+		"- 完了・キャンセル済みの予約は30日後に自動削除されます\n" +
+		"- 期限切れの予約は毎日午前3時に自動完了されます\n\n" +
+		"## 利用可能チャンネル:\n" +
+		"- https://discord.com/channels/1090816023965479035/1375843736864559195で利用が可能です\n" +
+		"- または、認証済みの場合のみDMでも利用可能です\n\n" +
+		"## 認証方法:\n" +
+		"[こちら](https://discord.com/oauth2/authorize?client_id=1425303718882185237)から認証を行ってください\n" +
+		"## サポート:\n" +
+		"- 問題が発生した場合は、フィードバックまでご連絡ください\n",
+
+	// コマンド定義（説明・選択肢）。キーは internal/commands の localizeCommand を参照
+	"command.reserve":                 "部室の予約を作成します",
+	"command.reserve.date":            "予約日（例: 2025/10/15、明日、来週の火曜、明日14時）",
+	"command.reserve.start_time":      "開始時間（例: 14:00、14時半、3pm）※日付に時刻を含めた場合は省略可",
+	"command.reserve.end_time":        "終了時間（例: 15:00、16時）※省略時は開始時刻+利用時間（既定1時間）",
+	"command.reserve.duration":        "利用時間（例: 30m、1h30m、90、2時間）※end_time の代わりに指定",
+	"command.reserve-now":             "今から部室を予約します（開始時刻は30分単位に切り捨て）",
+	"command.reserve-now.duration":    "利用時間（例: 30m、1h30m、2時間）※省略時は1時間",
+	"command.reserve-form":            "入力フォームから部室を予約します（直近の空き時間が入力済み）",
+	"command.cancel":                  "予約を取り消します",
+	"command.complete":                "予約を完了にします",
+	"command.edit":                    "予約を編集します",
+	"command.edit.date":               "新しい予約日（例: 2025/10/15、明日、来週の火曜）※変更しない場合は省略",
+	"command.edit.start_time":         "新しい開始時間（例: 14:00、14時半、3pm）※変更しない場合は省略",
+	"command.edit.end_time":           "新しい終了時間（例: 15:00、16時）※変更しない場合は省略",
+	"command.edit.duration":           "新しい利用時間（例: 30m、1h30m、2時間）※end_time の代わりに指定",
+	"command.edit.comment":            "新しいコメント（※変更しない場合は省略）",
+	"command.extend":                  "予約の終了時刻を延長・短縮します（今すぐ終了することもできます）",
+	"command.extend.reservation_id":   "変更する予約ID",
+	"command.extend.by":               "変更する長さ（例: 30m で延長、-30m で短縮、now で今すぐ終了）",
+	"command.transfer":                "自分の予約を別のメンバーに譲渡します（相手が承諾すると予約者が変わります）",
+	"command.transfer.reservation_id": "譲渡する予約ID",
+	"command.transfer.to":             "譲渡先のメンバー",
+	"command.list":                    "すべての予約を表示します（自分だけに表示されます）",
+	"command.my-reservations":         "自分の予約を表示します（自分だけに表示されます）",
+	"command.history":                 "完了・キャンセル済みを含む予約履歴と利用時間の合計を表示します（自分だけに表示）",
+	"command.history.status":          "状態で絞り込み（省略時はすべて）",
+	"command.history.user":            "履歴を表示するユーザー（管理者のみ）",
+	"command.stats":                   "月ごとのコマンド利用状況と部室の利用率を表示します（自分だけに表示）",
+	"command.stats.month":             "集計する月（YYYY-MM または YYYY/MM、省略時は今月）",
+	"command.availability":            "指定日の空き時間を表示します（自分だけに表示されます）",
+	"command.availability.date":       "日付（YYYY-MM-DD または YYYY/MM/DD）",
+	"command.availability.duration":   "必要な時間（例: 30m, 1h30m, 90）※指定するとその長さ以上の空きだけを表示",
+	"command.schedule":                "予約のタイムライン画像を表示します（自分だけに表示されます）",
+	"command.schedule.view":           "表示範囲（省略時は日）",
+	"command.schedule.view.day":       "日",
+	"command.schedule.view.week":      "週",
+	"command.schedule.date":           "日付（YYYY-MM-DD または YYYY/MM/DD）※省略時は今日",
+	"command.help":                    "ヘルプメッセージを表示します（自分だけに表示されます）",
+	"command.feedback":                "システムへのご意見・ご要望を匿名で送信します",
+	"command.feedback.message":        "フィードバック内容",
+	"command.view_reservations":       "予約を見る",
+	"command.admin":                   "管理者用の予約管理コマンド",
+	"command.admin.reservation_id":    "予約ID（すべてのユーザーの予約から検索）",
+	"command.admin.reason":            "操作の理由（監査チャンネルに記録されます）",
+	"command.admin.cancel":            "任意のユーザーの予約を取り消します",
+	"command.admin.edit":              "任意のユーザーの予約を編集します",
+	"command.admin.edit.date":         "新しい予約日（YYYY-MM-DD または YYYY/MM/DD）※変更しない場合は省略",
+	"command.admin.edit.start_time":   "新しい開始時間（HH:MM形式）※変更しない場合は省略",
+	"command.admin.edit.end_time":     "新しい終了時間（HH:MM形式）※変更しない場合は省略",
+	"command.admin.edit.comment":      "新しいコメント（※変更しない場合は省略）",
+	"command.admin.edit.override":     "休室日・開室時間外への変更を許可する（既定: False）",
+	"command.admin.reopen":            "完了・キャンセル済みの予約を予約中に戻します",
+	"command.admin.reassign":          "予約を別のユーザーに割り当てます",
+	"command.admin.reassign.user":     "新しい予約者",
+	"command.admin.list":              "すべてのユーザーの予約を条件付きで表示します",
+	"command.admin.list.status":       "状態で絞り込み（任意）",
+	"option.reservation_id":           "予約ID",
+	"option.comment":                  "コメント（任意）",
+	"option.user":                     "予約者で絞り込み（任意）",
+	"option.status":                   "状態で絞り込み（省略時は予約中のみ）",
+	"option.status.active":            "予約中",
+	"option.status.pending":           "予約中",
+	"option.status.completed":         "完了",
+	"option.status.cancelled":         "キャンセル済み",
+	"option.status.past":              "過去（完了・キャンセル済み）",
+	"option.status.all":               "すべて",
+	"option.from":                     "この日付以降（YYYY-MM-DD または YYYY/MM/DD、任意）",
+	"option.to":                       "この日付以前（YYYY-MM-DD または YYYY/MM/DD、任意）",
+
+	// /language
+	"language.title":             "🌐 表示言語",
+	"language.name":              "日本語",
+	"language.auto":              "Discordの言語設定に従う",
+	"language.current":           "現在の表示言語: %s",
+	"language.set":               "表示言語を %s に設定しました。",
+	"language.reset":             "表示言語をDiscordの言語設定に従うように戻しました。",
+	"language.invalid":           "対応していない言語です。",
+	"language.save_failed":       "表示言語の保存に失敗しました",
+	"command.language":           "表示言語を切り替えます（自分だけに表示されます）",
+	"command.language.lang":      "表示言語（省略すると現在の設定を表示）",
+	"command.language.lang.ja":   "日本語",
+	"command.language.lang.en":   "English",
+	"command.language.lang.auto": "自動（Discordの設定に従う）",
+
+	// /ranking
+	"ranking.title":        "🏆 ランキングへの掲載",
+	"ranking.shown":        "週間レポートの利用時間のランキングに名前を載せます。",
+	"ranking.hidden":       "週間レポートの利用時間のランキングに名前を載せません。",
+	"ranking.save_failed":  "設定の保存に失敗しました",
+	"command.ranking":      "週間レポートのランキングに名前を載せるかどうかを設定します（自分だけに表示されます）",
+	"command.ranking.show": "ランキングに名前を載せる（省略すると現在の設定を表示）",
+}
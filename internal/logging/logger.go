@@ -264,11 +264,28 @@ func (l *Logger) GetStats() *CommandStats {
 	l.mutex.RLock()
 	defer l.mutex.RUnlock()
 
-	// コピーを返す
+	// コマンド実行中に更新されるため、マップも含めてコピーを返す
 	statsCopy := *l.stats
+	statsCopy.CommandCounts = copyCounts(l.stats.CommandCounts)
+	statsCopy.UserCounts = copyCounts(l.stats.UserCounts)
+	statsCopy.MonthlyStats = make(map[string]MonthlyStat, len(l.stats.MonthlyStats))
+	for key, monthly := range l.stats.MonthlyStats {
+		monthly.CommandCounts = copyCounts(monthly.CommandCounts)
+		monthly.UserCounts = copyCounts(monthly.UserCounts)
+		statsCopy.MonthlyStats[key] = monthly
+	}
 	return &statsCopy
 }
 
+// copyCounts は回数のマップをコピーする
+func copyCounts(counts map[string]int) map[string]int {
+	copied := make(map[string]int, len(counts))
+	for key, count := range counts {
+		copied[key] = count
+	}
+	return copied
+}
+
 // GetMonthlyLogPath は現在の月次ログファイルのパスを取得する
 func (l *Logger) GetMonthlyLogPath() string {
 	l.mutex.RLock()
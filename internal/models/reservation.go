@@ -17,17 +17,68 @@ const (
 
 // Reservation は予約情報を表す構造体
 type Reservation struct {
-	ID        string            `json:"id"`         // 予約ID（推測しにくい英数字列）
-	UserID    string            `json:"user_id"`    // 予約者のDiscord ID
-	Username  string            `json:"username"`   // 予約者の表示名
-	Date      string            `json:"date"`       // 予約日（YYYY-MM-DD形式）
-	StartTime string            `json:"start_time"` // 開始時間（HH:MM形式）
-	EndTime   string            `json:"end_time"`   // 終了時間（HH:MM形式）
-	Comment   string            `json:"comment"`    // コメント（オプション）
-	Status    ReservationStatus `json:"status"`     // 予約状態
-	CreatedAt time.Time         `json:"created_at"` // 作成日時
-	UpdatedAt time.Time         `json:"updated_at"` // 更新日時
-	ChannelID string            `json:"channel_id"` // 予約が行われたチャンネルID
+	ID        string            `json:"id"`                 // 予約ID（推測しにくい英数字列）
+	UserID    string            `json:"user_id"`            // 予約者のDiscord ID
+	Username  string            `json:"username"`           // 予約者の表示名
+	Date      string            `json:"date"`               // 予約日（YYYY-MM-DD形式）
+	StartTime string            `json:"start_time"`         // 開始時間（HH:MM形式）
+	EndTime   string            `json:"end_time"`           // 終了時間（HH:MM形式）
+	Comment   string            `json:"comment"`            // コメント（オプション）
+	Status    ReservationStatus `json:"status"`             // 予約状態
+	CreatedAt time.Time         `json:"created_at"`         // 作成日時
+	UpdatedAt time.Time         `json:"updated_at"`         // 更新日時
+	ChannelID string            `json:"channel_id"`         // 予約が行われたチャンネルID
+	History   []HistoryEntry    `json:"history,omitempty"`  // 変更履歴（管理者操作など）
+	Watchers  []string          `json:"watchers,omitempty"` // 空いたら通知を希望しているユーザーのDiscord ID
+	Transfer  *TransferOffer    `json:"transfer,omitempty"` // 承諾待ちの譲渡の申し出
+
+	ReminderSentFor  string     `json:"reminder_sent_for,omitempty"`   // 開始前のリマインダーを送った開始日時（YYYY-MM-DD HH:MM）。開始日時が変わった場合は送り直す
+	EndPromptSentFor string     `json:"end_prompt_sent_for,omitempty"` // 終了前の延長・終了の確認を送った終了日時（YYYY-MM-DD HH:MM）。延長などで終了日時が変わった場合は送り直す
+	CheckedInAt      *time.Time `json:"checked_in_at,omitempty"`       // チェックインした日時
+}
+
+// TransferOffer は予約を別のメンバーに譲渡する申し出を表す
+type TransferOffer struct {
+	FromUserID string    `json:"from_user_id"` // 申し出た予約者のDiscord ID（予約者が変わった後の申し出は無効）
+	ToUserID   string    `json:"to_user_id"`   // 譲渡先のDiscord ID
+	ToUsername string    `json:"to_username"`  // 譲渡先の表示名
+	OfferedAt  time.Time `json:"offered_at"`   // 申し出た日時
+}
+
+// HistoryEntry は予約に対する操作の履歴を表す
+type HistoryEntry struct {
+	Action    string    `json:"action"`            // 操作の種類（admin_cancel, admin_edit など）
+	ActorID   string    `json:"actor_id"`          // 操作したユーザーのDiscord ID
+	Reason    string    `json:"reason,omitempty"`  // 操作理由
+	Details   string    `json:"details,omitempty"` // 変更内容（任意）
+	Timestamp time.Time `json:"timestamp"`         // 操作日時
+}
+
+// AddHistory は予約に操作履歴を追加する
+func (r *Reservation) AddHistory(action, actorID, reason, details string) {
+	r.History = append(r.History, HistoryEntry{
+		Action:    action,
+		ActorID:   actorID,
+		Reason:    reason,
+		Details:   details,
+		Timestamp: time.Now(),
+	})
+}
+
+// Clone は予約のコピーを返す（履歴・空き通知の登録などのスライスやポインタもコピーし、元の予約と共有しない）
+func (r *Reservation) Clone() *Reservation {
+	clone := *r
+	clone.History = append([]HistoryEntry(nil), r.History...)
+	clone.Watchers = append([]string(nil), r.Watchers...)
+	if r.Transfer != nil {
+		transfer := *r.Transfer
+		clone.Transfer = &transfer
+	}
+	if r.CheckedInAt != nil {
+		checkedInAt := *r.CheckedInAt
+		clone.CheckedInAt = &checkedInAt
+	}
+	return &clone
 }
 
 // GenerateReservationID は推測しにくいランダムな予約IDを生成する
@@ -39,6 +90,42 @@ func GenerateReservationID() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// AddWatcher は空いたら通知を希望するユーザーを登録する（登録済みの場合は false）
+func (r *Reservation) AddWatcher(userID string) bool {
+	for _, watcher := range r.Watchers {
+		if watcher == userID {
+			return false
+		}
+	}
+	r.Watchers = append(r.Watchers, userID)
+	return true
+}
+
+// StartKey は予約の開始日時を表す文字列（YYYY-MM-DD HH:MM）を返す（通知を送った予約の時間が変わったかどうかの判定に使う）
+func (r *Reservation) StartKey() string {
+	return r.Date + " " + r.StartTime
+}
+
+// EndKey は予約の終了日時を表す文字列（YYYY-MM-DD HH:MM）を返す
+func (r *Reservation) EndKey() string {
+	return r.Date + " " + r.EndTime
+}
+
+// IsNoShow は予約の時間に来なかった（無断欠席の）予約かどうかを返す
+// リマインダーでチェックインを求めたのに、チェックインも延長・終了の操作もされずに完了した予約を無断欠席とする
+// チェックインのボタンはリマインダーにしかないため、リマインダーを送っていない予約（REMINDER_MINUTES=0 や直前の予約）は数えない
+func (r *Reservation) IsNoShow() bool {
+	if r.Status != StatusCompleted || r.ReminderSentFor == "" || r.CheckedInAt != nil {
+		return false
+	}
+	for _, entry := range r.History {
+		if entry.ActorID == r.UserID && (entry.Action == "extend" || entry.Action == "finish") {
+			return false
+		}
+	}
+	return true
+}
+
 // GetDateTime は予約日時をtime.Time型で返す
 func (r *Reservation) GetDateTime(timeStr string) (time.Time, error) {
 	layout := "2006-01-02 15:04"
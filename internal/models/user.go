@@ -0,0 +1,7 @@
+package models
+
+// UserSettings はメンバーごとの設定を表す
+type UserSettings struct {
+	Locale      string `json:"locale,omitempty"`        // 表示言語（ja, en など。空の場合はDiscordの言語設定に従う）
+	ShowInRanks bool   `json:"show_in_ranks,omitempty"` // 週間レポートの利用時間のランキングに名前を載せる（/ranking で設定）
+}
@@ -0,0 +1,330 @@
+// Package naturaltime は「明日」「来週の火曜」「14時」「2時間」「tomorrow 3pm」のような
+// 日本語・英語の自然な日時表現を解釈する
+package naturaltime
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnrecognized は入力を日時として解釈できなかったことを表す
+var ErrUnrecognized = errors.New("naturaltime: unrecognized input")
+
+// normalize は全角の英数字・記号を半角にし、小文字化して前後の空白を取り除く
+func normalize(input string) string {
+	input = strings.Map(func(r rune) rune {
+		switch {
+		case r >= '０' && r <= '９':
+			return '0' + (r - '０')
+		case r >= 'Ａ' && r <= 'Ｚ':
+			return 'a' + (r - 'Ａ')
+		case r >= 'ａ' && r <= 'ｚ':
+			return 'a' + (r - 'ａ')
+		}
+		switch r {
+		case '：':
+			return ':'
+		case '／':
+			return '/'
+		case '－':
+			return '-'
+		case '．':
+			return '.'
+		case '　':
+			return ' '
+		}
+		return r
+	}, input)
+	return strings.Join(strings.Fields(strings.ToLower(input)), " ")
+}
+
+// relativeDays は今日を基準にした相対的な日付の表現
+var relativeDays = map[string]int{
+	"今日":                 0,
+	"きょう":                0,
+	"本日":                 0,
+	"today":              0,
+	"明日":                 1,
+	"あした":                1,
+	"あす":                 1,
+	"tomorrow":           1,
+	"明後日":                2,
+	"あさって":               2,
+	"day after tomorrow": 2,
+}
+
+// weekdayNames は曜日の表記（日本語・英語）
+var weekdayNames = map[string]time.Weekday{
+	"日": time.Sunday, "月": time.Monday, "火": time.Tuesday, "水": time.Wednesday,
+	"木": time.Thursday, "金": time.Friday, "土": time.Saturday,
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var (
+	fullDatePattern  = regexp.MustCompile(`^(\d{4}|\d{2})[-/年](\d{1,2})[-/月](\d{1,2})日?$`)
+	monthDayPattern  = regexp.MustCompile(`^(\d{1,2})[/月](\d{1,2})日?$`)
+	dayPattern       = regexp.MustCompile(`^(\d{1,2})日$`)
+	daysLaterPattern = regexp.MustCompile(`^(?:(\d+)(日|週間)後|in (\d+) (days?|weeks?))$`)
+	jaWeekdayPattern = regexp.MustCompile(`^(今週|来週|再来週)?の?([日月火水木金土])(?:曜日?)?$`)
+	enWeekdayPattern = regexp.MustCompile(`^(?:(this|next) )?([a-z]+)$`)
+)
+
+// ParseDate は日付の表現を解釈し、now と同じタイムゾーンの 0 時を返す
+//
+// 対応する表現:
+//   - 2025-11-20 / 2025/11/20 / 25/11/20 / 2025年11月20日
+//   - 11/20 / 11月20日（過ぎていれば翌年）、20日（過ぎていれば翌月）
+//   - 今日・明日・明後日 / today・tomorrow
+//   - 3日後・2週間後 / in 3 days・in 2 weeks
+//   - 火曜・火曜日 / tue・tuesday（今日以降で最も近い日）
+//   - 今週の火曜・来週の火曜・再来週の火曜 / this tue・next tue（月曜始まりの週で数える）
+func ParseDate(input string, now time.Time) (time.Time, error) {
+	s := normalize(input)
+	if s == "" {
+		return time.Time{}, ErrUnrecognized
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if days, ok := relativeDays[s]; ok {
+		return today.AddDate(0, 0, days), nil
+	}
+
+	if m := fullDatePattern.FindStringSubmatch(s); m != nil {
+		year := atoi(m[1])
+		if len(m[1]) == 2 {
+			year += 2000
+		}
+		return validDate(year, atoi(m[2]), atoi(m[3]), now.Location())
+	}
+
+	if m := monthDayPattern.FindStringSubmatch(s); m != nil {
+		date, err := validDate(today.Year(), atoi(m[1]), atoi(m[2]), now.Location())
+		if err != nil {
+			return time.Time{}, err
+		}
+		if date.Before(today) {
+			return validDate(today.Year()+1, atoi(m[1]), atoi(m[2]), now.Location())
+		}
+		return date, nil
+	}
+
+	if m := dayPattern.FindStringSubmatch(s); m != nil {
+		// 今月に存在しない日、または過ぎている日は翌月以降で最初に存在する日にする
+		day := atoi(m[1])
+		for offset := 0; offset <= 2; offset++ {
+			first := time.Date(today.Year(), today.Month()+time.Month(offset), 1, 0, 0, 0, 0, now.Location())
+			date, err := validDate(first.Year(), int(first.Month()), day, now.Location())
+			if err == nil && !date.Before(today) {
+				return date, nil
+			}
+		}
+		return time.Time{}, ErrUnrecognized
+	}
+
+	if m := daysLaterPattern.FindStringSubmatch(s); m != nil {
+		count, unit := m[1], m[2]
+		if count == "" {
+			count, unit = m[3], m[4]
+		}
+		n := atoi(count)
+		if strings.HasPrefix(unit, "週") || strings.HasPrefix(unit, "week") {
+			n *= 7
+		}
+		return today.AddDate(0, 0, n), nil
+	}
+
+	if m := jaWeekdayPattern.FindStringSubmatch(s); m != nil {
+		weeks := map[string]int{"": -1, "今週": 0, "来週": 1, "再来週": 2}[m[1]]
+		return weekdayDate(today, weekdayNames[m[2]], weeks), nil
+	}
+
+	if m := enWeekdayPattern.FindStringSubmatch(s); m != nil {
+		if weekday, ok := weekdayNames[m[2]]; ok {
+			weeks := map[string]int{"": -1, "this": 0, "next": 1}[m[1]]
+			return weekdayDate(today, weekday, weeks), nil
+		}
+	}
+
+	return time.Time{}, ErrUnrecognized
+}
+
+// weekdayDate は指定した曜日の日付を返す
+// weeks が負の場合は今日以降で最も近い日、それ以外は今週（月曜始まり）から weeks 週後の日を返す
+func weekdayDate(today time.Time, weekday time.Weekday, weeks int) time.Time {
+	if weeks < 0 {
+		return today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7)
+	}
+	// 月曜=0 〜 日曜=6 として数える
+	fromMonday := func(w time.Weekday) int { return (int(w) + 6) % 7 }
+	monday := today.AddDate(0, 0, -fromMonday(today.Weekday()))
+	return monday.AddDate(0, 0, weeks*7+fromMonday(weekday))
+}
+
+// validDate は存在する日付の場合のみ time.Time を返す（2月30日などは受け付けない）
+func validDate(year, month, day int, loc *time.Location) (time.Time, error) {
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, ErrUnrecognized
+	}
+	return date, nil
+}
+
+var (
+	clockPattern   = regexp.MustCompile(`^(\d{1,2}):(\d{1,2})$`)
+	compactPattern = regexp.MustCompile(`^(\d{1,2})(\d{2})$`)
+	hourPattern    = regexp.MustCompile(`^(\d{1,2})(?:時(?:(\d{1,2})分|半)?)?$`)
+)
+
+// ParseTime は時刻の表現を解釈し、HH:MM 形式で返す
+//
+// 対応する表現:
+//   - 14:00 / 9:30 / 1400 / 14
+//   - 14時・14時30分・14時半、午前10時・午後3時
+//   - 3pm・3:30pm・10 am
+//   - 正午 / noon
+func ParseTime(input string) (string, error) {
+	s := normalize(input)
+	if s == "正午" || s == "noon" {
+		return "12:00", nil
+	}
+
+	// 午前・午後 / am・pm の指定を取り出す
+	meridiem := ""
+	for _, prefix := range []string{"午前", "午後"} {
+		if strings.HasPrefix(s, prefix) {
+			meridiem, s = prefix, strings.TrimSpace(strings.TrimPrefix(s, prefix))
+		}
+	}
+	for suffix, value := range map[string]string{"am": "午前", "a.m.": "午前", "pm": "午後", "p.m.": "午後"} {
+		if meridiem == "" && strings.HasSuffix(s, suffix) {
+			meridiem, s = value, strings.TrimSpace(strings.TrimSuffix(s, suffix))
+		}
+	}
+
+	hour, minute := -1, 0
+	if m := clockPattern.FindStringSubmatch(s); m != nil {
+		hour, minute = atoi(m[1]), atoi(m[2])
+	} else if m := compactPattern.FindStringSubmatch(s); m != nil && meridiem == "" {
+		hour, minute = atoi(m[1]), atoi(m[2])
+	} else if m := hourPattern.FindStringSubmatch(s); m != nil {
+		hour = atoi(m[1])
+		if strings.HasSuffix(s, "半") {
+			minute = 30
+		} else if m[2] != "" {
+			minute = atoi(m[2])
+		}
+	} else {
+		return "", ErrUnrecognized
+	}
+
+	switch meridiem {
+	case "午前":
+		if hour < 1 || hour > 12 {
+			return "", ErrUnrecognized
+		}
+		hour %= 12
+	case "午後":
+		if hour < 1 || hour > 12 {
+			return "", ErrUnrecognized
+		}
+		hour = hour%12 + 12
+	}
+
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return "", ErrUnrecognized
+	}
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC).Format("15:04"), nil
+}
+
+var durationPartPattern = regexp.MustCompile(`(\d+(?:\.\d+)?) ?(時間半|時間|分|hours?|hrs?|h|minutes?|mins?|m)`)
+
+// ParseDuration は時間の長さの表現を解釈する
+//
+// 対応する表現:
+//   - 90（数字のみは分として扱う）
+//   - 2時間・30分・1時間半・1時間30分・1.5時間
+//   - 2h・90m・1h30m・1.5h / 2 hours・30 min・1 hour 30 minutes
+func ParseDuration(input string) (time.Duration, error) {
+	s := normalize(input)
+	if minutes, err := strconv.Atoi(s); err == nil {
+		if minutes <= 0 {
+			return 0, ErrUnrecognized
+		}
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	matches := durationPartPattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return 0, ErrUnrecognized
+	}
+
+	var total time.Duration
+	consumed := 0
+	for _, m := range matches {
+		// 数値と単位の組の間には空白以外を挟まない
+		if strings.TrimSpace(s[consumed:m[0]]) != "" {
+			return 0, ErrUnrecognized
+		}
+		consumed = m[1]
+
+		value, err := strconv.ParseFloat(s[m[2]:m[3]], 64)
+		if err != nil {
+			return 0, ErrUnrecognized
+		}
+		unit := s[m[4]:m[5]]
+		switch {
+		case unit == "時間半":
+			total += time.Duration((value + 0.5) * float64(time.Hour))
+		case unit == "時間" || strings.HasPrefix(unit, "h"):
+			total += time.Duration(value * float64(time.Hour))
+		default:
+			total += time.Duration(value * float64(time.Minute))
+		}
+	}
+	if strings.TrimSpace(s[consumed:]) != "" {
+		return 0, ErrUnrecognized
+	}
+
+	if total <= 0 || total%time.Minute != 0 {
+		return 0, ErrUnrecognized
+	}
+	return total, nil
+}
+
+// ParseDateTime は「明日14時」「tomorrow 3pm」のような日付と時刻を組み合わせた表現を解釈する
+// 時刻を含まない場合は日付のみを解釈し、clock は空文字列になる
+func ParseDateTime(input string, now time.Time) (date time.Time, clock string, err error) {
+	s := normalize(input)
+	if date, err := ParseDate(s, now); err == nil {
+		return date, "", nil
+	}
+
+	// 先頭から日付として解釈できる最長の部分を探し、残りを時刻として解釈する
+	runes := []rune(s)
+	for split := len(runes) - 1; split > 0; split-- {
+		datePart := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(string(runes[:split])), "の"))
+		date, err := ParseDate(datePart, now)
+		if err != nil {
+			continue
+		}
+		if clock, err := ParseTime(string(runes[split:])); err == nil {
+			return date, clock, nil
+		}
+	}
+	return time.Time{}, "", ErrUnrecognized
+}
+
+// atoi は正規表現で数字であることを確認済みの文字列を整数に変換する
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,159 @@
+package naturaltime
+
+import (
+	"testing"
+	"time"
+)
+
+// 2025-11-20 は木曜日
+var testNow = time.Date(2025, 11, 20, 12, 0, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2025-12-01", "2025-12-01"},
+		{"2025/1/5", "2025-01-05"},
+		{"26/1/5", "2026-01-05"},
+		{"２０２５年１２月３日", "2025-12-03"},
+		{"12/24", "2025-12-24"},
+		{"1月5日", "2026-01-05"},
+		{"25日", "2025-11-25"},
+		{"10日", "2025-12-10"},
+		{"今日", "2025-11-20"},
+		{"明日", "2025-11-21"},
+		{"あさって", "2025-11-22"},
+		{"Tomorrow", "2025-11-21"},
+		{"3日後", "2025-11-23"},
+		{"2週間後", "2025-12-04"},
+		{"in 3 days", "2025-11-23"},
+		{"火曜", "2025-11-25"},
+		{"木曜日", "2025-11-20"},
+		{"今週の金曜", "2025-11-21"},
+		{"来週の火曜", "2025-11-25"},
+		{"来週火曜日", "2025-11-25"},
+		{"再来週の月曜", "2025-12-01"},
+		{"fri", "2025-11-21"},
+		{"next tue", "2025-11-25"},
+		{"this sunday", "2025-11-23"},
+	}
+	for _, tt := range tests {
+		date, err := ParseDate(tt.input, testNow)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got := date.Format("2006-01-02"); got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+
+	for _, input := range []string{"", "someday", "2025-02-30", "13/01", "来週", "32日"} {
+		if _, err := ParseDate(input, testNow); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"14:00", "14:00"},
+		{"9:30", "09:30"},
+		{"１４：３０", "14:30"},
+		{"1400", "14:00"},
+		{"14", "14:00"},
+		{"14時", "14:00"},
+		{"14時15分", "14:15"},
+		{"14時半", "14:30"},
+		{"午前10時", "10:00"},
+		{"午後3時半", "15:30"},
+		{"3pm", "15:00"},
+		{"3:30 PM", "15:30"},
+		{"12am", "00:00"},
+		{"noon", "12:00"},
+		{"正午", "12:00"},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+
+	for _, input := range []string{"", "25:00", "14:60", "午後13時", "15pm", "夕方"} {
+		if _, err := ParseTime(input); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"90", 90 * time.Minute},
+		{"2時間", 2 * time.Hour},
+		{"30分", 30 * time.Minute},
+		{"1時間半", 90 * time.Minute},
+		{"1時間30分", 90 * time.Minute},
+		{"1.5時間", 90 * time.Minute},
+		{"2h", 2 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"90min", 90 * time.Minute},
+		{"1 hour 15 minutes", 75 * time.Minute},
+		{"２時間", 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.input, tt.expected, got)
+		}
+	}
+
+	for _, input := range []string{"", "0", "-30", "しばらく", "2時間くらい", "0.01h"} {
+		if _, err := ParseDuration(input); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		input string
+		date  string
+		clock string
+	}{
+		{"明日", "2025-11-21", ""},
+		{"明日14時", "2025-11-21", "14:00"},
+		{"tomorrow 3pm", "2025-11-21", "15:00"},
+		{"来週の火曜 15時半", "2025-11-25", "15:30"},
+		{"12/24 18:00", "2025-12-24", "18:00"},
+	}
+	for _, tt := range tests {
+		date, clock, err := ParseDateTime(tt.input, testNow)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if date.Format("2006-01-02") != tt.date || clock != tt.clock {
+			t.Errorf("%q: expected %s %s, got %s %s", tt.input, tt.date, tt.clock, date.Format("2006-01-02"), clock)
+		}
+	}
+
+	if _, _, err := ParseDateTime("明日の夕方", testNow); err == nil {
+		t.Error("Expected error for unrecognized time")
+	}
+}
@@ -0,0 +1,246 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+// SlotMinutes は予約枠の刻み幅（分）
+const SlotMinutes = 30
+
+var (
+	// OpeningTime は部室の開室時刻（HH:MM形式）
+	OpeningTime = "09:00"
+	// ClosingTime は部室の閉室時刻（HH:MM形式）
+	ClosingTime = "21:00"
+
+	// closedWeekdays は毎週の休室日の曜日
+	closedWeekdays = map[time.Weekday]bool{}
+	// closedDates は特定の休室日（YYYY-MM-DD形式）と、その理由（祝日名など。空の場合もある）
+	closedDates = map[string]string{}
+)
+
+// weekdayNames は休室日の指定に使う曜日の名前
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Slot は同じ日の時間帯を表す
+type Slot struct {
+	Start string // 開始時刻（HH:MM形式）
+	End   string // 終了時刻（HH:MM形式）
+}
+
+// Duration は時間帯の長さを返す
+func (s Slot) Duration() time.Duration {
+	return time.Duration(ToMinutes(s.End)-ToMinutes(s.Start)) * time.Minute
+}
+
+// SetOpeningHours は開室時間を "HH:MM-HH:MM" 形式の文字列から設定する
+func SetOpeningHours(hours string) error {
+	parts := strings.Split(hours, "-")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid opening hours %q (expected HH:MM-HH:MM)", hours)
+	}
+
+	opening := strings.TrimSpace(parts[0])
+	closing := strings.TrimSpace(parts[1])
+	for _, t := range []string{opening, closing} {
+		if _, err := time.Parse("15:04", t); err != nil {
+			return fmt.Errorf("invalid opening hours %q: %w", hours, err)
+		}
+	}
+	if closing <= opening {
+		return fmt.Errorf("invalid opening hours %q: closing time must be after opening time", hours)
+	}
+
+	OpeningTime = opening
+	ClosingTime = closing
+	return nil
+}
+
+// SetClosedDays は休室日を "sun,2025-12-29=年末休み,2026-01-12=成人の日" のようなカンマ区切りの文字列から設定する
+// 曜日（sun〜sat）は毎週の休室日、日付（YYYY-MM-DD形式）はその日だけの休室日で、= の後に理由（祝日名など）を付けられる
+func SetClosedDays(spec string) error {
+	weekdays := map[time.Weekday]bool{}
+	dates := map[string]string{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if weekday, ok := weekdayNames[strings.ToLower(entry)]; ok {
+			weekdays[weekday] = true
+			continue
+		}
+
+		date, reason, _ := strings.Cut(entry, "=")
+		date = strings.TrimSpace(date)
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("invalid closed day %q (expected sun-sat or YYYY-MM-DD[=reason])", entry)
+		}
+		dates[date] = strings.TrimSpace(reason)
+	}
+
+	closedWeekdays = weekdays
+	closedDates = dates
+	return nil
+}
+
+// ClosedOn は指定日（YYYY-MM-DD形式）が休室日かどうかと、休室の理由（日付で指定した場合のみ）を返す
+func ClosedOn(date string) (reason string, closed bool) {
+	if reason, ok := closedDates[date]; ok {
+		return reason, true
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", false
+	}
+	return "", closedWeekdays[t.Weekday()]
+}
+
+// ToMinutes は HH:MM 形式の時刻を0時からの経過分に変換する（不正な値は -1）
+func ToMinutes(hhmm string) int {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return -1
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// FromMinutes は0時からの経過分を HH:MM 形式の時刻に変換する
+func FromMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// RoundUpToSlot は時刻を予約枠の刻みに切り上げる
+func RoundUpToSlot(hhmm string) string {
+	minutes := ToMinutes(hhmm)
+	if rem := minutes % SlotMinutes; rem != 0 {
+		minutes += SlotMinutes - rem
+	}
+	return FromMinutes(minutes)
+}
+
+// RoundDownToSlot は時刻を予約枠の刻みに切り捨てる
+func RoundDownToSlot(hhmm string) string {
+	minutes := ToMinutes(hhmm)
+	return FromMinutes(minutes - minutes%SlotMinutes)
+}
+
+// ActiveReservationsOn は指定日の有効な（pending状態の）予約を開始時刻順で返す
+func ActiveReservationsOn(reservations []*models.Reservation, date string) []*models.Reservation {
+	active := make([]*models.Reservation, 0)
+	for _, r := range reservations {
+		if r.Date == date && r.Status == models.StatusPending {
+			active = append(active, r)
+		}
+	}
+
+	sort.Slice(active, func(a, b int) bool {
+		return active[a].StartTime < active[b].StartTime
+	})
+	return active
+}
+
+// FreeSlots は指定日の開室時間のうち、有効な予約で埋まっていない時間帯を返す
+// earliest を指定した場合はその時刻より前の時間を除外する（当日の経過時間を除く場合など）。休室日は空き時間なし
+func FreeSlots(reservations []*models.Reservation, date string, earliest string) []Slot {
+	if _, closed := ClosedOn(date); closed {
+		return []Slot{}
+	}
+
+	cursor := OpeningTime
+	if earliest != "" && earliest > cursor {
+		cursor = earliest
+	}
+
+	slots := make([]Slot, 0)
+	for _, r := range ActiveReservationsOn(reservations, date) {
+		if r.StartTime > cursor {
+			end := r.StartTime
+			if end > ClosingTime {
+				end = ClosingTime
+			}
+			if end > cursor {
+				slots = append(slots, Slot{Start: cursor, End: end})
+			}
+		}
+		if r.EndTime > cursor {
+			cursor = r.EndTime
+		}
+	}
+
+	if cursor < ClosingTime {
+		slots = append(slots, Slot{Start: cursor, End: ClosingTime})
+	}
+	return slots
+}
+
+// FreeUntil は指定日の start から続く空き時間帯の終わり（次の予約の開始時刻または閉室時刻）を返す
+// start が開室時間外の場合や、有効な予約と重なる場合は false を返す
+func FreeUntil(reservations []*models.Reservation, date string, start string) (string, bool) {
+	if start < OpeningTime || start >= ClosingTime {
+		return "", false
+	}
+	for _, slot := range FreeSlots(reservations, date, start) {
+		if slot.Start == start {
+			return slot.End, true
+		}
+	}
+	return "", false
+}
+
+// ReservationAt は指定日の clock の時点で使われている有効な予約を返す（ない場合は nil）
+func ReservationAt(reservations []*models.Reservation, date string, clock string) *models.Reservation {
+	for _, r := range ActiveReservationsOn(reservations, date) {
+		if r.StartTime <= clock && clock < r.EndTime {
+			return r
+		}
+	}
+	return nil
+}
+
+// FreeTime は指定日の earliest 以降の空き時間の合計を返す
+func FreeTime(reservations []*models.Reservation, date string, earliest string) time.Duration {
+	var total time.Duration
+	for _, slot := range FreeSlots(reservations, date, earliest) {
+		total += slot.Duration()
+	}
+	return total
+}
+
+// FilterByDuration は指定した長さ以上の時間帯だけを返す
+func FilterByDuration(slots []Slot, minDuration time.Duration) []Slot {
+	filtered := make([]Slot, 0, len(slots))
+	for _, slot := range slots {
+		if slot.Duration() >= minDuration {
+			filtered = append(filtered, slot)
+		}
+	}
+	return filtered
+}
+
+// NextFreeSlot は now 以降で最初に minDuration 以上空いている時間帯を、最大 days 日先まで探す
+// 見つかった日付（YYYY-MM-DD形式）と時間帯を返す
+func NextFreeSlot(reservations []*models.Reservation, now time.Time, days int, minDuration time.Duration) (string, Slot, bool) {
+	for offset := 0; offset < days; offset++ {
+		date := now.AddDate(0, 0, offset).Format("2006-01-02")
+
+		earliest := ""
+		if offset == 0 {
+			earliest = RoundUpToSlot(now.Format("15:04"))
+		}
+
+		slots := FilterByDuration(FreeSlots(reservations, date, earliest), minDuration)
+		if len(slots) > 0 {
+			return date, slots[0], true
+		}
+	}
+	return "", Slot{}, false
+}
@@ -0,0 +1,211 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+)
+
+func TestFreeSlots(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending},
+		{ID: "r2", Date: "2025-11-20", StartTime: "13:00", EndTime: "14:30", Status: models.StatusPending},
+		{ID: "r3", Date: "2025-11-20", StartTime: "14:00", EndTime: "15:00", Status: models.StatusPending},
+		// キャンセル済み・別日の予約は無視される
+		{ID: "r4", Date: "2025-11-20", StartTime: "16:00", EndTime: "18:00", Status: models.StatusCancelled},
+		{ID: "r5", Date: "2025-11-21", StartTime: "09:00", EndTime: "21:00", Status: models.StatusPending},
+	}
+
+	slots := FreeSlots(reservations, "2025-11-20", "")
+	expected := []Slot{
+		{Start: "09:00", End: "10:00"},
+		{Start: "11:00", End: "13:00"},
+		{Start: "15:00", End: "21:00"},
+	}
+	if len(slots) != len(expected) {
+		t.Fatalf("Expected %d slots, got %d: %v", len(expected), len(slots), slots)
+	}
+	for idx, slot := range slots {
+		if slot != expected[idx] {
+			t.Errorf("Slot %d: expected %v, got %v", idx, expected[idx], slot)
+		}
+	}
+
+	// earliest より前の時間は除外される
+	slots = FreeSlots(reservations, "2025-11-20", "12:00")
+	if len(slots) != 2 || slots[0] != (Slot{Start: "12:00", End: "13:00"}) {
+		t.Errorf("Expected free slots from 12:00, got %v", slots)
+	}
+
+	// 終日埋まっている日は空きがない
+	if slots := FreeSlots(reservations, "2025-11-21", ""); len(slots) != 0 {
+		t.Errorf("Expected no free slots, got %v", slots)
+	}
+}
+
+func TestFreeUntil(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending},
+		{ID: "r2", Date: "2025-11-20", StartTime: "13:00", EndTime: "14:30", Status: models.StatusPending},
+	}
+
+	tests := []struct {
+		start string
+		end   string
+		ok    bool
+	}{
+		{"09:00", "10:00", true},
+		{"11:00", "13:00", true},
+		{"14:30", "21:00", true},
+		{"10:30", "", false}, // 予約と重なる
+		{"08:00", "", false}, // 開室前
+		{"21:00", "", false}, // 閉室後
+	}
+	for _, tt := range tests {
+		end, ok := FreeUntil(reservations, "2025-11-20", tt.start)
+		if end != tt.end || ok != tt.ok {
+			t.Errorf("%s: expected %q (%v), got %q (%v)", tt.start, tt.end, tt.ok, end, ok)
+		}
+	}
+}
+
+func TestReservationAt(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", Date: "2025-11-20", StartTime: "13:00", EndTime: "14:30", Status: models.StatusPending},
+		{ID: "r2", Date: "2025-11-20", StartTime: "15:00", EndTime: "16:00", Status: models.StatusCancelled},
+	}
+
+	if r := ReservationAt(reservations, "2025-11-20", "14:00"); r == nil || r.ID != "r1" {
+		t.Errorf("Expected r1 at 14:00, got %v", r)
+	}
+	// 終了時刻ちょうどは空いている
+	if r := ReservationAt(reservations, "2025-11-20", "14:30"); r != nil {
+		t.Errorf("Expected no reservation at 14:30, got %v", r)
+	}
+	// キャンセル済み・別日の予約は無視される
+	if r := ReservationAt(reservations, "2025-11-20", "15:00"); r != nil {
+		t.Errorf("Expected cancelled reservation to be ignored, got %v", r)
+	}
+	if r := ReservationAt(reservations, "2025-11-21", "13:00"); r != nil {
+		t.Errorf("Expected no reservation on another date, got %v", r)
+	}
+}
+
+func TestRoundToSlot(t *testing.T) {
+	tests := []struct {
+		input string
+		up    string
+		down  string
+	}{
+		{"14:00", "14:00", "14:00"},
+		{"14:07", "14:30", "14:00"},
+		{"14:30", "14:30", "14:30"},
+		{"14:59", "15:00", "14:30"},
+	}
+	for _, tt := range tests {
+		if got := RoundUpToSlot(tt.input); got != tt.up {
+			t.Errorf("RoundUpToSlot(%q) = %q, want %q", tt.input, got, tt.up)
+		}
+		if got := RoundDownToSlot(tt.input); got != tt.down {
+			t.Errorf("RoundDownToSlot(%q) = %q, want %q", tt.input, got, tt.down)
+		}
+	}
+}
+
+func TestFilterByDuration(t *testing.T) {
+	slots := []Slot{
+		{Start: "09:00", End: "09:30"},
+		{Start: "11:00", End: "13:00"},
+	}
+
+	filtered := FilterByDuration(slots, time.Hour)
+	if len(filtered) != 1 || filtered[0].Start != "11:00" {
+		t.Errorf("Expected only the 2-hour slot, got %v", filtered)
+	}
+}
+
+func TestNextFreeSlot(t *testing.T) {
+	reservations := []*models.Reservation{
+		{ID: "r1", Date: "2025-11-20", StartTime: "18:00", EndTime: "21:00", Status: models.StatusPending},
+		{ID: "r2", Date: "2025-11-21", StartTime: "09:00", EndTime: "10:00", Status: models.StatusPending},
+	}
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	// 当日は現在時刻を枠に切り上げた時刻から探す
+	now := time.Date(2025, 11, 20, 16, 10, 0, 0, jst)
+	date, slot, ok := NextFreeSlot(reservations, now, 7, time.Hour)
+	if !ok || date != "2025-11-20" || slot != (Slot{Start: "16:30", End: "18:00"}) {
+		t.Errorf("Expected 2025-11-20 16:30-18:00, got %s %v (%v)", date, slot, ok)
+	}
+
+	// 当日に十分な空きがなければ翌日以降を探す
+	now = time.Date(2025, 11, 20, 17, 10, 0, 0, jst)
+	date, slot, ok = NextFreeSlot(reservations, now, 7, time.Hour)
+	if !ok || date != "2025-11-21" || slot.Start != "10:00" {
+		t.Errorf("Expected 2025-11-21 from 10:00, got %s %v (%v)", date, slot, ok)
+	}
+
+	if _, _, ok := NextFreeSlot(reservations, now, 1, time.Hour); ok {
+		t.Error("Expected no free slot within 1 day")
+	}
+}
+
+func TestSetOpeningHours(t *testing.T) {
+	defer func() {
+		OpeningTime = "09:00"
+		ClosingTime = "21:00"
+	}()
+
+	if err := SetOpeningHours("10:00-18:30"); err != nil {
+		t.Fatalf("SetOpeningHours failed: %v", err)
+	}
+	if OpeningTime != "10:00" || ClosingTime != "18:30" {
+		t.Errorf("Expected 10:00-18:30, got %s-%s", OpeningTime, ClosingTime)
+	}
+
+	for _, invalid := range []string{"", "10:00", "18:00-10:00", "25:00-26:00"} {
+		if err := SetOpeningHours(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestSetClosedDays(t *testing.T) {
+	defer SetClosedDays("")
+
+	if err := SetClosedDays("sun, 2025-12-29=年末休み,2025-12-30"); err != nil {
+		t.Fatalf("SetClosedDays failed: %v", err)
+	}
+
+	tests := []struct {
+		date   string
+		reason string
+		closed bool
+	}{
+		{"2025-11-23", "", true}, // 日曜日
+		{"2025-11-24", "", false},
+		{"2025-12-29", "年末休み", true},
+		{"2025-12-30", "", true},
+	}
+	for _, tt := range tests {
+		reason, closed := ClosedOn(tt.date)
+		if reason != tt.reason || closed != tt.closed {
+			t.Errorf("%s: expected %q (%v), got %q (%v)", tt.date, tt.reason, tt.closed, reason, closed)
+		}
+	}
+
+	// 休室日は空き時間なし
+	if slots := FreeSlots(nil, "2025-11-23", ""); len(slots) != 0 {
+		t.Errorf("Expected no free slots on a closed day, got %v", slots)
+	}
+	if free := FreeTime(nil, "2025-11-24", "18:00"); free != 3*time.Hour {
+		t.Errorf("Expected 3h free, got %v", free)
+	}
+
+	for _, invalid := range []string{"someday", "2025-13-01", "2025/12/29"} {
+		if err := SetClosedDays(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
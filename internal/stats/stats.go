@@ -0,0 +1,183 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+// UserUsage はユーザーごとの利用状況を表す
+type UserUsage struct {
+	UserID   string
+	Bookings int // 有効な予約（キャンセル以外）の件数
+	Minutes  int // 有効な予約の合計時間（分）
+}
+
+// Ranked は集計値の順位付けに使う項目を表す
+type Ranked struct {
+	Key     int // 曜日（time.Weekday）または時（0〜23）
+	Minutes int
+}
+
+// Report は期間内の部室の利用状況を表す
+type Report struct {
+	From      string // 集計開始日（YYYY-MM-DD形式）
+	To        string // 集計終了日（YYYY-MM-DD形式）
+	Bookings  int    // 有効な予約（キャンセル以外）の件数
+	Completed int    // 完了した予約の件数
+	Cancelled int    // キャンセルした予約の件数
+	NoShows   int    // 完了した予約のうち無断欠席（models.Reservation.IsNoShow）の件数
+
+	BookedMinutes int // 有効な予約の合計時間（分）
+	OpenMinutes   int // 期間内の開室時間の合計（分、休室日を除く）
+
+	ByWeekday [7]int  // 曜日ごとの予約時間（分）
+	ByHour    [24]int // 時間帯ごとの予約時間（分）
+	Users     map[string]*UserUsage
+}
+
+// Compute は from 〜 to（両端を含む、YYYY-MM-DD形式）の予約から利用状況を集計する
+// 開室時間と休室日は schedule の現在の設定で計算する
+func Compute(reservations []*models.Reservation, from, to string) Report {
+	report := Report{
+		From:  from,
+		To:    to,
+		Users: make(map[string]*UserUsage),
+	}
+
+	start, errFrom := time.Parse("2006-01-02", from)
+	end, errTo := time.Parse("2006-01-02", to)
+	if errFrom != nil || errTo != nil || end.Before(start) {
+		return report
+	}
+
+	openPerDay := schedule.ToMinutes(schedule.ClosingTime) - schedule.ToMinutes(schedule.OpeningTime)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if _, closed := schedule.ClosedOn(day.Format("2006-01-02")); !closed {
+			report.OpenMinutes += openPerDay
+		}
+	}
+
+	for _, r := range reservations {
+		if r.Date < from || r.Date > to {
+			continue
+		}
+
+		switch r.Status {
+		case models.StatusCancelled:
+			report.Cancelled++
+			continue
+		case models.StatusCompleted:
+			report.Completed++
+			if r.IsNoShow() {
+				report.NoShows++
+			}
+		}
+
+		startMinute := schedule.ToMinutes(r.StartTime)
+		endMinute := schedule.ToMinutes(r.EndTime)
+		if startMinute < 0 || endMinute <= startMinute {
+			continue
+		}
+		minutes := endMinute - startMinute
+
+		report.Bookings++
+		report.BookedMinutes += minutes
+
+		if date, err := time.Parse("2006-01-02", r.Date); err == nil {
+			report.ByWeekday[date.Weekday()] += minutes
+		}
+
+		// 時間帯ごとに、その1時間に重なる分数を加算する
+		for hour := startMinute / 60; hour*60 < endMinute && hour < 24; hour++ {
+			overlapStart := max(startMinute, hour*60)
+			overlapEnd := min(endMinute, (hour+1)*60)
+			report.ByHour[hour] += overlapEnd - overlapStart
+		}
+
+		usage, ok := report.Users[r.UserID]
+		if !ok {
+			usage = &UserUsage{UserID: r.UserID}
+			report.Users[r.UserID] = usage
+		}
+		usage.Bookings++
+		usage.Minutes += minutes
+	}
+
+	return report
+}
+
+// UtilizationRate は開室時間に対する予約時間の割合（0〜1）を返す
+func (r Report) UtilizationRate() float64 {
+	if r.OpenMinutes == 0 {
+		return 0
+	}
+	return float64(r.BookedMinutes) / float64(r.OpenMinutes)
+}
+
+// CancelRate は全予約に対するキャンセルの割合（0〜1）を返す
+func (r Report) CancelRate() float64 {
+	total := r.Bookings + r.Cancelled
+	if total == 0 {
+		return 0
+	}
+	return float64(r.Cancelled) / float64(total)
+}
+
+// NoShowRate は完了した予約に対する無断欠席の割合（0〜1）を返す
+func (r Report) NoShowRate() float64 {
+	if r.Completed == 0 {
+		return 0
+	}
+	return float64(r.NoShows) / float64(r.Completed)
+}
+
+// TopUsers は予約時間の多い順に最大 n 人の利用状況を返す
+func (r Report) TopUsers(n int) []UserUsage {
+	users := make([]UserUsage, 0, len(r.Users))
+	for _, usage := range r.Users {
+		users = append(users, *usage)
+	}
+	sort.Slice(users, func(a, b int) bool {
+		if users[a].Minutes != users[b].Minutes {
+			return users[a].Minutes > users[b].Minutes
+		}
+		if users[a].Bookings != users[b].Bookings {
+			return users[a].Bookings > users[b].Bookings
+		}
+		return users[a].UserID < users[b].UserID
+	})
+	if len(users) > n {
+		users = users[:n]
+	}
+	return users
+}
+
+// BusiestWeekdays は予約時間の多い順に最大 n 件の曜日を返す（予約がない曜日は除く）
+func (r Report) BusiestWeekdays(n int) []Ranked {
+	return topRanked(r.ByWeekday[:], n)
+}
+
+// BusiestHours は予約時間の多い順に最大 n 件の時間帯を返す（予約がない時間帯は除く）
+func (r Report) BusiestHours(n int) []Ranked {
+	return topRanked(r.ByHour[:], n)
+}
+
+// topRanked は値の大きい順に最大 n 件を返す（同じ値の場合はキーの小さい順）
+func topRanked(values []int, n int) []Ranked {
+	ranked := make([]Ranked, 0, len(values))
+	for key, minutes := range values {
+		if minutes > 0 {
+			ranked = append(ranked, Ranked{Key: key, Minutes: minutes})
+		}
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		return ranked[a].Minutes > ranked[b].Minutes
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dice/hxs_reservation_system/internal/models"
+	"github.com/dice/hxs_reservation_system/internal/schedule"
+)
+
+func TestCompute(t *testing.T) {
+	// 2025-11-17 は月曜日
+	reservations := []*models.Reservation{
+		{UserID: "u1", Date: "2025-11-17", StartTime: "10:30", EndTime: "12:00", Status: models.StatusCompleted},
+		{UserID: "u1", Date: "2025-11-18", StartTime: "13:00", EndTime: "14:00", Status: models.StatusPending},
+		{UserID: "u2", Date: "2025-11-17", StartTime: "14:00", EndTime: "18:00", Status: models.StatusCompleted},
+		{UserID: "u2", Date: "2025-11-19", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCancelled},
+		// 期間外
+		{UserID: "u3", Date: "2025-11-24", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCompleted},
+	}
+
+	report := Compute(reservations, "2025-11-17", "2025-11-23")
+
+	if report.Bookings != 3 || report.Completed != 2 || report.Cancelled != 1 {
+		t.Errorf("Unexpected counts: bookings=%d completed=%d cancelled=%d", report.Bookings, report.Completed, report.Cancelled)
+	}
+	if report.BookedMinutes != 390 {
+		t.Errorf("Expected 390 booked minutes, got %d", report.BookedMinutes)
+	}
+	// 09:00-21:00 × 7日
+	if report.OpenMinutes != 7*12*60 {
+		t.Errorf("Expected %d open minutes, got %d", 7*12*60, report.OpenMinutes)
+	}
+	if rate := report.UtilizationRate(); rate < 0.077 || rate > 0.078 {
+		t.Errorf("Unexpected utilization rate: %f", rate)
+	}
+	if rate := report.CancelRate(); rate != 0.25 {
+		t.Errorf("Expected cancel rate 0.25, got %f", rate)
+	}
+
+	// 10時台は 10:30-11:00 の30分のみ
+	if report.ByHour[10] != 30 || report.ByHour[11] != 60 || report.ByHour[14] != 60 {
+		t.Errorf("Unexpected hourly distribution: %v", report.ByHour)
+	}
+
+	weekdays := report.BusiestWeekdays(2)
+	if len(weekdays) != 2 || weekdays[0].Key != int(time.Monday) || weekdays[0].Minutes != 330 {
+		t.Errorf("Expected Monday to be busiest, got %v", weekdays)
+	}
+
+	users := report.TopUsers(5)
+	if len(users) != 2 || users[0].UserID != "u2" || users[0].Minutes != 240 || users[1].Bookings != 2 {
+		t.Errorf("Unexpected top users: %v", users)
+	}
+}
+
+func TestComputeNoShows(t *testing.T) {
+	checkedIn := time.Date(2025, 11, 17, 10, 5, 0, 0, time.UTC)
+	reservations := []*models.Reservation{
+		// リマインダーを送ったのにチェックインしなかった
+		{UserID: "u1", Date: "2025-11-17", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCompleted, ReminderSentFor: "2025-11-17 10:00"},
+		// チェックインした
+		{UserID: "u2", Date: "2025-11-17", StartTime: "11:00", EndTime: "12:00", Status: models.StatusCompleted, ReminderSentFor: "2025-11-17 11:00", CheckedInAt: &checkedIn},
+		// 延長した（来ていた）
+		{UserID: "u3", Date: "2025-11-17", StartTime: "13:00", EndTime: "15:00", Status: models.StatusCompleted, ReminderSentFor: "2025-11-17 13:00",
+			History: []models.HistoryEntry{{Action: "extend", ActorID: "u3"}}},
+		// リマインダーを送っていない
+		{UserID: "u4", Date: "2025-11-17", StartTime: "16:00", EndTime: "17:00", Status: models.StatusCompleted},
+	}
+
+	report := Compute(reservations, "2025-11-17", "2025-11-17")
+	if report.NoShows != 1 {
+		t.Errorf("Expected 1 no-show, got %d", report.NoShows)
+	}
+	if rate := report.NoShowRate(); rate != 0.25 {
+		t.Errorf("Expected no-show rate 0.25, got %f", rate)
+	}
+}
+
+func TestComputeExcludesClosedDays(t *testing.T) {
+	defer schedule.SetClosedDays("")
+	if err := schedule.SetClosedDays("sun,2025-11-19"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2025-11-17〜23 のうち水曜日と日曜日は休室日
+	report := Compute(nil, "2025-11-17", "2025-11-23")
+	if report.OpenMinutes != 5*12*60 {
+		t.Errorf("Expected %d open minutes, got %d", 5*12*60, report.OpenMinutes)
+	}
+}
+
+func TestComputeInvalidRange(t *testing.T) {
+	report := Compute(nil, "2025-11-23", "2025-11-17")
+	if report.OpenMinutes != 0 || report.UtilizationRate() != 0 {
+		t.Errorf("Expected empty report for invalid range, got %+v", report)
+	}
+}
@@ -5,38 +5,119 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/dice/hxs_reservation_system/internal/models"
 )
 
-const dataFilePath = "data/reservations.json"
+// defaultDataDir は予約データなどを保存する既定のディレクトリ（作業ディレクトリからの相対パス）
+const defaultDataDir = "data"
+
+// 保存先のディレクトリに置くファイル
+const (
+	dataFileName    = "reservations.json"
+	archiveFileName = "archive.json" // 保持期間を過ぎて削除した予約の保存先
+	usersFileName   = "users.json"   // メンバーごとの設定の保存先
+	stateFileName   = "state.json"   // Botの状態（掲示板のメッセージIDなど）の保存先
+)
 
 // Storage は予約データを管理する
+// 取得した予約は他のゴルーチンと共有されるため変更しないこと。変更は ModifyReservation で行い、
+// 保存済みの予約はコピーを変更して差し替える（取得済みの予約の内容は変わらない）
 type Storage struct {
+	dir          string // 保存先のディレクトリ
 	mu           sync.RWMutex
 	Reservations map[string]*models.Reservation `json:"reservations"`
+	users        map[string]*models.UserSettings
+	state        map[string]string
+
+	listenersMu sync.Mutex
+	listeners   []func()
+}
+
+// ReservationFilter は予約検索の条件を表す
+type ReservationFilter struct {
+	UserID   string                     // 予約者のDiscord ID（空の場合は全ユーザー）
+	Statuses []models.ReservationStatus // 対象ステータス（空の場合は全ステータス）
+	FromDate string                     // 開始日（YYYY-MM-DD形式、空の場合は制限なし）
+	ToDate   string                     // 終了日（YYYY-MM-DD形式、空の場合は制限なし）
+}
+
+// Matches は予約がフィルタ条件に一致するかを返す
+func (f ReservationFilter) Matches(r *models.Reservation) bool {
+	if f.UserID != "" && r.UserID != f.UserID {
+		return false
+	}
+	if f.FromDate != "" && r.Date < f.FromDate {
+		return false
+	}
+	if f.ToDate != "" && r.Date > f.ToDate {
+		return false
+	}
+	if len(f.Statuses) == 0 {
+		return true
+	}
+	for _, status := range f.Statuses {
+		if r.Status == status {
+			return true
+		}
+	}
+	return false
 }
 
-// NewStorage は新しいStorageインスタンスを作成する
+// NewStorage は既定のディレクトリ（data/）に保存するStorageインスタンスを作成する
 func NewStorage() *Storage {
+	return NewStorageIn(defaultDataDir)
+}
+
+// NewStorageIn は dir に保存するStorageインスタンスを作成する（テストでは t.TempDir() を指定する）
+func NewStorageIn(dir string) *Storage {
 	return &Storage{
+		dir:          dir,
 		Reservations: make(map[string]*models.Reservation),
+		users:        make(map[string]*models.UserSettings),
+		state:        make(map[string]string),
+	}
+}
+
+// OnChange は予約が追加・変更・削除されたときに呼ぶ関数を登録する
+// 関数はロックの外で別のゴルーチンから呼ばれるため、続けて変更があった場合は何度も呼ばれる
+func (s *Storage) OnChange(fn func()) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// notifyChange は OnChange で登録された関数を呼ぶ
+func (s *Storage) notifyChange() {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	for _, fn := range s.listeners {
+		go fn()
 	}
 }
 
-// Load はファイルから予約データを読み込む
+// Load はファイルから予約データとメンバーごとの設定、Botの状態を読み込む
 func (s *Storage) Load() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := s.loadUsersLocked(); err != nil {
+		return err
+	}
+	if err := s.loadStateLocked(); err != nil {
+		return err
+	}
+
 	// ファイルが存在しない場合は新規作成
-	if _, err := os.Stat(dataFilePath); os.IsNotExist(err) {
+	if _, err := os.Stat(s.path(dataFileName)); os.IsNotExist(err) {
 		return nil
 	}
 
-	data, err := os.ReadFile(dataFilePath)
+	data, err := os.ReadFile(s.path(dataFileName))
 	if err != nil {
 		return err
 	}
@@ -49,16 +130,152 @@ func (s *Storage) Load() error {
 }
 
 // Save は予約データをファイルに保存する
+// 予約を直接変更した後に呼ばれるため、保存に成功した場合は変更を通知する
 func (s *Storage) Save() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := s.writeLocked(); err != nil {
+		return err
+	}
+	s.notifyChange()
+	return nil
+}
+
+// writeLocked は予約データをファイルに書き込む（呼び出し側でロックを保持すること）
+func (s *Storage) writeLocked() error {
 	data, err := json.MarshalIndent(s.Reservations, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(dataFilePath, data, 0644)
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(dataFileName), data, 0644)
+}
+
+// loadUsersLocked はメンバーごとの設定をファイルから読み込む（呼び出し側でロックを保持すること）
+func (s *Storage) loadUsersLocked() error {
+	data, err := os.ReadFile(s.path(usersFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &s.users)
+}
+
+// loadStateLocked はBotの状態をファイルから読み込む（呼び出し側でロックを保持すること）
+func (s *Storage) loadStateLocked() error {
+	data, err := os.ReadFile(s.path(stateFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &s.state)
+}
+
+// path は保存先のディレクトリにあるファイルのパスを返す
+func (s *Storage) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// GetState は再起動後も保持するBotの状態を取得する（未設定の場合は空文字）
+func (s *Storage) GetState(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.state[key]
+}
+
+// SetState はBotの状態を変更してファイルに保存する（空文字を指定した場合は削除する）
+// 保存に失敗した場合は変更前の状態に戻す
+func (s *Storage) SetState(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.state[key]
+	if value == "" {
+		delete(s.state, key)
+	} else {
+		s.state[key] = value
+	}
+
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(s.dir, 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(s.path(stateFileName), data, 0644)
+	}
+	if err != nil {
+		if existed {
+			s.state[key] = previous
+		} else {
+			delete(s.state, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetUserSettings は指定されたユーザーの設定を取得する（未設定の場合は空の設定）
+func (s *Storage) GetUserSettings(userID string) models.UserSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if settings, ok := s.users[userID]; ok {
+		return *settings
+	}
+	return models.UserSettings{}
+}
+
+// UpdateUserSettings は指定されたユーザーの設定を変更してファイルに保存する
+// 保存に失敗した場合は変更前の設定に戻す
+func (s *Storage) UpdateUserSettings(userID string, update func(settings *models.UserSettings)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.users[userID]
+	updated := models.UserSettings{}
+	if existed {
+		updated = *previous
+	}
+	update(&updated)
+
+	if updated == (models.UserSettings{}) {
+		delete(s.users, userID)
+	} else {
+		s.users[userID] = &updated
+	}
+
+	data, err := json.MarshalIndent(s.users, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(s.dir, 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(s.path(usersFileName), data, 0644)
+	}
+	if err != nil {
+		if existed {
+			s.users[userID] = previous
+		} else {
+			delete(s.users, userID)
+		}
+		return err
+	}
+	return nil
 }
 
 // AddReservation は新しい予約を追加する
@@ -71,10 +288,38 @@ func (s *Storage) AddReservation(reservation *models.Reservation) error {
 	}
 
 	s.Reservations[reservation.ID] = reservation
+	s.notifyChange()
 	return nil
 }
 
-// GetReservation は指定されたIDの予約を取得する
+// InsertReservation は新しい予約の確認・追加・保存を1つのロックの中で行う
+// check には既存のすべての予約が渡され、エラーを返した場合や保存に失敗した場合は何も追加しない
+func (s *Storage) InsertReservation(reservation *models.Reservation, check func(others []*models.Reservation) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.Reservations[reservation.ID]; exists {
+		return errors.New("reservation with this ID already exists")
+	}
+
+	others := make([]*models.Reservation, 0, len(s.Reservations))
+	for _, r := range s.Reservations {
+		others = append(others, r)
+	}
+	if err := check(others); err != nil {
+		return err
+	}
+
+	s.Reservations[reservation.ID] = reservation
+	if err := s.writeLocked(); err != nil {
+		delete(s.Reservations, reservation.ID)
+		return err
+	}
+	s.notifyChange()
+	return nil
+}
+
+// GetReservation は指定されたIDの予約を取得する（返された予約は変更しないこと）
 func (s *Storage) GetReservation(id string) (*models.Reservation, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -87,17 +332,36 @@ func (s *Storage) GetReservation(id string) (*models.Reservation, error) {
 	return reservation, nil
 }
 
-// UpdateReservation は予約情報を更新する
-func (s *Storage) UpdateReservation(reservation *models.Reservation) error {
+// ModifyReservation は予約の確認・変更・保存を1つのロックの中で行う
+// modify には予約のコピーと他のすべての予約が渡され、エラーを返した場合や保存に失敗した場合は何も変更しない
+func (s *Storage) ModifyReservation(id string, modify func(r *models.Reservation, others []*models.Reservation) error) (*models.Reservation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.Reservations[reservation.ID]; !exists {
-		return errors.New("reservation not found")
+	current, exists := s.Reservations[id]
+	if !exists {
+		return nil, errors.New("reservation not found")
 	}
 
-	s.Reservations[reservation.ID] = reservation
-	return nil
+	others := make([]*models.Reservation, 0, len(s.Reservations)-1)
+	for otherID, r := range s.Reservations {
+		if otherID != id {
+			others = append(others, r)
+		}
+	}
+
+	updated := current.Clone()
+	if err := modify(updated, others); err != nil {
+		return nil, err
+	}
+
+	s.Reservations[id] = updated
+	if err := s.writeLocked(); err != nil {
+		s.Reservations[id] = current
+		return nil, err
+	}
+	s.notifyChange()
+	return updated, nil
 }
 
 // GetAllReservations はすべての予約を取得する
@@ -128,18 +392,119 @@ func (s *Storage) GetUserReservations(userID string) []*models.Reservation {
 	return reservations
 }
 
-// CheckOverlap は時間の重複をチェックする
-func (s *Storage) CheckOverlap(newReservation *models.Reservation) (*models.Reservation, error) {
+// FindReservations は条件に一致する予約を日時順で取得する
+func (s *Storage) FindReservations(filter ReservationFilter) []*models.Reservation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reservations := make([]*models.Reservation, 0)
+	for _, r := range s.Reservations {
+		if filter.Matches(r) {
+			reservations = append(reservations, r)
+		}
+	}
+
+	sortByDateTime(reservations)
+	return reservations
+}
+
+// FindArchivedReservations はアーカイブ済みの予約から条件に一致するものを日時順で取得する
+func (s *Storage) FindArchivedReservations(filter ReservationFilter) ([]*models.Reservation, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for _, existing := range s.Reservations {
+	archived, err := s.readArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make([]*models.Reservation, 0)
+	for _, r := range archived {
+		if filter.Matches(r) {
+			reservations = append(reservations, r)
+		}
+	}
+
+	sortByDateTime(reservations)
+	return reservations, nil
+}
+
+// FindHistory は現在の予約とアーカイブ済みの予約の両方から、条件に一致するものを日時順で取得する
+func (s *Storage) FindHistory(filter ReservationFilter) ([]*models.Reservation, error) {
+	archived, err := s.FindArchivedReservations(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := append(s.FindReservations(filter), archived...)
+	sortByDateTime(reservations)
+	return reservations, nil
+}
+
+// sortByDateTime は予約を日付・開始時刻の順に並べ替える
+func sortByDateTime(reservations []*models.Reservation) {
+	sort.Slice(reservations, func(a, b int) bool {
+		if reservations[a].Date != reservations[b].Date {
+			return reservations[a].Date < reservations[b].Date
+		}
+		return reservations[a].StartTime < reservations[b].StartTime
+	})
+}
+
+// readArchive はアーカイブファイルを読み込む（ファイルがない場合は空）
+func (s *Storage) readArchive() (map[string]*models.Reservation, error) {
+	archived := make(map[string]*models.Reservation)
+
+	data, err := os.ReadFile(s.path(archiveFileName))
+	if os.IsNotExist(err) {
+		return archived, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return archived, nil
+	}
+
+	if err := json.Unmarshal(data, &archived); err != nil {
+		return nil, err
+	}
+	return archived, nil
+}
+
+// archiveLocked は削除する予約をアーカイブファイルに追記する（呼び出し側でロックを保持すること）
+func (s *Storage) archiveLocked(reservations []*models.Reservation) error {
+	archived, err := s.readArchive()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range reservations {
+		archived[r.ID] = r
+	}
+
+	data, err := json.MarshalIndent(archived, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(archiveFileName), data, 0644)
+}
+
+// FindOverlap は reservations のうち r と時間が重なる予約を返す（r と同じIDの予約は除く。重ならない場合は nil）
+// ModifyReservation・InsertReservation の中で others との重複を確認する場合に使う
+func FindOverlap(r *models.Reservation, reservations []*models.Reservation) (*models.Reservation, error) {
+	for _, existing := range reservations {
 		// 同じIDの場合はスキップ
-		if existing.ID == newReservation.ID {
+		if existing.ID == r.ID {
 			continue
 		}
 
-		overlaps, err := newReservation.OverlapsWith(existing)
+		overlaps, err := r.OverlapsWith(existing)
 		if err != nil {
 			return nil, err
 		}
@@ -162,6 +527,7 @@ func (s *Storage) DeleteReservation(id string) error {
 	}
 
 	delete(s.Reservations, id)
+	s.notifyChange()
 	return nil
 }
 
@@ -173,7 +539,7 @@ func (s *Storage) AutoCompleteExpiredReservations() (int, error) {
 	now := time.Now()
 	count := 0
 
-	for _, reservation := range s.Reservations {
+	for id, reservation := range s.Reservations {
 		// pending状態の予約のみ対象
 		if reservation.Status != models.StatusPending {
 			continue
@@ -185,29 +551,29 @@ func (s *Storage) AutoCompleteExpiredReservations() (int, error) {
 			return count, fmt.Errorf("failed to parse end time for reservation %s: %w", reservation.ID, err)
 		}
 
-		// 終了時刻が過ぎていればcompletedに変更
+		// 終了時刻が過ぎていればcompletedに変更（取得済みの予約を変えないよう、コピーに差し替える）
 		if endDateTime.Before(now) {
-			reservation.Status = models.StatusCompleted
-			reservation.UpdatedAt = now
+			completed := reservation.Clone()
+			completed.Status = models.StatusCompleted
+			completed.UpdatedAt = now
+			s.Reservations[id] = completed
 			count++
 		}
 	}
 
 	// 変更があった場合は即座に保存
 	if count > 0 {
-		data, err := json.MarshalIndent(s.Reservations, "", "  ")
-		if err != nil {
-			return count, err
-		}
-		if err := os.WriteFile(dataFilePath, data, 0644); err != nil {
+		if err := s.writeLocked(); err != nil {
 			return count, err
 		}
+		s.notifyChange()
 	}
 
 	return count, nil
 }
 
 // CleanupOldReservations は古い完了済み・キャンセル済み予約を削除する
+// 削除した予約は履歴や統計のためにアーカイブファイルへ移す
 // retentionDays: 保持期間（日数）
 func (s *Storage) CleanupOldReservations(retentionDays int) (int, error) {
 	s.mu.Lock()
@@ -231,20 +597,27 @@ func (s *Storage) CleanupOldReservations(retentionDays int) (int, error) {
 		}
 	}
 
-	// 削除を実行
+	// アーカイブに移してから削除を実行（アーカイブに失敗した場合は削除しない）
+	if count > 0 {
+		toArchive := make([]*models.Reservation, 0, len(idsToDelete))
+		for _, id := range idsToDelete {
+			toArchive = append(toArchive, s.Reservations[id])
+		}
+		if err := s.archiveLocked(toArchive); err != nil {
+			return 0, err
+		}
+	}
+
 	for _, id := range idsToDelete {
 		delete(s.Reservations, id)
 	}
 
 	// 削除があった場合は即座に保存
 	if count > 0 {
-		data, err := json.MarshalIndent(s.Reservations, "", "  ")
-		if err != nil {
-			return count, err
-		}
-		if err := os.WriteFile(dataFilePath, data, 0644); err != nil {
+		if err := s.writeLocked(); err != nil {
 			return count, err
 		}
+		s.notifyChange()
 	}
 
 	return count, nil
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -8,7 +9,7 @@ import (
 )
 
 func TestAutoCompleteExpiredReservations(t *testing.T) {
-	store := NewStorage()
+	store := NewStorageIn(t.TempDir())
 
 	// 過去の予約を作成（終了時刻が過ぎている）
 	pastReservation := &models.Reservation{
@@ -97,7 +98,7 @@ func TestAutoCompleteExpiredReservations(t *testing.T) {
 }
 
 func TestCleanupOldReservations(t *testing.T) {
-	store := NewStorage()
+	store := NewStorageIn(t.TempDir())
 
 	// 31日前に完了した予約（削除されるはず）
 	oldCompleted := &models.Reservation{
@@ -195,10 +196,31 @@ func TestCleanupOldReservations(t *testing.T) {
 	if err != nil {
 		t.Error("Expected old pending reservation to exist")
 	}
+
+	// 削除した予約はアーカイブから検索できる
+	archived, err := store.FindArchivedReservations(ReservationFilter{UserID: "user2"})
+	if err != nil {
+		t.Fatalf("FindArchivedReservations failed: %v", err)
+	}
+	if len(archived) != 1 || archived[0].ID != "test-old-cancelled" {
+		t.Errorf("Expected archived cancelled reservation, got %v", archived)
+	}
+
+	history, err := store.FindHistory(ReservationFilter{Statuses: []models.ReservationStatus{models.StatusCompleted}})
+	if err != nil {
+		t.Fatalf("FindHistory failed: %v", err)
+	}
+	ids := make(map[string]bool)
+	for _, r := range history {
+		ids[r.ID] = true
+	}
+	if !ids["test-old-completed"] || !ids["test-recent-completed"] {
+		t.Errorf("Expected history to include archived and live completed reservations, got %v", ids)
+	}
 }
 
 func TestDeleteReservation(t *testing.T) {
-	store := NewStorage()
+	store := NewStorageIn(t.TempDir())
 
 	// テスト用予約を作成
 	reservation := &models.Reservation{
@@ -244,3 +266,204 @@ func TestDeleteReservation(t *testing.T) {
 		t.Error("Expected error when deleting non-existent reservation")
 	}
 }
+
+func TestFindReservations(t *testing.T) {
+	store := NewStorageIn(t.TempDir())
+
+	reservations := []*models.Reservation{
+		{ID: "r1", UserID: "user1", Date: "2025-11-12", StartTime: "14:00", EndTime: "15:00", Status: models.StatusPending},
+		{ID: "r2", UserID: "user1", Date: "2025-11-10", StartTime: "10:00", EndTime: "11:00", Status: models.StatusCancelled},
+		{ID: "r3", UserID: "user2", Date: "2025-11-11", StartTime: "09:00", EndTime: "10:00", Status: models.StatusPending},
+		{ID: "r4", UserID: "user2", Date: "2025-11-20", StartTime: "09:00", EndTime: "10:00", Status: models.StatusCompleted},
+	}
+	for _, r := range reservations {
+		if err := store.AddReservation(r); err != nil {
+			t.Fatalf("Failed to add reservation: %v", err)
+		}
+	}
+
+	// 条件なしの場合はすべての予約が日時順で返る
+	all := store.FindReservations(ReservationFilter{})
+	if len(all) != 4 {
+		t.Fatalf("Expected 4 reservations, got %d", len(all))
+	}
+	if all[0].ID != "r2" || all[3].ID != "r4" {
+		t.Errorf("Expected reservations sorted by date, got %s ... %s", all[0].ID, all[3].ID)
+	}
+
+	// ユーザーで絞り込み
+	if got := store.FindReservations(ReservationFilter{UserID: "user1"}); len(got) != 2 {
+		t.Errorf("Expected 2 reservations for user1, got %d", len(got))
+	}
+
+	// ステータスで絞り込み
+	if got := store.FindReservations(ReservationFilter{Statuses: []models.ReservationStatus{models.StatusPending}}); len(got) != 2 {
+		t.Errorf("Expected 2 pending reservations, got %d", len(got))
+	}
+
+	// 日付範囲で絞り込み（両端を含む）
+	got := store.FindReservations(ReservationFilter{FromDate: "2025-11-11", ToDate: "2025-11-12"})
+	if len(got) != 2 || got[0].ID != "r3" || got[1].ID != "r1" {
+		t.Errorf("Expected r3 and r1 in date range, got %d reservation(s)", len(got))
+	}
+}
+
+func TestModifyReservation(t *testing.T) {
+	store := NewStorageIn(t.TempDir())
+	store.AddReservation(&models.Reservation{ID: "r1", UserID: "user1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending})
+	store.AddReservation(&models.Reservation{ID: "r2", UserID: "user2", Date: "2025-11-20", StartTime: "12:00", EndTime: "13:00", Status: models.StatusPending})
+
+	// エラーを返した場合は変更されない
+	_, err := store.ModifyReservation("r1", func(r *models.Reservation, others []*models.Reservation) error {
+		r.UserID = "user2"
+		return errors.New("rejected")
+	})
+	if err == nil {
+		t.Fatal("Expected error from modify")
+	}
+	if r, _ := store.GetReservation("r1"); r.UserID != "user1" {
+		t.Errorf("Expected reservation to be unchanged, got owner %s", r.UserID)
+	}
+
+	// 他の予約が渡され、変更が反映される
+	before, _ := store.GetReservation("r1")
+	updated, err := store.ModifyReservation("r1", func(r *models.Reservation, others []*models.Reservation) error {
+		if len(others) != 1 || others[0].ID != "r2" {
+			t.Errorf("Expected only r2 as others, got %v", others)
+		}
+		r.UserID = "user2"
+		r.AddWatcher("user3")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ModifyReservation failed: %v", err)
+	}
+	if r, _ := store.GetReservation("r1"); r != updated || r.UserID != "user2" {
+		t.Errorf("Expected updated reservation to be stored, got %+v", r)
+	}
+
+	// 取得済みの予約は変わらない（コピーが差し替えられる）
+	if before.UserID != "user1" || len(before.Watchers) != 0 {
+		t.Errorf("Expected the previously fetched reservation to be unchanged, got %+v", before)
+	}
+
+	if _, err := store.ModifyReservation("missing", func(*models.Reservation, []*models.Reservation) error { return nil }); err == nil {
+		t.Error("Expected error for missing reservation")
+	}
+}
+
+func TestInsertReservation(t *testing.T) {
+	store := NewStorageIn(t.TempDir())
+	store.AddReservation(&models.Reservation{ID: "r1", UserID: "user1", Date: "2025-11-20", StartTime: "10:00", EndTime: "11:00", Status: models.StatusPending})
+	noOverlap := func(r *models.Reservation) func([]*models.Reservation) error {
+		return func(others []*models.Reservation) error {
+			if overlapping, err := FindOverlap(r, others); err != nil || overlapping != nil {
+				return errors.New("overlap")
+			}
+			return nil
+		}
+	}
+
+	// 確認でエラーを返した場合は追加されない
+	overlapping := &models.Reservation{ID: "r2", UserID: "user2", Date: "2025-11-20", StartTime: "10:30", EndTime: "11:30", Status: models.StatusPending}
+	if err := store.InsertReservation(overlapping, noOverlap(overlapping)); err == nil {
+		t.Fatal("Expected error from check")
+	}
+	if _, err := store.GetReservation("r2"); err == nil {
+		t.Error("Expected rejected reservation not to be stored")
+	}
+
+	// 既存の予約が渡され、追加した予約は保存される
+	r3 := &models.Reservation{ID: "r3", UserID: "user2", Date: "2025-11-20", StartTime: "11:00", EndTime: "12:00", Status: models.StatusPending}
+	if err := store.InsertReservation(r3, noOverlap(r3)); err != nil {
+		t.Fatalf("InsertReservation failed: %v", err)
+	}
+	reloaded := NewStorageIn(store.dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.GetReservation("r3"); err != nil {
+		t.Errorf("Expected inserted reservation to be saved, got %v", err)
+	}
+
+	if err := store.InsertReservation(r3, func([]*models.Reservation) error { return nil }); err == nil {
+		t.Error("Expected error for duplicate ID")
+	}
+}
+
+func TestUserSettings(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStorageIn(dir)
+	if settings := store.GetUserSettings("user1"); settings.Locale != "" {
+		t.Errorf("Expected empty settings, got %+v", settings)
+	}
+
+	if err := store.UpdateUserSettings("user1", func(s *models.UserSettings) { s.Locale = "en" }); err != nil {
+		t.Fatalf("UpdateUserSettings failed: %v", err)
+	}
+
+	// ファイルから読み込み直しても設定が残る
+	reloaded := NewStorageIn(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if settings := reloaded.GetUserSettings("user1"); settings.Locale != "en" {
+		t.Errorf("Expected locale en after reload, got %+v", settings)
+	}
+
+	// 空の設定に戻した場合は削除される
+	if err := store.UpdateUserSettings("user1", func(s *models.UserSettings) { s.Locale = "" }); err != nil {
+		t.Fatalf("UpdateUserSettings failed: %v", err)
+	}
+	if _, ok := store.users["user1"]; ok {
+		t.Error("Expected empty settings to be removed")
+	}
+}
+
+func TestState(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStorageIn(dir)
+	if err := store.SetState("board_message_id", "123"); err != nil {
+		t.Fatalf("SetState failed: %v", err)
+	}
+
+	// ファイルから読み込み直しても状態が残る
+	reloaded := NewStorageIn(dir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := reloaded.GetState("board_message_id"); got != "123" {
+		t.Errorf("Expected 123 after reload, got %q", got)
+	}
+
+	// 空文字を指定した場合は削除される
+	if err := store.SetState("board_message_id", ""); err != nil {
+		t.Fatalf("SetState failed: %v", err)
+	}
+	if _, ok := store.state["board_message_id"]; ok {
+		t.Error("Expected empty state to be removed")
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	store := NewStorageIn(t.TempDir())
+	changed := make(chan struct{}, 1)
+	store.OnChange(func() { changed <- struct{}{} })
+
+	store.AddReservation(&models.Reservation{ID: "r1", Status: models.StatusPending})
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected OnChange to be called after AddReservation")
+	}
+
+	// 変更に失敗した場合は通知しない
+	if err := store.DeleteReservation("missing"); err == nil {
+		t.Fatal("Expected error for a missing reservation")
+	}
+	select {
+	case <-changed:
+		t.Error("Expected no notification for a failed change")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
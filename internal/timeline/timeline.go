@@ -0,0 +1,154 @@
+package timeline
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// レイアウト（ピクセル）
+const (
+	imageWidth   = 1000
+	labelWidth   = 120
+	rightPadding = 20
+	titleHeight  = 40
+	axisHeight   = 24
+	rowHeight    = 32
+	barPadding   = 5
+	bottomMargin = 12
+)
+
+var (
+	backgroundColor = color.RGBA{0x2B, 0x2D, 0x31, 0xFF}
+	gridColor       = color.RGBA{0x40, 0x44, 0x4B, 0xFF}
+	rowStripeColor  = color.RGBA{0x31, 0x33, 0x38, 0xFF}
+	textColor       = color.RGBA{0xDB, 0xDE, 0xE1, 0xFF}
+	barTextColor    = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+)
+
+// Bar はタイムライン上の1つの予約を表す
+type Bar struct {
+	StartMinute int        // 開始（0時からの経過分）
+	EndMinute   int        // 終了（0時からの経過分）
+	Label       string     // バー内に表示する文字列（内蔵フォントで描画できる文字のみ）
+	Color       color.RGBA // バーの色
+	Outline     bool       // true の場合は枠線のみで描画する（キャンセル済みなど）
+}
+
+// Row はタイムラインの1行（日付や部屋など）を表す
+type Row struct {
+	Label string // 行の見出し
+	Bars  []Bar
+}
+
+// Chart はガントチャート形式のタイムライン画像の内容を表す
+type Chart struct {
+	Title       string // 画像上部のタイトル
+	StartMinute int    // 横軸の開始（0時からの経過分）
+	EndMinute   int    // 横軸の終了（0時からの経過分）
+	Rows        []Row
+}
+
+// Render はチャートをPNG画像として書き出す
+func Render(w io.Writer, chart Chart) error {
+	return png.Encode(w, Draw(chart))
+}
+
+// Draw はチャートを画像として描画する
+func Draw(chart Chart) *image.RGBA {
+	rows := len(chart.Rows)
+	if rows == 0 {
+		rows = 1
+	}
+	height := titleHeight + axisHeight + rows*rowHeight + bottomMargin
+	img := image.NewRGBA(image.Rect(0, 0, imageWidth, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{backgroundColor}, image.Point{}, draw.Src)
+
+	drawText(img, 12, (titleHeight-glyphHeight*2)/2, chart.Title, textColor, 2)
+
+	span := chart.EndMinute - chart.StartMinute
+	if span <= 0 {
+		return img
+	}
+	plotWidth := imageWidth - labelWidth - rightPadding
+	xOf := func(minute int) int {
+		if minute < chart.StartMinute {
+			minute = chart.StartMinute
+		}
+		if minute > chart.EndMinute {
+			minute = chart.EndMinute
+		}
+		return labelWidth + (minute-chart.StartMinute)*plotWidth/span
+	}
+
+	top := titleHeight + axisHeight
+	bottom := top + rows*rowHeight
+
+	// 行の背景（交互に色を変える）
+	for idx := range chart.Rows {
+		if idx%2 == 1 {
+			y := top + idx*rowHeight
+			fillRect(img, 0, y, imageWidth, y+rowHeight, rowStripeColor)
+		}
+	}
+
+	// 1時間ごとの目盛りと時刻ラベル
+	firstHour := (chart.StartMinute + 59) / 60
+	for hour := firstHour; hour*60 <= chart.EndMinute; hour++ {
+		x := xOf(hour * 60)
+		fillRect(img, x, top, x+1, bottom, gridColor)
+		label := twoDigits(hour)
+		drawText(img, x-textWidth(label, 1)/2, titleHeight+(axisHeight-glyphHeight)/2, label, textColor, 1)
+	}
+
+	// 各行の見出しとバー
+	for idx, row := range chart.Rows {
+		y := top + idx*rowHeight
+		drawText(img, 12, y+(rowHeight-glyphHeight)/2, row.Label, textColor, 1)
+
+		// 枠線のみのバーを先に描画し、有効な予約を上に重ねる
+		for _, outline := range []bool{true, false} {
+			for _, bar := range row.Bars {
+				if bar.Outline != outline {
+					continue
+				}
+				drawBar(img, xOf(bar.StartMinute), y+barPadding, xOf(bar.EndMinute), y+rowHeight-barPadding, bar)
+			}
+		}
+	}
+
+	return img
+}
+
+// drawBar は1つのバーとそのラベルを描画する
+func drawBar(img *image.RGBA, x0, y0, x1, y1 int, bar Bar) {
+	if x1-x0 < 2 {
+		x1 = x0 + 2
+	}
+
+	if bar.Outline {
+		fillRect(img, x0, y0, x1, y0+1, bar.Color)
+		fillRect(img, x0, y1-1, x1, y1, bar.Color)
+		fillRect(img, x0, y0, x0+1, y1, bar.Color)
+		fillRect(img, x1-1, y0, x1, y1, bar.Color)
+	} else {
+		fillRect(img, x0, y0, x1, y1, bar.Color)
+	}
+
+	// 収まる場合のみラベルを描画する
+	if bar.Label != "" && textWidth(bar.Label, 1)+8 <= x1-x0 {
+		drawText(img, x0+4, y0+(y1-y0-glyphHeight)/2, bar.Label, barTextColor, 1)
+	}
+}
+
+// fillRect は矩形を塗りつぶす
+func fillRect(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// twoDigits は 0〜99 の数値を2桁の文字列にする
+func twoDigits(n int) string {
+	return string([]byte{byte('0' + n/10%10), byte('0' + n%10)})
+}
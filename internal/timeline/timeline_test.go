@@ -0,0 +1,47 @@
+package timeline
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	chart := Chart{
+		Title:       "2025/11/20 THU",
+		StartMinute: 9 * 60,
+		EndMinute:   21 * 60,
+		Rows: []Row{
+			{Label: "#1", Bars: []Bar{{StartMinute: 10 * 60, EndMinute: 11 * 60, Label: "#1 ALICE", Color: color.RGBA{0x57, 0xF2, 0x87, 0xFF}}}},
+			{Label: "#2", Bars: []Bar{{StartMinute: 13 * 60, EndMinute: 14 * 60, Color: color.RGBA{0xED, 0x42, 0x45, 0xFF}, Outline: true}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, chart); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Rendered image is not a valid PNG: %v", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() != imageWidth {
+		t.Errorf("Expected width %d, got %d", imageWidth, bounds.Dx())
+	}
+	if expected := titleHeight + axisHeight + 2*rowHeight + bottomMargin; bounds.Dy() != expected {
+		t.Errorf("Expected height %d, got %d", expected, bounds.Dy())
+	}
+}
+
+func TestCanRender(t *testing.T) {
+	if !CanRender("alice_01") {
+		t.Error("Expected ASCII name to be renderable")
+	}
+	if CanRender("山田") {
+		t.Error("Expected Japanese name not to be renderable")
+	}
+}